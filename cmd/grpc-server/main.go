@@ -1,20 +1,23 @@
 package main
 
 import (
-	"fmt"
+	"context"
 	"log"
+	nethttp "net/http"
 	"os"
 	"strconv"
 
-	_ "github.com/go-sql-driver/mysql"
-	"github.com/jmoiron/sqlx"
 	"github.com/joho/godotenv"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
 
 	"github.com/unkabogaton/github-users/internal/application/cache"
 	"github.com/unkabogaton/github-users/internal/application/services"
+	"github.com/unkabogaton/github-users/internal/infrastructure/database"
 	"github.com/unkabogaton/github-users/internal/infrastructure/database/repositories"
 	grpcserver "github.com/unkabogaton/github-users/internal/infrastructure/grpc"
 	httpclient "github.com/unkabogaton/github-users/internal/infrastructure/http"
+	"github.com/unkabogaton/github-users/internal/infrastructure/metrics"
 )
 
 func main() {
@@ -25,37 +28,109 @@ func main() {
 		grpcAddress = ":9090"
 	}
 
-	dbUser := os.Getenv("DB_USER")
-	dbPassword := os.Getenv("DB_PASSWORD")
-	dbHost := os.Getenv("DB_HOST")
-	dbPort := os.Getenv("DB_PORT")
-	dbName := os.Getenv("DB_NAME")
-
-	dsn := fmt.Sprintf("%s:%s@tcp(%s:%s)/%s?parseTime=true&multiStatements=true",
-		dbUser, dbPassword, dbHost, dbPort, dbName)
-
-	database, databaseErr := sqlx.Open("mysql", dsn)
+	database, databaseErr := database.Open(database.ConnectionOptionsFromEnvironment("DB_HOST"))
 	if databaseErr != nil {
 		log.Fatalf("failed to open database: %v", databaseErr)
 	}
 	defer database.Close()
 
 	userRepository := repositories.NewUserRepository(database)
+	followRepository := repositories.NewFollowRepository(database)
+	organizationRepository := repositories.NewOrganizationRepository(database)
+	repoRepository := repositories.NewRepoRepository(database)
+
+	redisOptions, redisOptionsErr := cache.RedisOptionsFromEnvironment()
+	if redisOptionsErr != nil {
+		log.Fatalf("failed to configure Redis: %v", redisOptionsErr)
+	}
+	redisClient, redisClientErr := cache.NewRedisClient(context.Background(), redisOptions)
+	if redisClientErr != nil {
+		log.Fatalf("failed to connect to Redis: %v", redisClientErr)
+	}
+	defer redisClient.Close()
 
-	redisAddress := os.Getenv("REDIS_ADDRESS")
-	redisPassword := os.Getenv("REDIS_PASSWORD")
 	redisTTLString := os.Getenv("REDIS_TTL_SEC")
 	if redisTTLString == "" {
 		redisTTLString = "300"
 	}
 	redisTTLSeconds, _ := strconv.Atoi(redisTTLString)
-	redisCache := cache.NewRedisCache(redisAddress, redisPassword, redisTTLSeconds)
+	redisStaleTTLString := os.Getenv("REDIS_STALE_TTL_SEC")
+	if redisStaleTTLString == "" {
+		redisStaleTTLString = "3600"
+	}
+	redisStaleTTLSeconds, _ := strconv.Atoi(redisStaleTTLString)
+	redisNotFoundTTLString := os.Getenv("REDIS_NOT_FOUND_TTL_SEC")
+	if redisNotFoundTTLString == "" {
+		redisNotFoundTTLString = "60"
+	}
+	redisNotFoundTTLSeconds, _ := strconv.Atoi(redisNotFoundTTLString)
+	userCodec, userCodecErr := cache.UserCodecByName(os.Getenv("CACHE_CODEC"))
+	if userCodecErr != nil {
+		log.Fatalf("failed to configure cache: %v", userCodecErr)
+	}
+	cacheCallLogging, _ := strconv.ParseBool(os.Getenv("CACHE_LOG_CALLS"))
+	cacheMetrics := metrics.NewCacheMetrics(prometheus.DefaultRegisterer)
+	redisCache := cache.NewRedisCache(redisClient, redisTTLSeconds, redisStaleTTLSeconds, redisNotFoundTTLSeconds, userCodec, cacheCallLogging)
 
-	gitHubToken := os.Getenv("GITHUB_TOKEN")
-	gitHubClient := httpclient.NewGitHubClient(gitHubToken)
-	userService := services.NewUserService(userRepository, redisCache, gitHubClient)
+	userCache := cache.NewInstrumentedCache(redisCache, "redis", cacheMetrics)
+	localCacheMaximumEntries, localCacheSizeErr := strconv.Atoi(os.Getenv("LOCAL_CACHE_MAX_ENTRIES"))
+	if localCacheSizeErr != nil {
+		localCacheMaximumEntries = 10000
+	}
+	localCacheTTLSeconds, localCacheTTLErr := strconv.Atoi(os.Getenv("LOCAL_CACHE_TTL_SEC"))
+	if localCacheTTLErr != nil {
+		localCacheTTLSeconds = 30
+	}
+	if localCacheMaximumEntries > 0 {
+		localCache := cache.NewTieredCache(userCache, redisClient, localCacheMaximumEntries, localCacheTTLSeconds)
+		userCache = cache.NewInstrumentedCache(localCache, "local", cacheMetrics)
+	}
+
+	userListCacheTTLSeconds, userListCacheTTLErr := strconv.Atoi(os.Getenv("USER_LIST_CACHE_TTL_SEC"))
+	if userListCacheTTLErr == nil && userListCacheTTLSeconds > 0 {
+		userListCache := cache.NewRedisUserListCache(redisClient, userListCacheTTLSeconds)
+		userRepository = cache.NewListCachingUserRepository(userRepository, userListCache)
+	}
+
+	validatorStoreMaximumEntries, validatorStoreSizeErr := strconv.Atoi(os.Getenv("GITHUB_VALIDATOR_MAX_ENTRIES"))
+	if validatorStoreSizeErr != nil {
+		validatorStoreMaximumEntries = 10000
+	}
+	validatorStore := cache.NewMemoryValidatorStore(validatorStoreMaximumEntries)
+	if os.Getenv("GITHUB_VALIDATOR_STORE") == "redis" {
+		validatorTTLSeconds, validatorTTLErr := strconv.Atoi(os.Getenv("GITHUB_VALIDATOR_TTL_SEC"))
+		if validatorTTLErr != nil {
+			validatorTTLSeconds = 86400
+		}
+		validatorStore = cache.NewRedisValidatorStore(redisClient, validatorTTLSeconds)
+	}
+
+	gitHubClientOptions, gitHubOptionsErr := httpclient.GitHubClientOptionsFromEnvironment()
+	if gitHubOptionsErr != nil {
+		log.Fatalf("failed to configure GitHub client: %v", gitHubOptionsErr)
+	}
+	gitHubClientOptions.ValidatorStore = validatorStore
+	gitHubClient, gitHubClientErr := httpclient.NewGitHubClientWithOptions(gitHubClientOptions)
+	if gitHubClientErr != nil {
+		log.Fatalf("failed to create GitHub client: %v", gitHubClientErr)
+	}
+	userService := services.NewUserService(userRepository, userCache, gitHubClient)
+	followService := services.NewFollowService(followRepository, userRepository)
+	organizationService := services.NewOrganizationService(organizationRepository, userRepository)
+	repositoryService := services.NewRepositoryService(repoRepository, userRepository)
+
+	if metricsAddress := os.Getenv("METRICS_ADDRESS"); metricsAddress != "" {
+		metricsMux := nethttp.NewServeMux()
+		metricsMux.Handle("/metrics", promhttp.Handler())
+		go func() {
+			log.Printf("Serving metrics on %s", metricsAddress)
+			if err := nethttp.ListenAndServe(metricsAddress, metricsMux); err != nil {
+				log.Printf("metrics server failed: %v", err)
+			}
+		}()
+	}
 
-	server := grpcserver.NewServer(userService)
+	server := grpcserver.NewServer(userService, followService, organizationService, repositoryService, gitHubClient)
 	log.Printf("Starting gRPC server on %s", grpcAddress)
 	if err := server.ListenAndServe(grpcAddress); err != nil {
 		log.Fatalf("gRPC server failed: %v", err)
@@ -1,22 +1,26 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"os"
 	"strconv"
 
 	"github.com/gin-gonic/gin"
-	_ "github.com/go-sql-driver/mysql"
-	"github.com/jmoiron/sqlx"
 	"github.com/joho/godotenv"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
 
 	"github.com/unkabogaton/github-users/internal/application/cache"
 	"github.com/unkabogaton/github-users/internal/application/services"
+	"github.com/unkabogaton/github-users/internal/domain/interfaces"
+	"github.com/unkabogaton/github-users/internal/infrastructure/database"
 	"github.com/unkabogaton/github-users/internal/infrastructure/database/repositories"
 	"github.com/unkabogaton/github-users/internal/infrastructure/http"
 	"github.com/unkabogaton/github-users/internal/infrastructure/http/controllers"
 	"github.com/unkabogaton/github-users/internal/infrastructure/http/middleware"
+	"github.com/unkabogaton/github-users/internal/infrastructure/metrics"
 )
 
 func main() {
@@ -26,45 +30,122 @@ func main() {
 		restServerAddress = ":8080"
 	}
 
-	dbUser := os.Getenv("DB_USER")
-	dbPassword := os.Getenv("DB_PASSWORD")
-	dbHost := os.Getenv("DB_HOST")
-	dbPort := os.Getenv("DB_PORT")
-	dbName := os.Getenv("DB_NAME")
-
-	dsn := fmt.Sprintf("%s:%s@tcp(%s:%s)/%s?parseTime=true&multiStatements=true",
-		dbUser, dbPassword, dbHost, dbPort, dbName)
-
-	database, databaseErr := sqlx.Open("mysql", dsn)
+	database, databaseErr := database.Open(database.ConnectionOptionsFromEnvironment("DB_HOST"))
 
 	if databaseErr != nil {
 		panic(fmt.Errorf("failed to open database: %w", databaseErr))
 	}
 	defer database.Close()
 	userRepository := repositories.NewUserRepository(database)
+	followRepository := repositories.NewFollowRepository(database)
+	organizationRepository := repositories.NewOrganizationRepository(database)
+	repoRepository := repositories.NewRepoRepository(database)
+
+	redisOptions, redisOptionsErr := cache.RedisOptionsFromEnvironment()
+	if redisOptionsErr != nil {
+		log.Fatalf("failed to configure Redis: %v", redisOptionsErr)
+	}
+	redisClient, redisClientErr := cache.NewRedisClient(context.Background(), redisOptions)
+	if redisClientErr != nil {
+		log.Fatalf("failed to connect to Redis: %v", redisClientErr)
+	}
+	defer redisClient.Close()
 
-	redisAddress := os.Getenv("REDIS_ADDRESS")
-	redisPassword := os.Getenv("REDIS_PASSWORD")
 	redisTTLString := os.Getenv("REDIS_TTL_SEC")
 	if redisTTLString == "" {
 		redisTTLString = "300"
 	}
 	redisTTLSeconds, _ := strconv.Atoi(redisTTLString)
-	redisCache := cache.NewRedisCache(redisAddress, redisPassword, redisTTLSeconds)
+	redisStaleTTLString := os.Getenv("REDIS_STALE_TTL_SEC")
+	if redisStaleTTLString == "" {
+		redisStaleTTLString = "3600"
+	}
+	redisStaleTTLSeconds, _ := strconv.Atoi(redisStaleTTLString)
+	redisNotFoundTTLString := os.Getenv("REDIS_NOT_FOUND_TTL_SEC")
+	if redisNotFoundTTLString == "" {
+		redisNotFoundTTLString = "60"
+	}
+	redisNotFoundTTLSeconds, _ := strconv.Atoi(redisNotFoundTTLString)
+	userCodec, userCodecErr := cache.UserCodecByName(os.Getenv("CACHE_CODEC"))
+	if userCodecErr != nil {
+		log.Fatalf("failed to configure cache: %v", userCodecErr)
+	}
+	cacheCallLogging, _ := strconv.ParseBool(os.Getenv("CACHE_LOG_CALLS"))
+	cacheMetrics := metrics.NewCacheMetrics(prometheus.DefaultRegisterer)
+	redisCache := cache.NewRedisCache(redisClient, redisTTLSeconds, redisStaleTTLSeconds, redisNotFoundTTLSeconds, userCodec, cacheCallLogging)
+	cachesByName := map[string]interfaces.Cache{"redis": redisCache}
 
-	gitHubToken := os.Getenv("GITHUB_TOKEN")
-	gitHubClient := http.NewGitHubClient(gitHubToken)
-	userService := services.NewUserService(userRepository, redisCache, gitHubClient)
+	userCache := cache.NewInstrumentedCache(redisCache, "redis", cacheMetrics)
+	localCacheMaximumEntries, localCacheSizeErr := strconv.Atoi(os.Getenv("LOCAL_CACHE_MAX_ENTRIES"))
+	if localCacheSizeErr != nil {
+		localCacheMaximumEntries = 10000
+	}
+	localCacheTTLSeconds, localCacheTTLErr := strconv.Atoi(os.Getenv("LOCAL_CACHE_TTL_SEC"))
+	if localCacheTTLErr != nil {
+		localCacheTTLSeconds = 30
+	}
+	if localCacheMaximumEntries > 0 {
+		localCache := cache.NewTieredCache(userCache, redisClient, localCacheMaximumEntries, localCacheTTLSeconds)
+		cachesByName["local"] = localCache
+		userCache = cache.NewInstrumentedCache(localCache, "local", cacheMetrics)
+	}
+
+	userListCacheTTLSeconds, userListCacheTTLErr := strconv.Atoi(os.Getenv("USER_LIST_CACHE_TTL_SEC"))
+	if userListCacheTTLErr == nil && userListCacheTTLSeconds > 0 {
+		userListCache := cache.NewRedisUserListCache(redisClient, userListCacheTTLSeconds)
+		userRepository = cache.NewListCachingUserRepository(userRepository, userListCache)
+	}
+
+	validatorStoreMaximumEntries, validatorStoreSizeErr := strconv.Atoi(os.Getenv("GITHUB_VALIDATOR_MAX_ENTRIES"))
+	if validatorStoreSizeErr != nil {
+		validatorStoreMaximumEntries = 10000
+	}
+	validatorStore := cache.NewMemoryValidatorStore(validatorStoreMaximumEntries)
+	if os.Getenv("GITHUB_VALIDATOR_STORE") == "redis" {
+		validatorTTLSeconds, validatorTTLErr := strconv.Atoi(os.Getenv("GITHUB_VALIDATOR_TTL_SEC"))
+		if validatorTTLErr != nil {
+			validatorTTLSeconds = 86400
+		}
+		validatorStore = cache.NewRedisValidatorStore(redisClient, validatorTTLSeconds)
+	}
+
+	gitHubClientOptions, gitHubOptionsErr := http.GitHubClientOptionsFromEnvironment()
+	if gitHubOptionsErr != nil {
+		log.Fatalf("failed to configure GitHub client: %v", gitHubOptionsErr)
+	}
+	gitHubClientOptions.ValidatorStore = validatorStore
+	gitHubClient, gitHubClientErr := http.NewGitHubClientWithOptions(gitHubClientOptions)
+	if gitHubClientErr != nil {
+		log.Fatalf("failed to create GitHub client: %v", gitHubClientErr)
+	}
+	userService := services.NewUserService(userRepository, userCache, gitHubClient)
+	followService := services.NewFollowService(followRepository, userRepository)
+	organizationService := services.NewOrganizationService(organizationRepository, userRepository)
+	repositoryService := services.NewRepositoryService(repoRepository, userRepository)
 
 	router := gin.Default()
 	router.SetTrustedProxies(nil)
 	router.Use(middleware.ErrorHandlingMiddleware())
 	userController := controllers.NewUserController(userService)
+	gitHubController := controllers.NewGitHubController(userService, gitHubClient)
+	followController := controllers.NewFollowController(followService)
+	organizationController := controllers.NewOrganizationController(organizationService)
+	repositoryController := controllers.NewRepositoryController(repositoryService)
+	cacheController := controllers.NewCacheController(cacheMetrics, cachesByName)
 
 	router.GET("/users", userController.ListUsers)
 	router.PUT("/users/:username", userController.UpdateUser)
 	router.GET("/users/:username", userController.GetUser)
 	router.DELETE("/users/:username", userController.DeleteUser)
+	router.GET("/users/:username/followers", followController.ListFollowers)
+	router.GET("/users/:username/following", followController.ListFollowing)
+	router.GET("/users/:username/orgs", organizationController.ListUserOrganizations)
+	router.GET("/users/:username/repos", repositoryController.ListUserRepositories)
+	router.GET("/orgs/:org/users", organizationController.ListMembers)
+	router.GET("/github/rate-limit", gitHubController.GetRateLimits)
+	router.GET("/github/search/users", gitHubController.SearchUsers)
+	router.GET("/admin/cache/stats", cacheController.GetStats)
+	router.GET("/metrics", gin.WrapH(promhttp.Handler()))
 
 	log.Printf("Starting REST server on %s", restServerAddress)
 	if runError := router.Run(restServerAddress); runError != nil {
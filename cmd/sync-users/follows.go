@@ -0,0 +1,81 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/unkabogaton/github-users/internal/domain/entities"
+	"github.com/unkabogaton/github-users/internal/domain/interfaces"
+)
+
+// syncFollows crawls the followers and following lists of every stored user
+// and replaces that user's edges in user_follows with what GitHub returns.
+// The users on the other end are stored as summaries, so that the follow
+// listings, which join onto github_users, include them.
+func syncFollows(
+	applicationContext context.Context,
+	userRepository interfaces.UserRepository,
+	followRepository interfaces.FollowRepository,
+	gitHubClient interfaces.GitHubClient,
+	gitHubCredentials interfaces.GitHubCredentials,
+) {
+	crawlStoredUsers(applicationContext, userRepository, gitHubClient, gitHubCredentials,
+		func(ctx context.Context, storedUser entities.User) error {
+			return crawlFollows(ctx, userRepository, followRepository, gitHubClient, storedUser)
+		},
+	)
+	fmt.Println("GitHub follow graph synchronization complete.")
+}
+
+func crawlFollows(
+	ctx context.Context,
+	userRepository interfaces.UserRepository,
+	followRepository interfaces.FollowRepository,
+	gitHubClient interfaces.GitHubClient,
+	storedUser entities.User,
+) error {
+	followers, err := gitHubClient.FetchFollowers(ctx, storedUser.Login)
+	if err != nil {
+		return fmt.Errorf("fetch followers: %w", err)
+	}
+	if err := storeUserSummaries(ctx, userRepository, followers); err != nil {
+		return fmt.Errorf("store followers: %w", err)
+	}
+	followerEdges := make([]entities.UserFollow, 0, len(followers))
+	for _, follower := range followers {
+		followerEdges = append(followerEdges, entities.UserFollow{FollowerID: follower.ID, FollowingID: storedUser.ID})
+	}
+	if err := followRepository.ReplaceFollowers(ctx, storedUser.ID, followerEdges); err != nil {
+		return fmt.Errorf("store followers: %w", err)
+	}
+
+	following, err := gitHubClient.FetchFollowing(ctx, storedUser.Login)
+	if err != nil {
+		return fmt.Errorf("fetch following: %w", err)
+	}
+	if err := storeUserSummaries(ctx, userRepository, following); err != nil {
+		return fmt.Errorf("store following: %w", err)
+	}
+	followingEdges := make([]entities.UserFollow, 0, len(following))
+	for _, followed := range following {
+		followingEdges = append(followingEdges, entities.UserFollow{FollowerID: storedUser.ID, FollowingID: followed.ID})
+	}
+	if err := followRepository.ReplaceFollowing(ctx, storedUser.ID, followingEdges); err != nil {
+		return fmt.Errorf("store following: %w", err)
+	}
+	return nil
+}
+
+// storeUserSummaries stores the summaries of a follow list without touching
+// the profiles of users already stored.
+func storeUserSummaries(ctx context.Context, userRepository interfaces.UserRepository, gitHubUsers []entities.GitHubUser) error {
+	if len(gitHubUsers) == 0 {
+		return nil
+	}
+	summaries := make([]entities.User, 0, len(gitHubUsers))
+	for _, gitHubUser := range gitHubUsers {
+		summaries = append(summaries, gitHubUser.ToUser())
+	}
+	_, err := userRepository.BatchUpsertSummaries(ctx, &summaries)
+	return err
+}
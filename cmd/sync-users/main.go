@@ -8,13 +8,12 @@ import (
 	"sync"
 	"time"
 
-	_ "github.com/go-sql-driver/mysql"
-	"github.com/jmoiron/sqlx"
 	"github.com/joho/godotenv"
 
 	"github.com/unkabogaton/github-users/internal/application/cache"
 	"github.com/unkabogaton/github-users/internal/domain/entities"
 	"github.com/unkabogaton/github-users/internal/domain/interfaces"
+	"github.com/unkabogaton/github-users/internal/infrastructure/database"
 	"github.com/unkabogaton/github-users/internal/infrastructure/database/repositories"
 	"github.com/unkabogaton/github-users/internal/infrastructure/http"
 )
@@ -28,49 +27,187 @@ func convertEnvConfigToInt(key string, defaultValue int) int {
 	return defaultValue
 }
 
-func main() {
-	_ = godotenv.Load()
+func reportRateLimits(gitHubClient interfaces.GitHubClient, gitHubCredentials interfaces.GitHubCredentials) {
+	for _, rateLimitStatus := range gitHubClient.RateLimits() {
+		fmt.Printf(
+			"rate limit (%s): %d/%d remaining, resets at %s\n",
+			rateLimitStatus.Resource,
+			rateLimitStatus.Remaining,
+			rateLimitStatus.Limit,
+			rateLimitStatus.ResetAt.Format(time.RFC3339),
+		)
+	}
 
-	applicationContext := context.Background()
+	tokenUsageReporter, ok := gitHubCredentials.(interfaces.TokenUsageReporter)
+	if !ok {
+		return
+	}
+	for _, tokenUsage := range tokenUsageReporter.TokenUsage() {
+		fmt.Printf(
+			"token %s: %d requests, %d/%d remaining, resets at %s\n",
+			tokenUsage.Token,
+			tokenUsage.Requests,
+			tokenUsage.Remaining,
+			tokenUsage.Limit,
+			tokenUsage.ResetAt.Format(time.RFC3339),
+		)
+	}
+}
 
-	var (
-		usersPerPage            = convertEnvConfigToInt("USERS_PER_PAGE", 30)
-		workerPoolSize          = convertEnvConfigToInt("WORKER_POOL_SIZE", 5)
-		maximumFetchRetries     = convertEnvConfigToInt("MAXIMUM_FETCH_RETRIES", 3)
-		delayBetweenUpsertsMS   = convertEnvConfigToInt("DELAY_BETWEEN_UPSERTS_MS", 200)
-		maximumConsecutiveEmpty = convertEnvConfigToInt("MAXIMUM_CONSECUTIVE_EMPTY", 1)
-	)
+// storeUser queues a user taken from a list endpoint for batchWriter. List
+// endpoints only return summaries; with fetchFullProfile the profile endpoint
+// is asked for name, company, bio and the follower counts first. Summaries
+// are stored without touching the profile columns of users stored earlier.
+func storeUser(
+	ctx context.Context,
+	batchWriter *userBatchWriter,
+	gitHubClient interfaces.GitHubClient,
+	fetchedUser entities.GitHubUser,
+	fetchFullProfile bool,
+) {
+	if fetchFullProfile {
+		profile, profileErr := gitHubClient.FetchOne(ctx, fetchedUser.Login)
+		if profileErr == nil {
+			profileFetchedAt := time.Now()
+			userRecord := profile.ToUser()
+			userRecord.ProfileFetchedAt = &profileFetchedAt
+			batchWriter.AddProfile(userRecord)
+			return
+		}
+		fmt.Fprintf(
+			os.Stderr,
+			"profile fetch error (login %s, id %d): %v\n",
+			fetchedUser.Login,
+			fetchedUser.ID,
+			profileErr,
+		)
+	}
 
-	dbUser := os.Getenv("DB_USER")
-	dbPassword := os.Getenv("DB_PASSWORD")
-	dbHost := os.Getenv("DB_LOCAL_HOST")
-	dbPort := os.Getenv("DB_PORT")
-	dbName := os.Getenv("DB_NAME")
+	batchWriter.AddSummary(fetchedUser.ToUser())
+}
 
-	dsn := fmt.Sprintf("%s:%s@tcp(%s:%s)/%s?parseTime=true&multiStatements=true",
-		dbUser, dbPassword, dbHost, dbPort, dbName)
+// reportBatchTotals prints how many users batchWriter stored.
+func reportBatchTotals(batchWriter *userBatchWriter) {
+	totals, failed := batchWriter.Flush()
+	fmt.Printf("users stored: %d inserted, %d updated, %d failed\n", totals.Inserted, totals.Updated, failed)
+}
+
+func main() {
+	_ = godotenv.Load()
 
-	database, databaseErr := sqlx.Open("mysql", dsn)
+	applicationContext := context.Background()
+
+	database, databaseErr := database.Open(database.ConnectionOptionsFromEnvironment("DB_LOCAL_HOST"))
 	if databaseErr != nil {
 		panic(fmt.Errorf("failed to open database: %w", databaseErr))
 	}
 	defer database.Close()
 
 	userRepository := repositories.NewUserRepository(database)
+	followRepository := repositories.NewFollowRepository(database)
+	organizationRepository := repositories.NewOrganizationRepository(database)
+	repoRepository := repositories.NewRepoRepository(database)
+
+	redisOptions, redisOptionsErr := cache.RedisOptionsFromEnvironment()
+	if redisOptionsErr != nil {
+		panic(fmt.Errorf("failed to configure Redis: %w", redisOptionsErr))
+	}
+	redisClient, redisClientErr := cache.NewRedisClient(context.Background(), redisOptions)
+	if redisClientErr != nil {
+		panic(fmt.Errorf("failed to connect to Redis: %w", redisClientErr))
+	}
+	defer redisClient.Close()
 
-	redisAddress := os.Getenv("REDIS_ADDRESS")
-	redisPassword := os.Getenv("REDIS_PASSWORD")
 	redisTTLString := os.Getenv("REDIS_TTL_SEC")
 	if redisTTLString == "" {
 		redisTTLString = "300"
 	}
 	redisTTLSeconds, _ := strconv.Atoi(redisTTLString)
-	_ = cache.NewRedisCache(redisAddress, redisPassword, redisTTLSeconds)
+	redisStaleTTLString := os.Getenv("REDIS_STALE_TTL_SEC")
+	if redisStaleTTLString == "" {
+		redisStaleTTLString = "3600"
+	}
+	redisStaleTTLSeconds, _ := strconv.Atoi(redisStaleTTLString)
+	redisNotFoundTTLString := os.Getenv("REDIS_NOT_FOUND_TTL_SEC")
+	if redisNotFoundTTLString == "" {
+		redisNotFoundTTLString = "60"
+	}
+	redisNotFoundTTLSeconds, _ := strconv.Atoi(redisNotFoundTTLString)
+	userCodec, userCodecErr := cache.UserCodecByName(os.Getenv("CACHE_CODEC"))
+	if userCodecErr != nil {
+		panic(fmt.Errorf("failed to configure cache: %w", userCodecErr))
+	}
+	cacheCallLogging, _ := strconv.ParseBool(os.Getenv("CACHE_LOG_CALLS"))
+	redisCache := cache.NewRedisCache(redisClient, redisTTLSeconds, redisStaleTTLSeconds, redisNotFoundTTLSeconds, userCodec, cacheCallLogging)
+	// The servers keep local copies when LOCAL_CACHE_MAX_ENTRIES is set; go
+	// through a tiered cache as well so that cleared tombstones reach them.
+	if localCacheMaximumEntries := convertEnvConfigToInt("LOCAL_CACHE_MAX_ENTRIES", 10000); localCacheMaximumEntries > 0 {
+		redisCache = cache.NewTieredCache(redisCache, redisClient, localCacheMaximumEntries, convertEnvConfigToInt("LOCAL_CACHE_TTL_SEC", 30))
+	}
+	// Synced users have to invalidate the pages the servers cached.
+	if userListCacheTTLSeconds := convertEnvConfigToInt("USER_LIST_CACHE_TTL_SEC", 0); userListCacheTTLSeconds > 0 {
+		userListCache := cache.NewRedisUserListCache(redisClient, userListCacheTTLSeconds)
+		userRepository = cache.NewListCachingUserRepository(userRepository, userListCache)
+	}
 
-	gitHubAccessToken := os.Getenv("GITHUB_TOKEN")
-	gitHubClient := http.NewGitHubClient(gitHubAccessToken)
+	validatorStore := cache.NewMemoryValidatorStore(convertEnvConfigToInt("GITHUB_VALIDATOR_MAX_ENTRIES", 10000))
+	if os.Getenv("GITHUB_VALIDATOR_STORE") == "redis" {
+		validatorStore = cache.NewRedisValidatorStore(
+			redisClient,
+			convertEnvConfigToInt("GITHUB_VALIDATOR_TTL_SEC", 86400),
+		)
+	}
 
-	userChannel := make(chan entities.User, usersPerPage*workerPoolSize)
+	gitHubClientOptions, gitHubOptionsErr := http.GitHubClientOptionsFromEnvironment()
+	if gitHubOptionsErr != nil {
+		panic(fmt.Errorf("failed to configure GitHub client: %w", gitHubOptionsErr))
+	}
+	gitHubClientOptions.ValidatorStore = validatorStore
+	gitHubClient, gitHubClientErr := http.NewGitHubClientWithOptions(gitHubClientOptions)
+	if gitHubClientErr != nil {
+		panic(fmt.Errorf("failed to create GitHub client: %w", gitHubClientErr))
+	}
+	gitHubCredentials := gitHubClientOptions.Credentials
+
+	switch syncMode := os.Getenv("SYNC_MODE"); syncMode {
+	case "", "users":
+		syncUsers(applicationContext, userRepository, redisCache, gitHubClient, gitHubCredentials)
+	case "follows":
+		syncFollows(applicationContext, userRepository, followRepository, gitHubClient, gitHubCredentials)
+	case "org":
+		syncOrganizationMembers(applicationContext, os.Getenv("SYNC_ORG"), userRepository, redisCache, organizationRepository, gitHubClient, gitHubCredentials)
+	case "user-orgs":
+		syncUserOrganizations(applicationContext, userRepository, organizationRepository, gitHubClient, gitHubCredentials)
+	case "repos":
+		syncRepositories(applicationContext, userRepository, repoRepository, gitHubClient, gitHubCredentials)
+	default:
+		panic(fmt.Errorf("unknown SYNC_MODE %q", syncMode))
+	}
+}
+
+// syncUsers walks /users from the highest stored ID and upserts every user it
+// finds in batches of UPSERT_BATCH_SIZE, optionally enriched with the full
+// profile.
+func syncUsers(
+	applicationContext context.Context,
+	userRepository interfaces.UserRepository,
+	userCache interfaces.Cache,
+	gitHubClient interfaces.GitHubClient,
+	gitHubCredentials interfaces.GitHubCredentials,
+) {
+	var (
+		usersPerPage            = convertEnvConfigToInt("USERS_PER_PAGE", 30)
+		workerPoolSize          = convertEnvConfigToInt("WORKER_POOL_SIZE", 5)
+		maximumFetchRetries     = convertEnvConfigToInt("MAXIMUM_FETCH_RETRIES", 3)
+		delayBetweenUpsertsMS   = convertEnvConfigToInt("DELAY_BETWEEN_UPSERTS_MS", 200)
+		maximumConsecutiveEmpty = convertEnvConfigToInt("MAXIMUM_CONSECUTIVE_EMPTY", 1)
+		fetchFullProfiles       = convertEnvConfigToInt("FETCH_FULL_PROFILES", 1) == 1
+		upsertBatchSize         = convertEnvConfigToInt("UPSERT_BATCH_SIZE", 100)
+	)
+
+	batchWriter := newUserBatchWriter(applicationContext, userRepository, userCache, upsertBatchSize)
+
+	userChannel := make(chan entities.GitHubUser, usersPerPage*workerPoolSize)
 	var workerWaitGroup sync.WaitGroup
 
 	for workerIndex := 0; workerIndex < workerPoolSize; workerIndex++ {
@@ -78,16 +215,8 @@ func main() {
 
 		go func() {
 			defer workerWaitGroup.Done()
-			for userRecord := range userChannel {
-				if err := userRepository.Upsert(applicationContext, &userRecord); err != nil {
-					fmt.Fprintf(
-						os.Stderr,
-						"upsert error (login %s, id %d): %v\n",
-						userRecord.Login,
-						userRecord.ID,
-						err,
-					)
-				}
+			for fetchedUser := range userChannel {
+				storeUser(applicationContext, batchWriter, gitHubClient, fetchedUser, fetchFullProfiles)
 				time.Sleep(time.Duration(delayBetweenUpsertsMS) * time.Millisecond)
 			}
 		}()
@@ -98,9 +227,11 @@ func main() {
 
 		lastFetchedID := 0
 
+		// Resume after the highest stored ID. Rows are not written in ID
+		// order, so the newest row need not be the furthest one.
 		lastFetchOption := interfaces.ListOptions{
 			Limit:          1,
-			OrderBy:        "created_at",
+			OrderBy:        "id",
 			OrderDirection: "DESC",
 		}
 
@@ -116,68 +247,62 @@ func main() {
 		}
 
 		consecutiveEmptyBatches := 0
+		failedAttempts := 0
 
 		for {
-			var lastFetchError error
-			var fetchedUsers []entities.GitHubUser
-
-			for attempt := 1; attempt <= maximumFetchRetries; attempt++ {
-				usersBatch, fetchErr := gitHubClient.FetchUsersSince(
-					applicationContext,
-					lastFetchedID,
-					usersPerPage,
-				)
+			// Follow GitHub's Link headers from the last stored ID. When a page
+			// fails, the walk restarts after the last user handed to the workers.
+			var pageFetchError error
+			for fetchedUsers, fetchErr := range gitHubClient.IterateUsersSince(
+				applicationContext,
+				lastFetchedID,
+				usersPerPage,
+			) {
 				if fetchErr != nil {
-					lastFetchError = fetchErr
-					time.Sleep(time.Duration(attempt) * time.Second)
+					pageFetchError = fetchErr
+					break
+				}
+				failedAttempts = 0
+
+				if len(fetchedUsers) == 0 {
+					consecutiveEmptyBatches++
+					if consecutiveEmptyBatches >= maximumConsecutiveEmpty {
+						break
+					}
 					continue
 				}
-				fetchedUsers = usersBatch
-				lastFetchError = nil
-				break
+				consecutiveEmptyBatches = 0
+				reportRateLimits(gitHubClient, gitHubCredentials)
+
+				for _, fetchedUser := range fetchedUsers {
+					if fetchedUser.ID > lastFetchedID {
+						lastFetchedID = fetchedUser.ID
+					}
+					userChannel <- fetchedUser
+				}
+			}
+
+			if pageFetchError == nil {
+				return
 			}
 
-			if lastFetchError != nil {
+			failedAttempts++
+			if failedAttempts >= maximumFetchRetries {
 				fmt.Fprintf(
 					os.Stderr,
 					"fetch failed after %d attempts (since=%d): %v\n",
 					maximumFetchRetries,
 					lastFetchedID,
-					lastFetchError,
+					pageFetchError,
 				)
 				return
 			}
-
-			if len(fetchedUsers) == 0 {
-				consecutiveEmptyBatches++
-				if consecutiveEmptyBatches >= maximumConsecutiveEmpty {
-					return
-				}
-				continue
-			}
-			consecutiveEmptyBatches = 0
-
-			nextSinceID := lastFetchedID
-			for _, fetchedUser := range fetchedUsers {
-				if fetchedUser.ID > nextSinceID {
-					nextSinceID = fetchedUser.ID
-				}
-				userChannel <- entities.User{
-					ID:           fetchedUser.ID,
-					Login:        fetchedUser.Login,
-					NodeID:       fetchedUser.NodeID,
-					AvatarURL:    fetchedUser.AvatarURL,
-					HTMLURL:      fetchedUser.HTMLURL,
-					URL:          fetchedUser.URL,
-					Type:         fetchedUser.Type,
-					UserViewType: fetchedUser.UserViewType,
-					SiteAdmin:    fetchedUser.SiteAdmin,
-				}
-			}
-			lastFetchedID = nextSinceID
+			time.Sleep(time.Duration(failedAttempts) * time.Second)
 		}
 	}()
 
 	workerWaitGroup.Wait()
+	reportBatchTotals(batchWriter)
+	reportRateLimits(gitHubClient, gitHubCredentials)
 	fmt.Println("GitHub user synchronization complete.")
 }
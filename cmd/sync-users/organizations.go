@@ -0,0 +1,117 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/unkabogaton/github-users/internal/domain/entities"
+	"github.com/unkabogaton/github-users/internal/domain/interfaces"
+)
+
+// syncOrganizationMembers stores organizationLogin, upserts every member it
+// lists and replaces the organization's memberships with that list.
+func syncOrganizationMembers(
+	applicationContext context.Context,
+	organizationLogin string,
+	userRepository interfaces.UserRepository,
+	userCache interfaces.Cache,
+	organizationRepository interfaces.OrganizationRepository,
+	gitHubClient interfaces.GitHubClient,
+	gitHubCredentials interfaces.GitHubCredentials,
+) {
+	var (
+		workerPoolSize        = convertEnvConfigToInt("WORKER_POOL_SIZE", 5)
+		delayBetweenUpsertsMS = convertEnvConfigToInt("DELAY_BETWEEN_UPSERTS_MS", 200)
+		fetchFullProfiles     = convertEnvConfigToInt("FETCH_FULL_PROFILES", 1) == 1
+		upsertBatchSize       = convertEnvConfigToInt("UPSERT_BATCH_SIZE", 100)
+	)
+
+	if organizationLogin == "" {
+		panic(fmt.Errorf("SYNC_ORG is required when SYNC_MODE=org"))
+	}
+
+	fetchedOrganization, err := gitHubClient.FetchOrganization(applicationContext, organizationLogin)
+	if err != nil {
+		panic(fmt.Errorf("failed to fetch organization %s: %w", organizationLogin, err))
+	}
+	organizationRecord := fetchedOrganization.ToOrganization()
+	if err := organizationRepository.Upsert(applicationContext, &organizationRecord); err != nil {
+		panic(fmt.Errorf("failed to store organization %s: %w", organizationLogin, err))
+	}
+
+	members, err := gitHubClient.FetchOrganizationMembers(applicationContext, organizationLogin)
+	if err != nil {
+		panic(fmt.Errorf("failed to fetch members of %s: %w", organizationLogin, err))
+	}
+	reportRateLimits(gitHubClient, gitHubCredentials)
+
+	memberChannel := make(chan entities.GitHubUser, len(members))
+	for _, member := range members {
+		memberChannel <- member
+	}
+	close(memberChannel)
+
+	batchWriter := newUserBatchWriter(applicationContext, userRepository, userCache, upsertBatchSize)
+	var workerWaitGroup sync.WaitGroup
+	for workerIndex := 0; workerIndex < workerPoolSize; workerIndex++ {
+		workerWaitGroup.Add(1)
+
+		go func() {
+			defer workerWaitGroup.Done()
+			for member := range memberChannel {
+				storeUser(applicationContext, batchWriter, gitHubClient, member, fetchFullProfiles)
+				time.Sleep(time.Duration(delayBetweenUpsertsMS) * time.Millisecond)
+			}
+		}()
+	}
+	workerWaitGroup.Wait()
+	reportBatchTotals(batchWriter)
+
+	memberships := make([]entities.UserOrganization, 0, len(members))
+	for _, member := range members {
+		memberships = append(memberships, entities.UserOrganization{UserID: member.ID, OrganizationID: organizationRecord.ID})
+	}
+	if err := organizationRepository.ReplaceMembers(applicationContext, organizationRecord.ID, memberships); err != nil {
+		fmt.Fprintf(os.Stderr, "failed to store members of %s: %v\n", organizationLogin, err)
+	}
+
+	reportRateLimits(gitHubClient, gitHubCredentials)
+	fmt.Printf("GitHub organization %s synchronization complete (%d members).\n", organizationLogin, len(members))
+}
+
+// syncUserOrganizations records the public organization memberships of every
+// stored user.
+func syncUserOrganizations(
+	applicationContext context.Context,
+	userRepository interfaces.UserRepository,
+	organizationRepository interfaces.OrganizationRepository,
+	gitHubClient interfaces.GitHubClient,
+	gitHubCredentials interfaces.GitHubCredentials,
+) {
+	crawlStoredUsers(applicationContext, userRepository, gitHubClient, gitHubCredentials,
+		func(ctx context.Context, storedUser entities.User) error {
+			fetchedOrganizations, err := gitHubClient.FetchUserOrganizations(ctx, storedUser.Login)
+			if err != nil {
+				return fmt.Errorf("fetch organizations: %w", err)
+			}
+
+			organizationRecords := make([]entities.Organization, 0, len(fetchedOrganizations))
+			memberships := make([]entities.UserOrganization, 0, len(fetchedOrganizations))
+			for _, fetchedOrganization := range fetchedOrganizations {
+				organizationRecords = append(organizationRecords, fetchedOrganization.ToOrganization())
+				memberships = append(memberships, entities.UserOrganization{UserID: storedUser.ID, OrganizationID: fetchedOrganization.ID})
+			}
+			if err := organizationRepository.BatchUpsertSummaries(ctx, &organizationRecords); err != nil {
+				return fmt.Errorf("store organizations: %w", err)
+			}
+			if err := organizationRepository.ReplaceUserOrganizations(ctx, storedUser.ID, memberships); err != nil {
+				return fmt.Errorf("store memberships: %w", err)
+			}
+			return nil
+		},
+	)
+	fmt.Println("GitHub user organization synchronization complete.")
+}
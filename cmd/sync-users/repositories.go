@@ -0,0 +1,38 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/unkabogaton/github-users/internal/domain/entities"
+	"github.com/unkabogaton/github-users/internal/domain/interfaces"
+)
+
+// syncRepositories replaces the stored public repositories of every stored
+// user with what GitHub lists for them.
+func syncRepositories(
+	applicationContext context.Context,
+	userRepository interfaces.UserRepository,
+	repoRepository interfaces.RepoRepository,
+	gitHubClient interfaces.GitHubClient,
+	gitHubCredentials interfaces.GitHubCredentials,
+) {
+	crawlStoredUsers(applicationContext, userRepository, gitHubClient, gitHubCredentials,
+		func(ctx context.Context, storedUser entities.User) error {
+			fetchedRepositories, err := gitHubClient.FetchUserRepos(ctx, storedUser.Login)
+			if err != nil {
+				return fmt.Errorf("fetch repositories: %w", err)
+			}
+
+			repositoryRecords := make([]entities.Repository, 0, len(fetchedRepositories))
+			for _, fetchedRepository := range fetchedRepositories {
+				repositoryRecords = append(repositoryRecords, fetchedRepository.ToRepository())
+			}
+			if err := repoRepository.ReplaceForOwner(ctx, storedUser.ID, repositoryRecords); err != nil {
+				return fmt.Errorf("store repositories: %w", err)
+			}
+			return nil
+		},
+	)
+	fmt.Println("GitHub repository synchronization complete.")
+}
@@ -0,0 +1,88 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/unkabogaton/github-users/internal/domain/entities"
+	"github.com/unkabogaton/github-users/internal/domain/interfaces"
+)
+
+// crawlStoredUsers pages through the stored users in ID order and hands each
+// one to crawlUser on a pool of WORKER_POOL_SIZE workers. Errors are logged
+// per user and do not stop the crawl. Only users stored before the crawl
+// started are visited, so that users a crawl stores, such as followers, do
+// not widen that same crawl.
+func crawlStoredUsers(
+	applicationContext context.Context,
+	userRepository interfaces.UserRepository,
+	gitHubClient interfaces.GitHubClient,
+	gitHubCredentials interfaces.GitHubCredentials,
+	crawlUser func(ctx context.Context, storedUser entities.User) error,
+) {
+	var (
+		usersPerPage   = convertEnvConfigToInt("USERS_PER_PAGE", 30)
+		workerPoolSize = convertEnvConfigToInt("WORKER_POOL_SIZE", 5)
+	)
+
+	userChannel := make(chan entities.User, usersPerPage*workerPoolSize)
+	var workerWaitGroup sync.WaitGroup
+
+	for workerIndex := 0; workerIndex < workerPoolSize; workerIndex++ {
+		workerWaitGroup.Add(1)
+
+		go func() {
+			defer workerWaitGroup.Done()
+			for storedUser := range userChannel {
+				if err := crawlUser(applicationContext, storedUser); err != nil {
+					fmt.Fprintf(
+						os.Stderr,
+						"crawl error (login %s, id %d): %v\n",
+						storedUser.Login,
+						storedUser.ID,
+						err,
+					)
+				}
+			}
+		}()
+	}
+
+	go func() {
+		defer close(userChannel)
+
+		crawlStartedAt := time.Now()
+		cursor := ""
+		for page := 1; ; page++ {
+			storedUsers, err := userRepository.ListPage(applicationContext, interfaces.ListOptions{
+				Limit:          usersPerPage,
+				OrderBy:        "id",
+				OrderDirection: "ASC",
+				Filters: []interfaces.Filter{
+					{Column: "created_at", Operator: interfaces.FilterLessOrEqual, Value: crawlStartedAt},
+				},
+				Cursor: cursor,
+			})
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "failed to list stored users (page %d): %v\n", page, err)
+				return
+			}
+			if len(storedUsers.Users) > 0 {
+				reportRateLimits(gitHubClient, gitHubCredentials)
+			}
+
+			for _, storedUser := range storedUsers.Users {
+				userChannel <- storedUser
+			}
+			if storedUsers.NextCursor == "" {
+				return
+			}
+			cursor = storedUsers.NextCursor
+		}
+	}()
+
+	workerWaitGroup.Wait()
+	reportRateLimits(gitHubClient, gitHubCredentials)
+}
@@ -0,0 +1,138 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sync"
+
+	"github.com/unkabogaton/github-users/internal/domain/entities"
+	"github.com/unkabogaton/github-users/internal/domain/interfaces"
+)
+
+// userBatchWriter collects users from the sync workers and stores them with
+// one BatchUpsert per batchSize users. Full profiles and the summaries of list
+// endpoints are batched apart, so that summaries never blank the profile
+// columns of users stored earlier. Any not-found tombstones left in
+// userCache for the stored logins are cleared, so that an account created
+// after a failed lookup becomes visible.
+type userBatchWriter struct {
+	ctx            context.Context
+	userRepository interfaces.UserRepository
+	userCache      interfaces.Cache
+	batchSize      int
+
+	// mutex guards the pending batches and the totals only; writes run
+	// outside it.
+	mutex            sync.Mutex
+	pendingProfiles  []entities.User
+	pendingSummaries []entities.User
+	totals           interfaces.BatchUpsertResult
+	failed           int
+}
+
+func newUserBatchWriter(
+	ctx context.Context,
+	userRepository interfaces.UserRepository,
+	userCache interfaces.Cache,
+	batchSize int,
+) *userBatchWriter {
+	if batchSize <= 0 {
+		batchSize = 1
+	}
+	return &userBatchWriter{
+		ctx:            ctx,
+		userRepository: userRepository,
+		userCache:      userCache,
+		batchSize:      batchSize,
+	}
+}
+
+// AddProfile queues a full profile and writes the batch once it is full.
+func (writer *userBatchWriter) AddProfile(userRecord entities.User) {
+	writer.mutex.Lock()
+	writer.pendingProfiles = append(writer.pendingProfiles, userRecord)
+	batch := writer.takeLocked(&writer.pendingProfiles, writer.batchSize)
+	writer.mutex.Unlock()
+
+	writer.write(batch, writer.userRepository.BatchUpsert)
+}
+
+// AddSummary queues a list endpoint summary and writes the batch once it is
+// full.
+func (writer *userBatchWriter) AddSummary(userRecord entities.User) {
+	writer.mutex.Lock()
+	writer.pendingSummaries = append(writer.pendingSummaries, userRecord)
+	batch := writer.takeLocked(&writer.pendingSummaries, writer.batchSize)
+	writer.mutex.Unlock()
+
+	writer.write(batch, writer.userRepository.BatchUpsertSummaries)
+}
+
+// Flush writes whatever is queued and returns the totals so far. Batches
+// that other goroutines are still writing are not waited for.
+func (writer *userBatchWriter) Flush() (interfaces.BatchUpsertResult, int) {
+	writer.mutex.Lock()
+	profiles := writer.takeLocked(&writer.pendingProfiles, 1)
+	summaries := writer.takeLocked(&writer.pendingSummaries, 1)
+	writer.mutex.Unlock()
+
+	writer.write(profiles, writer.userRepository.BatchUpsert)
+	writer.write(summaries, writer.userRepository.BatchUpsertSummaries)
+
+	writer.mutex.Lock()
+	defer writer.mutex.Unlock()
+	return writer.totals, writer.failed
+}
+
+// takeLocked swaps out the pending users once at least minimum are queued.
+func (writer *userBatchWriter) takeLocked(pending *[]entities.User, minimum int) []entities.User {
+	if len(*pending) < minimum {
+		return nil
+	}
+	batch := *pending
+	*pending = nil
+	return batch
+}
+
+// write stores batch without holding the mutex, so that the sync workers keep
+// queueing while the database and Redis round trips run.
+func (writer *userBatchWriter) write(
+	batch []entities.User,
+	upsert func(context.Context, *[]entities.User) (interfaces.BatchUpsertResult, error),
+) {
+	if len(batch) == 0 {
+		return
+	}
+
+	result, err := upsert(writer.ctx, &batch)
+
+	writer.mutex.Lock()
+	writer.totals.Inserted += result.Inserted
+	writer.totals.Updated += result.Updated
+	if err != nil {
+		// Chunks stored before the failure are already counted in result.
+		writer.failed += len(batch) - int(result.Inserted+result.Updated)
+	}
+	writer.mutex.Unlock()
+
+	if err != nil {
+		fmt.Fprintf(
+			os.Stderr,
+			"batch upsert error (%d users, logins %s..%s): %v\n",
+			len(batch),
+			batch[0].Login,
+			batch[len(batch)-1].Login,
+			err,
+		)
+		return
+	}
+
+	logins := make([]string, 0, len(batch))
+	for _, userRecord := range batch {
+		logins = append(logins, userRecord.Login)
+	}
+	if err := writer.userCache.ClearNotFound(writer.ctx, logins...); err != nil {
+		fmt.Fprintf(os.Stderr, "cache clear error (%d logins): %v\n", len(logins), err)
+	}
+}
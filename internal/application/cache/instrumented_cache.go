@@ -0,0 +1,87 @@
+package cache
+
+import (
+	"context"
+	"time"
+
+	"github.com/unkabogaton/github-users/internal/domain/entities"
+	"github.com/unkabogaton/github-users/internal/domain/interfaces"
+)
+
+// InstrumentedCache reports every call made to another Cache under name.
+type InstrumentedCache struct {
+	next    interfaces.Cache
+	name    string
+	metrics interfaces.CacheMetrics
+}
+
+func NewInstrumentedCache(next interfaces.Cache, name string, metrics interfaces.CacheMetrics) interfaces.Cache {
+	return &InstrumentedCache{next: next, name: name, metrics: metrics}
+}
+
+func (cache *InstrumentedCache) observe(operation, outcome string, startedAt time.Time) {
+	cache.metrics.ObserveCacheOperation(cache.name, operation, outcome, time.Since(startedAt))
+}
+
+func writeOutcome(err error) string {
+	if err != nil {
+		return interfaces.CacheOutcomeError
+	}
+	return interfaces.CacheOutcomeOK
+}
+
+func (cache *InstrumentedCache) GetUser(ctx context.Context, login string) (*entities.User, bool, error) {
+	cacheEntry, cacheHit, err := cache.GetUserEntry(ctx, login)
+	if err != nil || !cacheHit || cacheEntry.NotFound {
+		return nil, false, err
+	}
+	return cacheEntry.User, true, nil
+}
+
+func (cache *InstrumentedCache) GetUserEntry(ctx context.Context, login string) (*interfaces.CacheEntry, bool, error) {
+	startedAt := time.Now()
+	cacheEntry, cacheHit, err := cache.next.GetUserEntry(ctx, login)
+
+	outcome := interfaces.CacheOutcomeMiss
+	switch {
+	case err != nil:
+		outcome = interfaces.CacheOutcomeError
+	case !cacheHit:
+	case cacheEntry.NotFound:
+		outcome = interfaces.CacheOutcomeNotFoundHit
+	case cacheEntry.Stale:
+		outcome = interfaces.CacheOutcomeStaleHit
+	default:
+		outcome = interfaces.CacheOutcomeHit
+	}
+	cache.observe(interfaces.CacheOperationGet, outcome, startedAt)
+	return cacheEntry, cacheHit, err
+}
+
+func (cache *InstrumentedCache) SetUser(ctx context.Context, user *entities.User) error {
+	startedAt := time.Now()
+	err := cache.next.SetUser(ctx, user)
+	cache.observe(interfaces.CacheOperationSet, writeOutcome(err), startedAt)
+	return err
+}
+
+func (cache *InstrumentedCache) DeleteUser(ctx context.Context, login string) error {
+	startedAt := time.Now()
+	err := cache.next.DeleteUser(ctx, login)
+	cache.observe(interfaces.CacheOperationDelete, writeOutcome(err), startedAt)
+	return err
+}
+
+func (cache *InstrumentedCache) SetNotFound(ctx context.Context, login string) error {
+	startedAt := time.Now()
+	err := cache.next.SetNotFound(ctx, login)
+	cache.observe(interfaces.CacheOperationSetNotFound, writeOutcome(err), startedAt)
+	return err
+}
+
+func (cache *InstrumentedCache) ClearNotFound(ctx context.Context, logins ...string) error {
+	startedAt := time.Now()
+	err := cache.next.ClearNotFound(ctx, logins...)
+	cache.observe(interfaces.CacheOperationClearNotFound, writeOutcome(err), startedAt)
+	return err
+}
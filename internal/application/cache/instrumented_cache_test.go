@@ -0,0 +1,67 @@
+package cache
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	miniredis "github.com/alicebob/miniredis/v2"
+	"github.com/go-redis/redis/v8"
+	"github.com/stretchr/testify/require"
+
+	"github.com/unkabogaton/github-users/internal/domain/entities"
+	"github.com/unkabogaton/github-users/internal/domain/interfaces"
+)
+
+type recordingCacheMetrics struct {
+	mutex        sync.Mutex
+	observations []string
+}
+
+func (metrics *recordingCacheMetrics) ObserveCacheOperation(cacheName, operation, outcome string, duration time.Duration) {
+	metrics.mutex.Lock()
+	defer metrics.mutex.Unlock()
+	metrics.observations = append(metrics.observations, cacheName+" "+operation+" "+outcome)
+}
+
+func TestInstrumentedCache_ReportsOutcomes(t *testing.T) {
+	t.Parallel()
+	mini, err := miniredis.Run()
+	require.NoError(t, err)
+	defer mini.Close()
+
+	client := redis.NewClient(&redis.Options{Addr: mini.Addr()})
+	metrics := &recordingCacheMetrics{}
+	cache := NewInstrumentedCache(
+		&RedisCache{redisClient: client, ttl: time.Minute, notFoundTTL: time.Minute},
+		"redis",
+		metrics,
+	)
+	ctx := context.Background()
+
+	_, _, err = cache.GetUser(ctx, "octocat")
+	require.NoError(t, err)
+	require.NoError(t, cache.SetUser(ctx, &entities.User{ID: 1, Login: "octocat"}))
+	_, hit, err := cache.GetUser(ctx, "octocat")
+	require.NoError(t, err)
+	require.True(t, hit)
+	require.NoError(t, cache.DeleteUser(ctx, "octocat"))
+	require.NoError(t, cache.SetNotFound(ctx, "ghost"))
+	_, _, err = cache.GetUserEntry(ctx, "ghost")
+	require.NoError(t, err)
+
+	mini.Close()
+	_, _, err = cache.GetUserEntry(ctx, "octocat")
+	require.Error(t, err)
+
+	require.Equal(t, []string{
+		"redis get " + interfaces.CacheOutcomeMiss,
+		"redis set " + interfaces.CacheOutcomeOK,
+		"redis get " + interfaces.CacheOutcomeHit,
+		"redis delete " + interfaces.CacheOutcomeOK,
+		"redis set_not_found " + interfaces.CacheOutcomeOK,
+		"redis get " + interfaces.CacheOutcomeNotFoundHit,
+		"redis get " + interfaces.CacheOutcomeError,
+	}, metrics.observations)
+}
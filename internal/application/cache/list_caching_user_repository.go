@@ -0,0 +1,110 @@
+package cache
+
+import (
+	"context"
+
+	"github.com/unkabogaton/github-users/internal/domain/entities"
+	"github.com/unkabogaton/github-users/internal/domain/interfaces"
+)
+
+// ListCachingUserRepository serves List, ListPage and Count from a
+// UserListCache and invalidates every cached page and count whenever a user
+// is written or deleted, including writes that fail part way. The cache is
+// best effort: when it fails, the wrapped repository is read.
+type ListCachingUserRepository struct {
+	interfaces.UserRepository
+	listCache interfaces.UserListCache
+}
+
+func NewListCachingUserRepository(
+	repository interfaces.UserRepository,
+	listCache interfaces.UserListCache,
+) interfaces.UserRepository {
+	return &ListCachingUserRepository{UserRepository: repository, listCache: listCache}
+}
+
+func (repository *ListCachingUserRepository) List(
+	ctx context.Context,
+	options interfaces.ListOptions,
+) ([]entities.User, error) {
+	// The generation is read before the query: a write that lands while the
+	// query runs bumps it, and the page read before the write is orphaned.
+	generation, err := repository.listCache.UserListGeneration(ctx)
+	if err != nil {
+		return repository.UserRepository.List(ctx, options)
+	}
+	if users, cacheHit, err := repository.listCache.GetUserList(ctx, generation, options); err == nil && cacheHit {
+		return users, nil
+	}
+
+	users, err := repository.UserRepository.List(ctx, options)
+	if err != nil {
+		return nil, err
+	}
+	_ = repository.listCache.SetUserList(ctx, generation, options, users)
+	return users, nil
+}
+
+func (repository *ListCachingUserRepository) ListPage(
+	ctx context.Context,
+	options interfaces.ListOptions,
+) (*interfaces.UserPage, error) {
+	generation, err := repository.listCache.UserListGeneration(ctx)
+	if err != nil {
+		return repository.UserRepository.ListPage(ctx, options)
+	}
+	if userPage, cacheHit, err := repository.listCache.GetUserPage(ctx, generation, options); err == nil && cacheHit {
+		return userPage, nil
+	}
+
+	userPage, err := repository.UserRepository.ListPage(ctx, options)
+	if err != nil {
+		return nil, err
+	}
+	_ = repository.listCache.SetUserPage(ctx, generation, options, userPage)
+	return userPage, nil
+}
+
+func (repository *ListCachingUserRepository) Count(
+	ctx context.Context,
+	filters []interfaces.Filter,
+) (int64, error) {
+	generation, err := repository.listCache.UserListGeneration(ctx)
+	if err != nil {
+		return repository.UserRepository.Count(ctx, filters)
+	}
+	if count, cacheHit, err := repository.listCache.GetUserCount(ctx, generation, filters); err == nil && cacheHit {
+		return count, nil
+	}
+
+	count, err := repository.UserRepository.Count(ctx, filters)
+	if err != nil {
+		return 0, err
+	}
+	_ = repository.listCache.SetUserCount(ctx, generation, filters, count)
+	return count, nil
+}
+
+func (repository *ListCachingUserRepository) Upsert(ctx context.Context, user *entities.User) error {
+	err := repository.UserRepository.Upsert(ctx, user)
+	_ = repository.listCache.InvalidateUserLists(ctx)
+	return err
+}
+
+func (repository *ListCachingUserRepository) BatchUpsert(ctx context.Context, users *[]entities.User) (interfaces.BatchUpsertResult, error) {
+	result, err := repository.UserRepository.BatchUpsert(ctx, users)
+	_ = repository.listCache.InvalidateUserLists(ctx)
+	return result, err
+}
+
+func (repository *ListCachingUserRepository) BatchUpsertSummaries(ctx context.Context, users *[]entities.User) (interfaces.BatchUpsertResult, error) {
+	result, err := repository.UserRepository.BatchUpsertSummaries(ctx, users)
+	_ = repository.listCache.InvalidateUserLists(ctx)
+	return result, err
+}
+
+func (repository *ListCachingUserRepository) DeleteByLogin(ctx context.Context, login string) error {
+	err := repository.UserRepository.DeleteByLogin(ctx, login)
+	_ = repository.listCache.InvalidateUserLists(ctx)
+	return err
+}
@@ -0,0 +1,174 @@
+package cache
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	miniredis "github.com/alicebob/miniredis/v2"
+	"github.com/go-redis/redis/v8"
+	"github.com/stretchr/testify/require"
+
+	"github.com/unkabogaton/github-users/internal/domain/entities"
+	"github.com/unkabogaton/github-users/internal/domain/interfaces"
+)
+
+type countingUserRepository struct {
+	users         []entities.User
+	listCalls     int
+	listPageCalls int
+	countCalls    int
+	duringList    func()
+}
+
+func (r *countingUserRepository) Upsert(ctx context.Context, user *entities.User) error {
+	r.users = append(r.users, *user)
+	return nil
+}
+func (r *countingUserRepository) BatchUpsert(ctx context.Context, users *[]entities.User) (interfaces.BatchUpsertResult, error) {
+	r.users = append(r.users, *users...)
+	return interfaces.BatchUpsertResult{Inserted: int64(len(*users))}, nil
+}
+func (r *countingUserRepository) BatchUpsertSummaries(ctx context.Context, users *[]entities.User) (interfaces.BatchUpsertResult, error) {
+	return r.BatchUpsert(ctx, users)
+}
+func (r *countingUserRepository) GetByLogin(ctx context.Context, login string) (*entities.User, error) {
+	return nil, nil
+}
+func (r *countingUserRepository) List(ctx context.Context, options interfaces.ListOptions) ([]entities.User, error) {
+	r.listCalls++
+	users := append([]entities.User(nil), r.users...)
+	if r.duringList != nil {
+		r.duringList()
+	}
+	return users, nil
+}
+func (r *countingUserRepository) ListPage(ctx context.Context, options interfaces.ListOptions) (*interfaces.UserPage, error) {
+	r.listPageCalls++
+	return &interfaces.UserPage{Users: append([]entities.User(nil), r.users...)}, nil
+}
+func (r *countingUserRepository) Count(ctx context.Context, filters []interfaces.Filter) (int64, error) {
+	r.countCalls++
+	return int64(len(r.users)), nil
+}
+func (r *countingUserRepository) DeleteByLogin(ctx context.Context, login string) error {
+	return nil
+}
+
+func TestListCachingUserRepository_ServesCachedPagesUntilWrite(t *testing.T) {
+	t.Parallel()
+	mini, err := miniredis.Run()
+	require.NoError(t, err)
+	defer mini.Close()
+
+	client := redis.NewClient(&redis.Options{Addr: mini.Addr()})
+	inner := &countingUserRepository{users: []entities.User{{ID: 1, Login: "a"}}}
+	repository := NewListCachingUserRepository(inner, &RedisUserListCache{redisClient: client, ttl: time.Minute})
+	ctx := context.Background()
+	options := interfaces.ListOptions{Limit: 10, Page: 1, OrderBy: "id", OrderDirection: "ASC"}
+
+	for range 3 {
+		users, err := repository.List(ctx, options)
+		require.NoError(t, err)
+		require.Len(t, users, 1)
+	}
+	require.Equal(t, 1, inner.listCalls)
+
+	require.NoError(t, repository.Upsert(ctx, &entities.User{ID: 2, Login: "b"}))
+	users, err := repository.List(ctx, options)
+	require.NoError(t, err)
+	require.Len(t, users, 2)
+	require.Equal(t, 2, inner.listCalls)
+
+	require.NoError(t, repository.DeleteByLogin(ctx, "b"))
+	_, err = repository.List(ctx, options)
+	require.NoError(t, err)
+	require.Equal(t, 3, inner.listCalls)
+}
+
+func TestListCachingUserRepository_ServesCachedPagesAndCountsUntilWrite(t *testing.T) {
+	t.Parallel()
+	mini, err := miniredis.Run()
+	require.NoError(t, err)
+	defer mini.Close()
+
+	client := redis.NewClient(&redis.Options{Addr: mini.Addr()})
+	inner := &countingUserRepository{users: []entities.User{{ID: 1, Login: "a"}}}
+	repository := NewListCachingUserRepository(inner, &RedisUserListCache{redisClient: client, ttl: time.Minute})
+	ctx := context.Background()
+	options := interfaces.ListOptions{Limit: 10, Page: 1, OrderBy: "id", OrderDirection: "ASC", Cursor: "position"}
+	filters := interfaces.UserListFilters{Type: "User"}.Filters()
+
+	for range 3 {
+		userPage, err := repository.ListPage(ctx, options)
+		require.NoError(t, err)
+		require.Len(t, userPage.Users, 1)
+
+		count, err := repository.Count(ctx, filters)
+		require.NoError(t, err)
+		require.EqualValues(t, 1, count)
+	}
+	require.Equal(t, 1, inner.listPageCalls)
+	require.Equal(t, 1, inner.countCalls)
+
+	options.Cursor = "another"
+	_, err = repository.ListPage(ctx, options)
+	require.NoError(t, err)
+	_, err = repository.Count(ctx, nil)
+	require.NoError(t, err)
+	require.Equal(t, 2, inner.listPageCalls)
+	require.Equal(t, 2, inner.countCalls)
+
+	require.NoError(t, repository.Upsert(ctx, &entities.User{ID: 2, Login: "b"}))
+	userPage, err := repository.ListPage(ctx, options)
+	require.NoError(t, err)
+	require.Len(t, userPage.Users, 2)
+	count, err := repository.Count(ctx, nil)
+	require.NoError(t, err)
+	require.EqualValues(t, 2, count)
+	require.Equal(t, 3, inner.listPageCalls)
+	require.Equal(t, 3, inner.countCalls)
+}
+
+func TestListCachingUserRepository_DoesNotCachePageReadBeforeWrite(t *testing.T) {
+	t.Parallel()
+	mini, err := miniredis.Run()
+	require.NoError(t, err)
+	defer mini.Close()
+
+	client := redis.NewClient(&redis.Options{Addr: mini.Addr()})
+	inner := &countingUserRepository{users: []entities.User{{ID: 1, Login: "a"}}}
+	repository := NewListCachingUserRepository(inner, &RedisUserListCache{redisClient: client, ttl: time.Minute})
+	ctx := context.Background()
+	options := interfaces.ListOptions{Limit: 10, Page: 1, OrderBy: "id", OrderDirection: "ASC"}
+
+	// A write lands after the page was read but before it is cached.
+	inner.duringList = func() {
+		inner.duringList = nil
+		require.NoError(t, repository.Upsert(ctx, &entities.User{ID: 2, Login: "b"}))
+	}
+	users, err := repository.List(ctx, options)
+	require.NoError(t, err)
+	require.Len(t, users, 1)
+
+	users, err = repository.List(ctx, options)
+	require.NoError(t, err)
+	require.Len(t, users, 2)
+	require.Equal(t, 2, inner.listCalls)
+}
+
+func TestListCachingUserRepository_FallsBackWhenCacheFails(t *testing.T) {
+	t.Parallel()
+	mini, err := miniredis.Run()
+	require.NoError(t, err)
+	client := redis.NewClient(&redis.Options{Addr: mini.Addr()})
+	mini.Close()
+
+	inner := &countingUserRepository{users: []entities.User{{ID: 1, Login: "a"}}}
+	repository := NewListCachingUserRepository(inner, &RedisUserListCache{redisClient: client, ttl: time.Minute})
+
+	users, err := repository.List(context.Background(), interfaces.ListOptions{Limit: 10, Page: 1})
+	require.NoError(t, err)
+	require.Len(t, users, 1)
+	require.NoError(t, repository.Upsert(context.Background(), &entities.User{ID: 2, Login: "b"}))
+}
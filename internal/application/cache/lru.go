@@ -0,0 +1,75 @@
+package cache
+
+import (
+	"container/list"
+	"sync"
+)
+
+// lru is a size-bounded, concurrency-safe map that evicts the least recently
+// used entry once it holds maximumEntries items.
+type lru[K comparable, V any] struct {
+	mutex          sync.Mutex
+	maximumEntries int
+	order          *list.List
+	items          map[K]*list.Element
+}
+
+type lruEntry[K comparable, V any] struct {
+	key   K
+	value V
+}
+
+func newLRU[K comparable, V any](maximumEntries int) *lru[K, V] {
+	return &lru[K, V]{
+		maximumEntries: maximumEntries,
+		order:          list.New(),
+		items:          map[K]*list.Element{},
+	}
+}
+
+func (cache *lru[K, V]) Get(key K) (V, bool) {
+	cache.mutex.Lock()
+	defer cache.mutex.Unlock()
+
+	element, ok := cache.items[key]
+	if !ok {
+		var zeroValue V
+		return zeroValue, false
+	}
+	cache.order.MoveToFront(element)
+	return element.Value.(*lruEntry[K, V]).value, true
+}
+
+func (cache *lru[K, V]) Set(key K, value V) {
+	cache.mutex.Lock()
+	defer cache.mutex.Unlock()
+
+	if element, ok := cache.items[key]; ok {
+		element.Value.(*lruEntry[K, V]).value = value
+		cache.order.MoveToFront(element)
+		return
+	}
+
+	cache.items[key] = cache.order.PushFront(&lruEntry[K, V]{key: key, value: value})
+	for cache.maximumEntries > 0 && cache.order.Len() > cache.maximumEntries {
+		oldest := cache.order.Back()
+		cache.order.Remove(oldest)
+		delete(cache.items, oldest.Value.(*lruEntry[K, V]).key)
+	}
+}
+
+func (cache *lru[K, V]) Delete(key K) {
+	cache.mutex.Lock()
+	defer cache.mutex.Unlock()
+
+	if element, ok := cache.items[key]; ok {
+		cache.order.Remove(element)
+		delete(cache.items, key)
+	}
+}
+
+func (cache *lru[K, V]) Len() int {
+	cache.mutex.Lock()
+	defer cache.mutex.Unlock()
+	return cache.order.Len()
+}
@@ -2,8 +2,10 @@ package cache
 
 import (
 	"context"
+	"encoding/binary"
 	"encoding/json"
 	"log"
+	"sync/atomic"
 	"time"
 
 	"github.com/go-redis/redis/v8"
@@ -11,59 +13,186 @@ import (
 	"github.com/unkabogaton/github-users/internal/domain/interfaces"
 )
 
+// RedisCache keeps each user for ttl plus staleTTL. Past ttl (the soft TTL)
+// the entry is reported stale; past both Redis evicts it (the hard TTL).
+// Logins GitHub reported missing are kept as tombstones for notFoundTTL; a
+// notFoundTTL of zero turns tombstones off. Users are written with codec and
+// read back with whichever known codec wrote them. callLogging writes an
+// [INFO] line for every hit, miss and write; errors are always logged.
 type RedisCache struct {
-	redisClient *redis.Client
+	redisClient redis.UniversalClient
 	ttl         time.Duration
+	staleTTL    time.Duration
+	notFoundTTL time.Duration
+	codec       UserCodec
+	callLogging bool
 }
 
-func NewRedisCache(address, password string, ttlSeconds int) interfaces.Cache {
-	client := redis.NewClient(&redis.Options{
-		Addr:     address,
-		Password: password,
-	})
-	log.Printf("[INFO] Redis client initialized at %s", address)
+// A stored user is the codec's format byte, the soft expiry as big-endian
+// unix seconds (0 for none) and the encoded user.
+const cachedUserHeaderLength = 9
+
+// legacyCachedUser is the JSON form written before codecs existed. Entries
+// written before soft expiry was tracked hold the bare user and are read as
+// fresh.
+type legacyCachedUser struct {
+	User          *entities.User `json:"user"`
+	SoftExpiresAt int64          `json:"soft_expires_at,omitempty"`
+}
+
+func NewRedisCache(
+	redisClient redis.UniversalClient,
+	ttlSeconds, staleTTLSeconds, notFoundTTLSeconds int,
+	codec UserCodec,
+	callLogging bool,
+) interfaces.Cache {
 	return &RedisCache{
-		redisClient: client,
+		redisClient: redisClient,
 		ttl:         time.Duration(ttlSeconds) * time.Second,
+		staleTTL:    time.Duration(staleTTLSeconds) * time.Second,
+		notFoundTTL: time.Duration(notFoundTTLSeconds) * time.Second,
+		codec:       codec,
+		callLogging: callLogging,
 	}
 }
 
-func (cache *RedisCache) GetUser(ctx context.Context, login string) (*entities.User, bool, error) {
-	key := "user:" + login
-	value, err := cache.redisClient.Get(ctx, key).Result()
-	if err == redis.Nil {
-		log.Printf("[INFO] Cache miss for key: %s", key)
-		return nil, false, nil
+func (cache *RedisCache) logCall(format string, arguments ...interface{}) {
+	if cache.callLogging {
+		log.Printf(format, arguments...)
 	}
+}
+
+func (cache *RedisCache) userCodec() UserCodec {
+	if cache.codec == nil {
+		return jsonUserCodec{}
+	}
+	return cache.codec
+}
+
+func (cache *RedisCache) encodeUser(user *entities.User, softExpiresAt int64) ([]byte, error) {
+	codec := cache.userCodec()
+	payload, err := codec.Marshal(user)
 	if err != nil {
+		return nil, err
+	}
+
+	value := make([]byte, cachedUserHeaderLength, cachedUserHeaderLength+len(payload))
+	value[0] = codec.Format()
+	binary.BigEndian.PutUint64(value[1:cachedUserHeaderLength], uint64(softExpiresAt))
+	return append(value, payload...), nil
+}
+
+// decodeUser reads a stored user. known is false for a format this build does
+// not understand, which callers treat as a miss.
+func decodeUser(value []byte) (user *entities.User, softExpiresAt int64, known bool, err error) {
+	if len(value) > 0 && value[0] == '{' {
+		var stored legacyCachedUser
+		if err := json.Unmarshal(value, &stored); err != nil {
+			return nil, 0, true, err
+		}
+		if stored.User == nil {
+			var legacyUser entities.User
+			if err := json.Unmarshal(value, &legacyUser); err != nil {
+				return nil, 0, true, err
+			}
+			stored.User = &legacyUser
+		}
+		return stored.User, stored.SoftExpiresAt, true, nil
+	}
+
+	if len(value) < cachedUserHeaderLength {
+		return nil, 0, false, nil
+	}
+	codec, ok := userCodecs[value[0]]
+	if !ok {
+		return nil, 0, false, nil
+	}
+	softExpiresAt = int64(binary.BigEndian.Uint64(value[1:cachedUserHeaderLength]))
+	user, err = codec.Unmarshal(value[cachedUserHeaderLength:])
+	return user, softExpiresAt, true, err
+}
+
+func notFoundKey(login string) string {
+	return "user:notfound:" + login
+}
+
+func (cache *RedisCache) GetUser(ctx context.Context, login string) (*entities.User, bool, error) {
+	cacheEntry, cacheHit, err := cache.GetUserEntry(ctx, login)
+	if err != nil || !cacheHit || cacheEntry.NotFound {
+		return nil, false, err
+	}
+	return cacheEntry.User, true, nil
+}
+
+func (cache *RedisCache) GetUserEntry(ctx context.Context, login string) (*interfaces.CacheEntry, bool, error) {
+	key := "user:" + login
+	// The user and its tombstone may live on different cluster nodes, so
+	// they are read with a pipeline rather than one MGET.
+	var userCommand, notFoundCommand *redis.StringCmd
+	_, err := cache.redisClient.Pipelined(ctx, func(pipeline redis.Pipeliner) error {
+		userCommand = pipeline.Get(ctx, key)
+		notFoundCommand = pipeline.Get(ctx, notFoundKey(login))
+		return nil
+	})
+	if err != nil && err != redis.Nil {
 		log.Printf("[ERROR] Redis GET error for key %s: %v", key, err)
 		return nil, false, err
 	}
+	value, userErr := userCommand.Result()
+	if userErr == redis.Nil {
+		if notFoundCommand.Err() == nil {
+			cache.logCall("[INFO] Not-found cache hit for key: %s", key)
+			return &interfaces.CacheEntry{NotFound: true}, true, nil
+		}
+		cache.logCall("[INFO] Cache miss for key: %s", key)
+		return nil, false, nil
+	}
 
-	var user entities.User
-	if err := json.Unmarshal([]byte(value), &user); err != nil {
+	user, softExpiresAt, known, err := decodeUser([]byte(value))
+	if !known {
+		cache.logCall("[INFO] Cache miss for key %s: unknown value format", key)
+		return nil, false, nil
+	}
+	if err != nil {
 		log.Printf("[ERROR] Failed to unmarshal cache value for key %s: %v", key, err)
 		return nil, false, err
 	}
 
-	log.Printf("[INFO] Cache hit for key: %s", key)
-	return &user, true, nil
+	stale := softExpiresAt != 0 && time.Now().Unix() >= softExpiresAt
+	if stale {
+		cache.logCall("[INFO] Stale cache hit for key: %s", key)
+	} else {
+		cache.logCall("[INFO] Cache hit for key: %s", key)
+	}
+	return &interfaces.CacheEntry{User: user, Stale: stale}, true, nil
 }
 
 func (cache *RedisCache) SetUser(ctx context.Context, user *entities.User) error {
 	key := "user:" + user.Login
-	bytes, err := json.Marshal(user)
+
+	softExpiresAt := int64(0)
+	hardTTL := time.Duration(0)
+	if cache.ttl > 0 {
+		softExpiresAt = time.Now().Add(cache.ttl).Unix()
+		hardTTL = cache.ttl + cache.staleTTL
+	}
+
+	bytes, err := cache.encodeUser(user, softExpiresAt)
 	if err != nil {
 		log.Printf("[ERROR] Failed to marshal user for cache key %s: %v", key, err)
 		return err
 	}
 
-	if err := cache.redisClient.Set(ctx, key, bytes, cache.ttl).Err(); err != nil {
+	if _, err := cache.redisClient.Pipelined(ctx, func(pipeline redis.Pipeliner) error {
+		pipeline.Set(ctx, key, bytes, hardTTL)
+		pipeline.Del(ctx, notFoundKey(user.Login))
+		return nil
+	}); err != nil {
 		log.Printf("[ERROR] Redis SET error for key %s: %v", key, err)
 		return err
 	}
 
-	log.Printf("[INFO] User cached with key: %s", key)
+	cache.logCall("[INFO] User cached with key: %s", key)
 	return nil
 }
 
@@ -74,6 +203,70 @@ func (cache *RedisCache) DeleteUser(ctx context.Context, login string) error {
 		return err
 	}
 
-	log.Printf("[INFO] Cache deleted for key: %s", key)
+	cache.logCall("[INFO] Cache deleted for key: %s", key)
 	return nil
 }
+
+func (cache *RedisCache) SetNotFound(ctx context.Context, login string) error {
+	if cache.notFoundTTL <= 0 {
+		return nil
+	}
+
+	key := notFoundKey(login)
+	if err := cache.redisClient.Set(ctx, key, 1, cache.notFoundTTL).Err(); err != nil {
+		log.Printf("[ERROR] Redis SET error for key %s: %v", key, err)
+		return err
+	}
+
+	cache.logCall("[INFO] Not-found cached with key: %s", key)
+	return nil
+}
+
+func (cache *RedisCache) ClearNotFound(ctx context.Context, logins ...string) error {
+	if len(logins) == 0 {
+		return nil
+	}
+
+	// One DEL per key, as the keys may hash to different cluster slots.
+	if _, err := cache.redisClient.Pipelined(ctx, func(pipeline redis.Pipeliner) error {
+		for _, login := range logins {
+			pipeline.Del(ctx, notFoundKey(login))
+		}
+		return nil
+	}); err != nil {
+		log.Printf("[ERROR] Redis DEL error for not-found keys of %v: %v", logins, err)
+		return err
+	}
+	return nil
+}
+
+// userKeyPattern matches the users and tombstones this cache writes, but not
+// the list, page and ETag keys other stores keep in the same database.
+const userKeyPattern = "user:*"
+
+// CountKeys returns the number of cached users and tombstones, summed over
+// the masters of a cluster. It walks the keyspace with SCAN, so its cost grows
+// with the whole database rather than with the users alone.
+func (cache *RedisCache) CountKeys(ctx context.Context) (int64, error) {
+	clusterClient, isCluster := cache.redisClient.(*redis.ClusterClient)
+	if !isCluster {
+		return countMatchingKeys(ctx, cache.redisClient, userKeyPattern)
+	}
+
+	var keyCount atomic.Int64
+	err := clusterClient.ForEachMaster(ctx, func(ctx context.Context, master *redis.Client) error {
+		masterKeyCount, err := countMatchingKeys(ctx, master, userKeyPattern)
+		keyCount.Add(masterKeyCount)
+		return err
+	})
+	return keyCount.Load(), err
+}
+
+func countMatchingKeys(ctx context.Context, redisClient redis.Cmdable, pattern string) (int64, error) {
+	var keyCount int64
+	keys := redisClient.Scan(ctx, 0, pattern, 1000).Iterator()
+	for keys.Next(ctx) {
+		keyCount++
+	}
+	return keyCount, keys.Err()
+}
@@ -2,37 +2,205 @@ package cache
 
 import (
 	"context"
+	"fmt"
 	"testing"
+	"time"
 
 	miniredis "github.com/alicebob/miniredis/v2"
 	"github.com/go-redis/redis/v8"
 	"github.com/stretchr/testify/require"
 
 	"github.com/unkabogaton/github-users/internal/domain/entities"
+	"github.com/unkabogaton/github-users/internal/domain/interfaces"
 )
 
-func TestRedisCache_SetGetDeleteUser(t *testing.T) {
+// cacheImplementations lists every interfaces.Cache the behavioural tests
+// below run against, each backed by the given miniredis client.
+var cacheImplementations = map[string]func(t *testing.T, client *redis.Client) interfaces.Cache{
+	"redis": func(t *testing.T, client *redis.Client) interfaces.Cache {
+		return &RedisCache{redisClient: client, ttl: time.Minute, notFoundTTL: 30 * time.Second}
+	},
+	"tiered": func(t *testing.T, client *redis.Client) interfaces.Cache {
+		next := &RedisCache{redisClient: client, ttl: time.Minute, notFoundTTL: 30 * time.Second}
+		cache := newTieredCache(next, client, 100, time.Minute)
+		t.Cleanup(func() { _ = cache.Close() })
+		return cache
+	},
+}
+
+func runCacheSuite(t *testing.T, test func(t *testing.T, mini *miniredis.Miniredis, cache interfaces.Cache)) {
+	for name, newCache := range cacheImplementations {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			mini, err := miniredis.Run()
+			require.NoError(t, err)
+			defer mini.Close()
+
+			client := redis.NewClient(&redis.Options{Addr: mini.Addr()})
+			test(t, mini, newCache(t, client))
+		})
+	}
+}
+
+func TestCache_SetGetDeleteUser(t *testing.T) {
+	t.Parallel()
+	runCacheSuite(t, func(t *testing.T, mini *miniredis.Miniredis, cache interfaces.Cache) {
+		ctx := context.Background()
+		user := &entities.User{ID: 1, Login: "sample_username"}
+
+		require.NoError(t, cache.SetUser(ctx, user))
+
+		got, hit, err := cache.GetUser(ctx, "sample_username")
+		require.NoError(t, err)
+		require.True(t, hit)
+		require.Equal(t, user.Login, got.Login)
+
+		require.NoError(t, cache.DeleteUser(ctx, "sample_username"))
+		got, hit, err = cache.GetUser(ctx, "sample_username")
+		require.NoError(t, err)
+		require.False(t, hit)
+		require.Nil(t, got)
+	})
+}
+
+func TestCache_NotFoundTombstones(t *testing.T) {
+	t.Parallel()
+	runCacheSuite(t, func(t *testing.T, mini *miniredis.Miniredis, cache interfaces.Cache) {
+		ctx := context.Background()
+		require.NoError(t, cache.SetNotFound(ctx, "ghost"))
+		require.Equal(t, 30*time.Second, mini.TTL("user:notfound:ghost"))
+
+		entry, hit, err := cache.GetUserEntry(ctx, "ghost")
+		require.NoError(t, err)
+		require.True(t, hit)
+		require.True(t, entry.NotFound)
+		require.Nil(t, entry.User)
+
+		user, hit, err := cache.GetUser(ctx, "ghost")
+		require.NoError(t, err)
+		require.False(t, hit)
+		require.Nil(t, user)
+
+		require.NoError(t, cache.ClearNotFound(ctx, "ghost", "other"))
+		_, hit, err = cache.GetUserEntry(ctx, "ghost")
+		require.NoError(t, err)
+		require.False(t, hit)
+
+		require.NoError(t, cache.SetNotFound(ctx, "ghost"))
+		require.NoError(t, cache.SetUser(ctx, &entities.User{ID: 3, Login: "ghost"}))
+		require.False(t, mini.Exists("user:notfound:ghost"))
+		entry, hit, err = cache.GetUserEntry(ctx, "ghost")
+		require.NoError(t, err)
+		require.True(t, hit)
+		require.False(t, entry.NotFound)
+	})
+}
+
+func TestRedisCache_NotFoundTombstonesDisabled(t *testing.T) {
 	t.Parallel()
 	mini, err := miniredis.Run()
 	require.NoError(t, err)
 	defer mini.Close()
 
 	client := redis.NewClient(&redis.Options{Addr: mini.Addr()})
-	cache := &RedisCache{redisClient: client, ttl: 0}
+	cache := &RedisCache{redisClient: client, ttl: time.Minute}
+
+	require.NoError(t, cache.SetNotFound(context.Background(), "nobody"))
+	require.False(t, mini.Exists("user:notfound:nobody"))
+}
+
+func TestRedisCache_CountKeysCountsOnlyUsers(t *testing.T) {
+	t.Parallel()
+	mini, err := miniredis.Run()
+	require.NoError(t, err)
+	defer mini.Close()
 
+	client := redis.NewClient(&redis.Options{Addr: mini.Addr()})
+	cache := &RedisCache{redisClient: client, ttl: time.Minute, notFoundTTL: time.Minute}
 	ctx := context.Background()
-	user := &entities.User{ID: 1, Login: "sample_username"}
 
-	require.NoError(t, cache.SetUser(ctx, user))
+	require.NoError(t, cache.SetUser(ctx, &entities.User{ID: 1, Login: "octocat"}))
+	require.NoError(t, cache.SetNotFound(ctx, "nobody"))
+	require.NoError(t, mini.Set("users:list:0:id:ASC:10:1", "[]"))
+	require.NoError(t, mini.Set("etag:/users/octocat", "W/\"1\""))
 
-	got, hit, err := cache.GetUser(ctx, "sample_username")
+	keyCount, err := cache.CountKeys(ctx)
 	require.NoError(t, err)
-	require.True(t, hit)
-	require.Equal(t, user.Login, got.Login)
+	require.EqualValues(t, 2, keyCount)
+}
+
+func TestRedisCache_ReadsValuesWrittenWithOtherCodecs(t *testing.T) {
+	t.Parallel()
+	mini, err := miniredis.Run()
+	require.NoError(t, err)
+	defer mini.Close()
 
-	require.NoError(t, cache.DeleteUser(ctx, "sample_username"))
-	got, hit, err = cache.GetUser(ctx, "sample_username")
+	client := redis.NewClient(&redis.Options{Addr: mini.Addr()})
+	reader := &RedisCache{redisClient: client, ttl: time.Minute, codec: jsonUserCodec{}}
+	ctx := context.Background()
+
+	for _, codec := range []UserCodec{jsonUserCodec{}, protobufUserCodec{}, binaryUserCodec{}} {
+		writer := &RedisCache{redisClient: client, ttl: time.Minute, codec: codec}
+		require.NoError(t, writer.SetUser(ctx, sampleCachedUser()))
+
+		value, err := mini.Get("user:octocat")
+		require.NoError(t, err)
+		require.Equal(t, codec.Format(), value[0])
+
+		entry, hit, err := reader.GetUserEntry(ctx, "octocat")
+		require.NoError(t, err)
+		require.True(t, hit)
+		require.False(t, entry.Stale)
+		require.Equal(t, "The Octocat", entry.User.Name)
+	}
+}
+
+func TestRedisCache_UnknownFormatIsMiss(t *testing.T) {
+	t.Parallel()
+	mini, err := miniredis.Run()
+	require.NoError(t, err)
+	defer mini.Close()
+
+	client := redis.NewClient(&redis.Options{Addr: mini.Addr()})
+	cache := &RedisCache{redisClient: client, ttl: time.Minute}
+
+	require.NoError(t, mini.Set("user:octocat", "\x7fsomething from a newer build"))
+	user, hit, err := cache.GetUser(context.Background(), "octocat")
 	require.NoError(t, err)
 	require.False(t, hit)
-	require.Nil(t, got)
+	require.Nil(t, user)
+}
+
+func TestRedisCache_SoftAndHardTTL(t *testing.T) {
+	t.Parallel()
+	mini, err := miniredis.Run()
+	require.NoError(t, err)
+	defer mini.Close()
+
+	client := redis.NewClient(&redis.Options{Addr: mini.Addr()})
+	cache := &RedisCache{redisClient: client, ttl: time.Minute, staleTTL: time.Hour}
+
+	ctx := context.Background()
+	require.NoError(t, cache.SetUser(ctx, &entities.User{ID: 1, Login: "octocat"}))
+	require.Equal(t, time.Minute+time.Hour, mini.TTL("user:octocat"))
+
+	entry, hit, err := cache.GetUserEntry(ctx, "octocat")
+	require.NoError(t, err)
+	require.True(t, hit)
+	require.False(t, entry.Stale)
+
+	expired := fmt.Sprintf(`{"user":{"ID":1,"Login":"octocat"},"soft_expires_at":%d}`, time.Now().Add(-time.Second).Unix())
+	require.NoError(t, mini.Set("user:octocat", expired))
+	entry, hit, err = cache.GetUserEntry(ctx, "octocat")
+	require.NoError(t, err)
+	require.True(t, hit)
+	require.True(t, entry.Stale)
+	require.Equal(t, "octocat", entry.User.Login)
+
+	require.NoError(t, mini.Set("user:hubot", `{"ID":2,"Login":"hubot"}`))
+	entry, hit, err = cache.GetUserEntry(ctx, "hubot")
+	require.NoError(t, err)
+	require.True(t, hit)
+	require.False(t, entry.Stale)
+	require.Equal(t, 2, entry.User.ID)
 }
@@ -0,0 +1,155 @@
+package cache
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"log"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/go-redis/redis/v8"
+	derr "github.com/unkabogaton/github-users/internal/domain/errors"
+)
+
+const (
+	RedisModeStandalone = "standalone"
+	RedisModeSentinel   = "sentinel"
+	RedisModeCluster    = "cluster"
+)
+
+// RedisOptions describe the Redis deployment shared by the caches. Addresses
+// is the single server in standalone mode and the seed list of sentinels or
+// cluster nodes otherwise.
+type RedisOptions struct {
+	Mode             string
+	Addresses        []string
+	MasterName       string
+	Username         string
+	Password         string
+	SentinelPassword string
+	DB               int
+	TLS              bool
+	PoolSize         int
+	MinIdleConns     int
+}
+
+// RedisOptionsFromEnvironment reads the REDIS_* environment variables.
+// REDIS_ADDRESS may list several comma-separated addresses.
+func RedisOptionsFromEnvironment() (RedisOptions, error) {
+	options := RedisOptions{
+		Mode:             os.Getenv("REDIS_MODE"),
+		MasterName:       os.Getenv("REDIS_MASTER_NAME"),
+		Username:         os.Getenv("REDIS_USERNAME"),
+		Password:         os.Getenv("REDIS_PASSWORD"),
+		SentinelPassword: os.Getenv("REDIS_SENTINEL_PASSWORD"),
+	}
+	for _, address := range strings.Split(os.Getenv("REDIS_ADDRESS"), ",") {
+		if address = strings.TrimSpace(address); address != "" {
+			options.Addresses = append(options.Addresses, address)
+		}
+	}
+
+	for variable, target := range map[string]*int{
+		"REDIS_DB":             &options.DB,
+		"REDIS_POOL_SIZE":      &options.PoolSize,
+		"REDIS_MIN_IDLE_CONNS": &options.MinIdleConns,
+	} {
+		if value := os.Getenv(variable); value != "" {
+			parsed, err := strconv.Atoi(value)
+			if err != nil {
+				return RedisOptions{}, derr.Wrap(derr.ErrorCodeValidation, fmt.Sprintf("invalid %s", variable), err)
+			}
+			*target = parsed
+		}
+	}
+	if value := os.Getenv("REDIS_TLS"); value != "" {
+		useTLS, err := strconv.ParseBool(value)
+		if err != nil {
+			return RedisOptions{}, derr.Wrap(derr.ErrorCodeValidation, "invalid REDIS_TLS", err)
+		}
+		options.TLS = useTLS
+	}
+
+	return options.withDefaults(), nil
+}
+
+func (options RedisOptions) withDefaults() RedisOptions {
+	if options.Mode == "" {
+		options.Mode = RedisModeStandalone
+	}
+	return options
+}
+
+// Validate reports settings that do not fit the selected mode.
+func (options RedisOptions) Validate() error {
+	if options.PoolSize < 0 || options.MinIdleConns < 0 {
+		return derr.New(derr.ErrorCodeValidation, "Redis pool sizes must not be negative")
+	}
+	if len(options.Addresses) == 0 {
+		return derr.New(derr.ErrorCodeValidation, "at least one Redis address is required")
+	}
+
+	switch options.Mode {
+	case RedisModeStandalone:
+		if len(options.Addresses) != 1 {
+			return derr.New(derr.ErrorCodeValidation, "standalone Redis takes exactly one address")
+		}
+	case RedisModeSentinel:
+		if options.MasterName == "" {
+			return derr.New(derr.ErrorCodeValidation, "sentinel Redis needs a master name")
+		}
+	case RedisModeCluster:
+		if options.DB != 0 {
+			return derr.New(derr.ErrorCodeValidation, "Redis Cluster only has database 0")
+		}
+	default:
+		return derr.New(derr.ErrorCodeValidation, fmt.Sprintf("unknown Redis mode %q", options.Mode))
+	}
+	return nil
+}
+
+func (options RedisOptions) universalOptions() *redis.UniversalOptions {
+	universalOptions := &redis.UniversalOptions{
+		Addrs:            options.Addresses,
+		DB:               options.DB,
+		Username:         options.Username,
+		Password:         options.Password,
+		SentinelPassword: options.SentinelPassword,
+		MasterName:       options.MasterName,
+		PoolSize:         options.PoolSize,
+		MinIdleConns:     options.MinIdleConns,
+	}
+	if options.TLS {
+		universalOptions.TLSConfig = &tls.Config{MinVersion: tls.VersionTLS12}
+	}
+	return universalOptions
+}
+
+// NewRedisClient validates options, connects in the selected mode and checks
+// the connection with a PING.
+func NewRedisClient(ctx context.Context, options RedisOptions) (redis.UniversalClient, error) {
+	options = options.withDefaults()
+	if err := options.Validate(); err != nil {
+		return nil, err
+	}
+
+	universalOptions := options.universalOptions()
+	var client redis.UniversalClient
+	switch options.Mode {
+	case RedisModeSentinel:
+		client = redis.NewFailoverClient(universalOptions.Failover())
+	case RedisModeCluster:
+		client = redis.NewClusterClient(universalOptions.Cluster())
+	default:
+		client = redis.NewClient(universalOptions.Simple())
+	}
+
+	if err := client.Ping(ctx).Err(); err != nil {
+		_ = client.Close()
+		return nil, derr.Wrap(derr.ErrorCodeInternal, fmt.Sprintf("failed to reach Redis (%s)", options.Mode), err)
+	}
+	log.Printf("[INFO] Redis client (%s) initialized at %s", options.Mode, strings.Join(options.Addresses, ","))
+	return client, nil
+}
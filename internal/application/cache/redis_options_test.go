@@ -0,0 +1,80 @@
+package cache
+
+import (
+	"context"
+	"testing"
+
+	miniredis "github.com/alicebob/miniredis/v2"
+	"github.com/stretchr/testify/require"
+
+	derr "github.com/unkabogaton/github-users/internal/domain/errors"
+)
+
+func TestRedisOptions_Validate(t *testing.T) {
+	t.Parallel()
+	testCases := map[string]struct {
+		options RedisOptions
+		valid   bool
+	}{
+		"standalone":               {RedisOptions{Mode: RedisModeStandalone, Addresses: []string{"redis:6379"}}, true},
+		"standalone two addresses": {RedisOptions{Mode: RedisModeStandalone, Addresses: []string{"a:6379", "b:6379"}}, false},
+		"no address":               {RedisOptions{Mode: RedisModeStandalone}, false},
+		"sentinel":                 {RedisOptions{Mode: RedisModeSentinel, Addresses: []string{"s1:26379", "s2:26379"}, MasterName: "mymaster"}, true},
+		"sentinel without master":  {RedisOptions{Mode: RedisModeSentinel, Addresses: []string{"s1:26379"}}, false},
+		"cluster":                  {RedisOptions{Mode: RedisModeCluster, Addresses: []string{"n1:6379", "n2:6379"}}, true},
+		"cluster with db":          {RedisOptions{Mode: RedisModeCluster, Addresses: []string{"n1:6379"}, DB: 2}, false},
+		"negative pool":            {RedisOptions{Mode: RedisModeStandalone, Addresses: []string{"redis:6379"}, PoolSize: -1}, false},
+		"unknown mode":             {RedisOptions{Mode: "replicated", Addresses: []string{"redis:6379"}}, false},
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			err := testCase.options.Validate()
+			if testCase.valid {
+				require.NoError(t, err)
+				return
+			}
+			require.True(t, derr.IsCode(err, derr.ErrorCodeValidation))
+		})
+	}
+}
+
+func TestRedisOptionsFromEnvironment(t *testing.T) {
+	t.Setenv("REDIS_MODE", "sentinel")
+	t.Setenv("REDIS_ADDRESS", "s1:26379, s2:26379")
+	t.Setenv("REDIS_MASTER_NAME", "mymaster")
+	t.Setenv("REDIS_DB", "3")
+	t.Setenv("REDIS_TLS", "true")
+	t.Setenv("REDIS_POOL_SIZE", "20")
+
+	options, err := RedisOptionsFromEnvironment()
+	require.NoError(t, err)
+	require.Equal(t, []string{"s1:26379", "s2:26379"}, options.Addresses)
+	require.Equal(t, 3, options.DB)
+	require.True(t, options.TLS)
+	require.Equal(t, 20, options.PoolSize)
+	require.NoError(t, options.Validate())
+	require.NotNil(t, options.universalOptions().TLSConfig)
+
+	t.Setenv("REDIS_DB", "three")
+	_, err = RedisOptionsFromEnvironment()
+	require.True(t, derr.IsCode(err, derr.ErrorCodeValidation))
+}
+
+func TestNewRedisClient_PingsOnStartup(t *testing.T) {
+	t.Parallel()
+	mini, err := miniredis.Run()
+	require.NoError(t, err)
+	address := mini.Addr()
+
+	client, err := NewRedisClient(context.Background(), RedisOptions{Addresses: []string{address}})
+	require.NoError(t, err)
+	require.NoError(t, client.Close())
+
+	mini.Close()
+	_, err = NewRedisClient(context.Background(), RedisOptions{Addresses: []string{address}})
+	require.True(t, derr.IsCode(err, derr.ErrorCodeInternal))
+
+	_, err = NewRedisClient(context.Background(), RedisOptions{Mode: RedisModeSentinel, Addresses: []string{address}})
+	require.True(t, derr.IsCode(err, derr.ErrorCodeValidation))
+}
@@ -0,0 +1,200 @@
+package cache
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"log"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/unkabogaton/github-users/internal/domain/entities"
+	"github.com/unkabogaton/github-users/internal/domain/interfaces"
+)
+
+// invalidationChannel is the Redis pub/sub channel on which TieredCache
+// instances announce the logins they changed.
+const invalidationChannel = "github-users:cache-invalidation"
+
+// TieredCache keeps recently read entries of another Cache in process memory,
+// bounded by maximumEntries and ttl. Writes go through to the wrapped cache
+// and are announced over Redis pub/sub, so that other replicas drop their
+// local copies.
+type TieredCache struct {
+	next        interfaces.Cache
+	entries     *lru[string, localCacheEntry]
+	ttl         time.Duration
+	redisClient redis.UniversalClient
+	pubSub      *redis.PubSub
+	instanceID  string
+}
+
+type localCacheEntry struct {
+	entry     interfaces.CacheEntry
+	expiresAt time.Time
+}
+
+type invalidationMessage struct {
+	Origin string   `json:"origin"`
+	Logins []string `json:"logins"`
+}
+
+func NewTieredCache(
+	next interfaces.Cache,
+	redisClient redis.UniversalClient,
+	maximumEntries, ttlSeconds int,
+) interfaces.Cache {
+	log.Printf("[INFO] Local cache initialized with %d entries", maximumEntries)
+	return newTieredCache(next, redisClient, maximumEntries, time.Duration(ttlSeconds)*time.Second)
+}
+
+func newTieredCache(
+	next interfaces.Cache,
+	redisClient redis.UniversalClient,
+	maximumEntries int,
+	ttl time.Duration,
+) *TieredCache {
+	instanceBytes := make([]byte, 8)
+	_, _ = rand.Read(instanceBytes)
+
+	cache := &TieredCache{
+		next:        next,
+		entries:     newLRU[string, localCacheEntry](maximumEntries),
+		ttl:         ttl,
+		redisClient: redisClient,
+		instanceID:  hex.EncodeToString(instanceBytes),
+	}
+	cache.subscribe()
+	return cache
+}
+
+// subscribe listens for invalidations from other instances. The subscription
+// is confirmed before returning; if Redis is unreachable the client keeps
+// reconnecting in the background.
+func (cache *TieredCache) subscribe() {
+	ctx := context.Background()
+	cache.pubSub = cache.redisClient.Subscribe(ctx, invalidationChannel)
+	if _, err := cache.pubSub.Receive(ctx); err != nil {
+		log.Printf("[ERROR] Redis SUBSCRIBE error for channel %s: %v", invalidationChannel, err)
+	}
+
+	go func() {
+		for message := range cache.pubSub.Channel() {
+			var invalidation invalidationMessage
+			if err := json.Unmarshal([]byte(message.Payload), &invalidation); err != nil {
+				log.Printf("[ERROR] Failed to unmarshal invalidation message: %v", err)
+				continue
+			}
+			if invalidation.Origin == cache.instanceID {
+				continue
+			}
+			for _, login := range invalidation.Logins {
+				cache.entries.Delete(login)
+			}
+		}
+	}()
+}
+
+// CountKeys returns the number of entries held in process memory.
+func (cache *TieredCache) CountKeys(ctx context.Context) (int64, error) {
+	return int64(cache.entries.Len()), nil
+}
+
+// Close stops listening for invalidations.
+func (cache *TieredCache) Close() error {
+	return cache.pubSub.Close()
+}
+
+func (cache *TieredCache) GetUser(ctx context.Context, login string) (*entities.User, bool, error) {
+	cacheEntry, cacheHit, err := cache.GetUserEntry(ctx, login)
+	if err != nil || !cacheHit || cacheEntry.NotFound {
+		return nil, false, err
+	}
+	return cacheEntry.User, true, nil
+}
+
+func (cache *TieredCache) GetUserEntry(ctx context.Context, login string) (*interfaces.CacheEntry, bool, error) {
+	if local, ok := cache.entries.Get(login); ok {
+		if time.Now().Before(local.expiresAt) {
+			return copyCacheEntry(local.entry), true, nil
+		}
+		cache.entries.Delete(login)
+	}
+
+	cacheEntry, cacheHit, err := cache.next.GetUserEntry(ctx, login)
+	if err != nil || !cacheHit {
+		return cacheEntry, cacheHit, err
+	}
+	cache.store(login, *cacheEntry)
+	return copyCacheEntry(*cacheEntry), true, nil
+}
+
+func (cache *TieredCache) SetUser(ctx context.Context, user *entities.User) error {
+	if err := cache.next.SetUser(ctx, user); err != nil {
+		return err
+	}
+	cache.store(user.Login, interfaces.CacheEntry{User: user})
+	return cache.publish(ctx, user.Login)
+}
+
+func (cache *TieredCache) DeleteUser(ctx context.Context, login string) error {
+	if err := cache.next.DeleteUser(ctx, login); err != nil {
+		return err
+	}
+	cache.entries.Delete(login)
+	return cache.publish(ctx, login)
+}
+
+func (cache *TieredCache) SetNotFound(ctx context.Context, login string) error {
+	if err := cache.next.SetNotFound(ctx, login); err != nil {
+		return err
+	}
+	cache.entries.Delete(login)
+	return cache.publish(ctx, login)
+}
+
+func (cache *TieredCache) ClearNotFound(ctx context.Context, logins ...string) error {
+	if len(logins) == 0 {
+		return nil
+	}
+	if err := cache.next.ClearNotFound(ctx, logins...); err != nil {
+		return err
+	}
+	for _, login := range logins {
+		cache.entries.Delete(login)
+	}
+	return cache.publish(ctx, logins...)
+}
+
+// store keeps a private copy of cacheEntry, so that callers mutating the
+// users they are handed cannot change what later callers read.
+func (cache *TieredCache) store(login string, cacheEntry interfaces.CacheEntry) {
+	if cache.ttl <= 0 {
+		return
+	}
+	cache.entries.Set(login, localCacheEntry{
+		entry:     *copyCacheEntry(cacheEntry),
+		expiresAt: time.Now().Add(cache.ttl),
+	})
+}
+
+func (cache *TieredCache) publish(ctx context.Context, logins ...string) error {
+	payload, err := json.Marshal(invalidationMessage{Origin: cache.instanceID, Logins: logins})
+	if err != nil {
+		return err
+	}
+	if err := cache.redisClient.Publish(ctx, invalidationChannel, payload).Err(); err != nil {
+		log.Printf("[ERROR] Redis PUBLISH error for channel %s: %v", invalidationChannel, err)
+		return err
+	}
+	return nil
+}
+
+func copyCacheEntry(cacheEntry interfaces.CacheEntry) *interfaces.CacheEntry {
+	if cacheEntry.User != nil {
+		userCopy := *cacheEntry.User
+		cacheEntry.User = &userCopy
+	}
+	return &cacheEntry
+}
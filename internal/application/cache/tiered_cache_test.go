@@ -0,0 +1,95 @@
+package cache
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	miniredis "github.com/alicebob/miniredis/v2"
+	"github.com/go-redis/redis/v8"
+	"github.com/stretchr/testify/require"
+
+	"github.com/unkabogaton/github-users/internal/domain/entities"
+)
+
+func newTestTieredCache(t *testing.T, mini *miniredis.Miniredis, ttl time.Duration) *TieredCache {
+	client := redis.NewClient(&redis.Options{Addr: mini.Addr()})
+	next := &RedisCache{redisClient: client, ttl: time.Minute}
+	cache := newTieredCache(next, client, 2, ttl)
+	t.Cleanup(func() { _ = cache.Close() })
+	return cache
+}
+
+func TestTieredCache_ServesLocalCopyUntilTTL(t *testing.T) {
+	t.Parallel()
+	mini, err := miniredis.Run()
+	require.NoError(t, err)
+	defer mini.Close()
+
+	cache := newTestTieredCache(t, mini, 100*time.Millisecond)
+	ctx := context.Background()
+	require.NoError(t, cache.SetUser(ctx, &entities.User{ID: 1, Login: "octocat", Name: "Octo"}))
+
+	mini.Del("user:octocat")
+	got, hit, err := cache.GetUser(ctx, "octocat")
+	require.NoError(t, err)
+	require.True(t, hit)
+	require.Equal(t, "Octo", got.Name)
+
+	got.Name = "changed by caller"
+	got, _, err = cache.GetUser(ctx, "octocat")
+	require.NoError(t, err)
+	require.Equal(t, "Octo", got.Name)
+
+	require.Eventually(t, func() bool {
+		_, hit, err := cache.GetUser(ctx, "octocat")
+		return err == nil && !hit
+	}, time.Second, 10*time.Millisecond)
+}
+
+func TestTieredCache_EvictsLeastRecentlyUsed(t *testing.T) {
+	t.Parallel()
+	mini, err := miniredis.Run()
+	require.NoError(t, err)
+	defer mini.Close()
+
+	cache := newTestTieredCache(t, mini, time.Minute)
+	ctx := context.Background()
+	for index, login := range []string{"a", "b", "c"} {
+		require.NoError(t, cache.SetUser(ctx, &entities.User{ID: index + 1, Login: login}))
+	}
+	require.Equal(t, 2, cache.entries.Len())
+	_, ok := cache.entries.Get("a")
+	require.False(t, ok)
+}
+
+func TestTieredCache_InvalidatesOtherInstances(t *testing.T) {
+	t.Parallel()
+	mini, err := miniredis.Run()
+	require.NoError(t, err)
+	defer mini.Close()
+
+	first := newTestTieredCache(t, mini, time.Minute)
+	second := newTestTieredCache(t, mini, time.Minute)
+	ctx := context.Background()
+
+	require.NoError(t, first.SetUser(ctx, &entities.User{ID: 1, Login: "octocat", Name: "Old"}))
+	got, _, err := second.GetUser(ctx, "octocat")
+	require.NoError(t, err)
+	require.Equal(t, "Old", got.Name)
+
+	require.NoError(t, first.SetUser(ctx, &entities.User{ID: 1, Login: "octocat", Name: "New"}))
+	require.Eventually(t, func() bool {
+		got, hit, err := second.GetUser(ctx, "octocat")
+		return err == nil && hit && got.Name == "New"
+	}, time.Second, 10*time.Millisecond)
+
+	require.NoError(t, first.DeleteUser(ctx, "octocat"))
+	require.Eventually(t, func() bool {
+		_, hit, err := second.GetUser(ctx, "octocat")
+		return err == nil && !hit
+	}, time.Second, 10*time.Millisecond)
+
+	_, ok := first.entries.Get("octocat")
+	require.False(t, ok)
+}
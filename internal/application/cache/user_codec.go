@@ -0,0 +1,330 @@
+package cache
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/unkabogaton/github-users/internal/domain/entities"
+	"github.com/unkabogaton/github-users/internal/infrastructure/grpc/gen"
+	"google.golang.org/protobuf/proto"
+)
+
+// UserCodec turns users into bytes for Redis. Every stored value starts with
+// the Format byte of the codec that wrote it, so that builds configured with
+// different codecs can read each other's values during a rollout.
+type UserCodec interface {
+	Format() byte
+	Marshal(user *entities.User) ([]byte, error)
+	Unmarshal(data []byte) (*entities.User, error)
+}
+
+// Format bytes already in use must never be reassigned. Values written before
+// codecs existed are bare JSON and start with '{'.
+const (
+	formatJSON     byte = 1
+	formatProtobuf byte = 2
+	formatBinary   byte = 3
+	// formatBinaryProfileFetchedAt is formatBinary followed by
+	// ProfileFetchedAt.
+	formatBinaryProfileFetchedAt byte = 4
+)
+
+var userCodecs = map[byte]UserCodec{
+	formatJSON:                   jsonUserCodec{},
+	formatProtobuf:               protobufUserCodec{},
+	formatBinary:                 binaryUserCodec{},
+	formatBinaryProfileFetchedAt: binaryUserCodec{profileFetchedAt: true},
+}
+
+// UserCodecByName returns the codec configured as json, protobuf or binary.
+func UserCodecByName(name string) (UserCodec, error) {
+	switch name {
+	case "", "json":
+		return jsonUserCodec{}, nil
+	case "protobuf":
+		return protobufUserCodec{}, nil
+	case "binary":
+		return binaryUserCodec{profileFetchedAt: true}, nil
+	default:
+		return nil, fmt.Errorf("unknown cache codec %q", name)
+	}
+}
+
+type jsonUserCodec struct{}
+
+func (jsonUserCodec) Format() byte { return formatJSON }
+
+func (jsonUserCodec) Marshal(user *entities.User) ([]byte, error) {
+	return json.Marshal(user)
+}
+
+func (jsonUserCodec) Unmarshal(data []byte) (*entities.User, error) {
+	var user entities.User
+	if err := json.Unmarshal(data, &user); err != nil {
+		return nil, err
+	}
+	return &user, nil
+}
+
+// protobufUserCodec stores users as gen.User. Timestamps keep whole seconds.
+type protobufUserCodec struct{}
+
+func (protobufUserCodec) Format() byte { return formatProtobuf }
+
+func (protobufUserCodec) Marshal(user *entities.User) ([]byte, error) {
+	return proto.Marshal(&gen.User{
+		Id:               int64(user.ID),
+		Login:            user.Login,
+		NodeId:           user.NodeID,
+		AvatarUrl:        user.AvatarURL,
+		Url:              user.URL,
+		HtmlUrl:          user.HTMLURL,
+		Type:             user.Type,
+		UserViewType:     user.UserViewType,
+		SiteAdmin:        user.SiteAdmin,
+		Name:             user.Name,
+		Company:          user.Company,
+		Blog:             user.Blog,
+		Location:         user.Location,
+		Email:            user.Email,
+		Bio:              user.Bio,
+		TwitterUsername:  user.TwitterUsername,
+		PublicRepos:      int32(user.PublicRepos),
+		PublicGists:      int32(user.PublicGists),
+		Followers:        int32(user.Followers),
+		Following:        int32(user.Following),
+		GithubCreatedAt:  optionalUnixSeconds(user.GitHubCreatedAt),
+		GithubUpdatedAt:  optionalUnixSeconds(user.GitHubUpdatedAt),
+		UpdatedAt:        optionalUnixSeconds(&user.UpdatedAt),
+		CreatedAt:        optionalUnixSeconds(&user.CreatedAt),
+		ProfileFetchedAt: optionalUnixSeconds(user.ProfileFetchedAt),
+	})
+}
+
+func (protobufUserCodec) Unmarshal(data []byte) (*entities.User, error) {
+	var protoUser gen.User
+	if err := proto.Unmarshal(data, &protoUser); err != nil {
+		return nil, err
+	}
+
+	user := &entities.User{
+		ID:               int(protoUser.GetId()),
+		Login:            protoUser.GetLogin(),
+		NodeID:           protoUser.GetNodeId(),
+		AvatarURL:        protoUser.GetAvatarUrl(),
+		URL:              protoUser.GetUrl(),
+		HTMLURL:          protoUser.GetHtmlUrl(),
+		Type:             protoUser.GetType(),
+		UserViewType:     protoUser.GetUserViewType(),
+		SiteAdmin:        protoUser.GetSiteAdmin(),
+		Name:             protoUser.GetName(),
+		Company:          protoUser.GetCompany(),
+		Blog:             protoUser.GetBlog(),
+		Location:         protoUser.GetLocation(),
+		Email:            protoUser.GetEmail(),
+		Bio:              protoUser.GetBio(),
+		TwitterUsername:  protoUser.GetTwitterUsername(),
+		PublicRepos:      int(protoUser.GetPublicRepos()),
+		PublicGists:      int(protoUser.GetPublicGists()),
+		Followers:        int(protoUser.GetFollowers()),
+		Following:        int(protoUser.GetFollowing()),
+		GitHubCreatedAt:  optionalTime(protoUser.GetGithubCreatedAt()),
+		GitHubUpdatedAt:  optionalTime(protoUser.GetGithubUpdatedAt()),
+		ProfileFetchedAt: optionalTime(protoUser.GetProfileFetchedAt()),
+	}
+	if updatedAt := optionalTime(protoUser.GetUpdatedAt()); updatedAt != nil {
+		user.UpdatedAt = *updatedAt
+	}
+	if createdAt := optionalTime(protoUser.GetCreatedAt()); createdAt != nil {
+		user.CreatedAt = *createdAt
+	}
+	return user, nil
+}
+
+func optionalUnixSeconds(timestamp *time.Time) int64 {
+	if timestamp == nil || timestamp.IsZero() {
+		return 0
+	}
+	return timestamp.Unix()
+}
+
+func optionalTime(unixSeconds int64) *time.Time {
+	if unixSeconds == 0 {
+		return nil
+	}
+	timestamp := time.Unix(unixSeconds, 0).UTC()
+	return &timestamp
+}
+
+// binaryUserCodec writes the user fields in declaration order: varints for
+// numbers and timestamps (unix nanoseconds, 0 for none) and length-prefixed
+// strings. Adding or reordering fields needs a new format byte; profileFetchedAt
+// selects formatBinaryProfileFetchedAt over formatBinary.
+type binaryUserCodec struct {
+	profileFetchedAt bool
+}
+
+func (codec binaryUserCodec) Format() byte {
+	if codec.profileFetchedAt {
+		return formatBinaryProfileFetchedAt
+	}
+	return formatBinary
+}
+
+func (codec binaryUserCodec) Marshal(user *entities.User) ([]byte, error) {
+	writer := &binaryWriter{}
+	writer.int(int64(user.ID))
+	for _, field := range []string{
+		user.Login, user.NodeID, user.AvatarURL, user.URL, user.HTMLURL,
+		user.Type, user.UserViewType,
+	} {
+		writer.string(field)
+	}
+	writer.bool(user.SiteAdmin)
+	for _, field := range []string{
+		user.Name, user.Company, user.Blog, user.Location, user.Email,
+		user.Bio, user.TwitterUsername,
+	} {
+		writer.string(field)
+	}
+	for _, field := range []int{user.PublicRepos, user.PublicGists, user.Followers, user.Following} {
+		writer.int(int64(field))
+	}
+	writer.time(user.GitHubCreatedAt)
+	writer.time(user.GitHubUpdatedAt)
+	writer.time(&user.UpdatedAt)
+	writer.time(&user.CreatedAt)
+	if codec.profileFetchedAt {
+		writer.time(user.ProfileFetchedAt)
+	}
+	return writer.buffer.Bytes(), nil
+}
+
+func (codec binaryUserCodec) Unmarshal(data []byte) (*entities.User, error) {
+	reader := &binaryReader{reader: bytes.NewReader(data)}
+	user := &entities.User{}
+	user.ID = int(reader.int())
+	for _, field := range []*string{
+		&user.Login, &user.NodeID, &user.AvatarURL, &user.URL, &user.HTMLURL,
+		&user.Type, &user.UserViewType,
+	} {
+		*field = reader.string()
+	}
+	user.SiteAdmin = reader.bool()
+	for _, field := range []*string{
+		&user.Name, &user.Company, &user.Blog, &user.Location, &user.Email,
+		&user.Bio, &user.TwitterUsername,
+	} {
+		*field = reader.string()
+	}
+	for _, field := range []*int{&user.PublicRepos, &user.PublicGists, &user.Followers, &user.Following} {
+		*field = int(reader.int())
+	}
+	user.GitHubCreatedAt = reader.time()
+	user.GitHubUpdatedAt = reader.time()
+	if updatedAt := reader.time(); updatedAt != nil {
+		user.UpdatedAt = *updatedAt
+	}
+	if createdAt := reader.time(); createdAt != nil {
+		user.CreatedAt = *createdAt
+	}
+	if codec.profileFetchedAt {
+		user.ProfileFetchedAt = reader.time()
+	}
+
+	if reader.err != nil {
+		return nil, reader.err
+	}
+	if reader.reader.Len() != 0 {
+		return nil, errors.New("trailing bytes after binary user")
+	}
+	return user, nil
+}
+
+type binaryWriter struct {
+	buffer bytes.Buffer
+}
+
+func (writer *binaryWriter) int(value int64) {
+	writer.buffer.Write(binary.AppendVarint(nil, value))
+}
+
+func (writer *binaryWriter) bool(value bool) {
+	if value {
+		writer.buffer.WriteByte(1)
+	} else {
+		writer.buffer.WriteByte(0)
+	}
+}
+
+func (writer *binaryWriter) string(value string) {
+	writer.buffer.Write(binary.AppendUvarint(nil, uint64(len(value))))
+	writer.buffer.WriteString(value)
+}
+
+func (writer *binaryWriter) time(timestamp *time.Time) {
+	if timestamp == nil || timestamp.IsZero() {
+		writer.int(0)
+		return
+	}
+	writer.int(timestamp.UnixNano())
+}
+
+// binaryReader remembers the first error so that callers can read a whole
+// record and check once.
+type binaryReader struct {
+	reader *bytes.Reader
+	err    error
+}
+
+func (reader *binaryReader) int() int64 {
+	if reader.err != nil {
+		return 0
+	}
+	value, err := binary.ReadVarint(reader.reader)
+	reader.err = err
+	return value
+}
+
+func (reader *binaryReader) bool() bool {
+	if reader.err != nil {
+		return false
+	}
+	value, err := reader.reader.ReadByte()
+	reader.err = err
+	return value == 1
+}
+
+func (reader *binaryReader) string() string {
+	if reader.err != nil {
+		return ""
+	}
+	length, err := binary.ReadUvarint(reader.reader)
+	if err != nil {
+		reader.err = err
+		return ""
+	}
+	if length > uint64(reader.reader.Len()) {
+		reader.err = errors.New("binary user string overruns value")
+		return ""
+	}
+	if length == 0 {
+		return ""
+	}
+	value := make([]byte, length)
+	_, reader.err = reader.reader.Read(value)
+	return string(value)
+}
+
+func (reader *binaryReader) time() *time.Time {
+	unixNanoseconds := reader.int()
+	if reader.err != nil || unixNanoseconds == 0 {
+		return nil
+	}
+	timestamp := time.Unix(0, unixNanoseconds).UTC()
+	return &timestamp
+}
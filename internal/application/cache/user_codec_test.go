@@ -0,0 +1,98 @@
+package cache
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/unkabogaton/github-users/internal/domain/entities"
+)
+
+func sampleCachedUser() *entities.User {
+	gitHubCreatedAt := time.Date(2011, 1, 25, 18, 44, 36, 0, time.UTC)
+	profileFetchedAt := time.Date(2025, 3, 1, 11, 0, 0, 0, time.UTC)
+	return &entities.User{
+		ID:               583231,
+		Login:            "octocat",
+		NodeID:           "MDQ6VXNlcjU4MzIzMQ==",
+		AvatarURL:        "https://avatars.githubusercontent.com/u/583231?v=4",
+		URL:              "https://api.github.com/users/octocat",
+		HTMLURL:          "https://github.com/octocat",
+		Type:             "User",
+		UserViewType:     "public",
+		SiteAdmin:        true,
+		Name:             "The Octocat",
+		Company:          "@github",
+		Location:         "San Francisco",
+		PublicRepos:      8,
+		Followers:        20000,
+		GitHubCreatedAt:  &gitHubCreatedAt,
+		ProfileFetchedAt: &profileFetchedAt,
+		UpdatedAt:        time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC),
+		CreatedAt:        time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC),
+	}
+}
+
+func TestUserCodecs_RoundTrip(t *testing.T) {
+	t.Parallel()
+	for _, name := range []string{"json", "protobuf", "binary"} {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			codec, err := UserCodecByName(name)
+			require.NoError(t, err)
+			require.Equal(t, codec, userCodecs[codec.Format()])
+
+			data, err := codec.Marshal(sampleCachedUser())
+			require.NoError(t, err)
+			got, err := codec.Unmarshal(data)
+			require.NoError(t, err)
+
+			want := sampleCachedUser()
+			require.Nil(t, got.GitHubUpdatedAt)
+			require.True(t, want.GitHubCreatedAt.Equal(*got.GitHubCreatedAt))
+			require.True(t, want.UpdatedAt.Equal(got.UpdatedAt))
+			require.True(t, want.CreatedAt.Equal(got.CreatedAt))
+			require.True(t, want.ProfileFetchedAt.Equal(*got.ProfileFetchedAt))
+			got.GitHubCreatedAt, want.GitHubCreatedAt = nil, nil
+			got.ProfileFetchedAt, want.ProfileFetchedAt = nil, nil
+			got.UpdatedAt, want.UpdatedAt = time.Time{}, time.Time{}
+			got.CreatedAt, want.CreatedAt = time.Time{}, time.Time{}
+			require.Equal(t, want, got)
+		})
+	}
+}
+
+func TestUserCodecs_BinaryIsSmallest(t *testing.T) {
+	t.Parallel()
+	jsonData, err := jsonUserCodec{}.Marshal(sampleCachedUser())
+	require.NoError(t, err)
+	binaryData, err := binaryUserCodec{}.Marshal(sampleCachedUser())
+	require.NoError(t, err)
+	require.Less(t, len(binaryData), len(jsonData))
+}
+
+func TestUserCodecs_RejectsTruncatedBinary(t *testing.T) {
+	t.Parallel()
+	data, err := binaryUserCodec{}.Marshal(sampleCachedUser())
+	require.NoError(t, err)
+	_, err = binaryUserCodec{}.Unmarshal(data[:len(data)/2])
+	require.Error(t, err)
+}
+
+func TestUserCodecs_ReadsBinaryWithoutProfileFetchedAt(t *testing.T) {
+	t.Parallel()
+	data, err := binaryUserCodec{}.Marshal(sampleCachedUser())
+	require.NoError(t, err)
+
+	got, err := userCodecs[formatBinary].Unmarshal(data)
+	require.NoError(t, err)
+	require.Equal(t, "octocat", got.Login)
+	require.Nil(t, got.ProfileFetchedAt)
+}
+
+func TestUserCodecByName_Unknown(t *testing.T) {
+	t.Parallel()
+	_, err := UserCodecByName("xml")
+	require.Error(t, err)
+}
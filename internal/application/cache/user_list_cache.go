@@ -0,0 +1,201 @@
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/unkabogaton/github-users/internal/domain/entities"
+	"github.com/unkabogaton/github-users/internal/domain/interfaces"
+)
+
+// userListGenerationKey holds a counter that is part of every cached page's
+// key. Bumping it orphans all pages without scanning for them; the orphans
+// expire on their own TTL.
+const userListGenerationKey = "users:list:generation"
+
+type RedisUserListCache struct {
+	redisClient redis.UniversalClient
+	ttl         time.Duration
+}
+
+func NewRedisUserListCache(redisClient redis.UniversalClient, ttlSeconds int) interfaces.UserListCache {
+	return &RedisUserListCache{
+		redisClient: redisClient,
+		ttl:         time.Duration(ttlSeconds) * time.Second,
+	}
+}
+
+// userListKey builds the key of one page under generation. Options that only
+// differ in case or in the order of their filters select the same page and
+// share a key.
+func userListKey(generation int64, options interfaces.ListOptions) string {
+	return fmt.Sprintf("users:list:%d:%s", generation, listOptionsKey(options))
+}
+
+// userPageKey builds the key of one paginated page under generation. Pages
+// read by cursor ignore the page number, so it is left out of their key.
+func userPageKey(generation int64, options interfaces.ListOptions) string {
+	if options.Cursor != "" {
+		options.Page = 0
+	}
+	return fmt.Sprintf("users:page:%d:%s:%s", generation, url.QueryEscape(options.Cursor), listOptionsKey(options))
+}
+
+// userCountKey builds the key of the number of users matching filters.
+func userCountKey(generation int64, filters []interfaces.Filter) string {
+	return fmt.Sprintf("users:count:%d:%s", generation, filtersKey(filters))
+}
+
+// listOptionsKey keeps OrderBy as given: the repository matches sort columns
+// case-sensitively, so "Login" and "login" are different orders.
+func listOptionsKey(options interfaces.ListOptions) string {
+	key := fmt.Sprintf(
+		"%s:%s:%d:%d",
+		options.OrderBy,
+		strings.ToUpper(options.OrderDirection),
+		options.Limit,
+		options.Page,
+	)
+	if len(options.Filters) == 0 {
+		return key
+	}
+	return key + ":" + filtersKey(options.Filters)
+}
+
+// filtersKey lists filters in a fixed order, so that the order they were
+// given in does not matter.
+func filtersKey(filters []interfaces.Filter) string {
+	filterKeys := make([]string, 0, len(filters))
+	for _, filter := range filters {
+		filterKeys = append(filterKeys, fmt.Sprintf("%s:%s:%s", filter.Column, filter.Operator, filterValueKey(filter.Value)))
+	}
+	sort.Strings(filterKeys)
+	return strings.Join(filterKeys, ",")
+}
+
+// filterValueKey formats a filter value for a cache key. Times are
+// normalized, so that equal instants in different zones share a key.
+func filterValueKey(value interface{}) string {
+	if timestamp, ok := value.(time.Time); ok {
+		return timestamp.UTC().Format(time.RFC3339Nano)
+	}
+	return url.QueryEscape(fmt.Sprint(value))
+}
+
+func (cache *RedisUserListCache) UserListGeneration(ctx context.Context) (int64, error) {
+	generation, err := cache.redisClient.Get(ctx, userListGenerationKey).Int64()
+	if err == redis.Nil {
+		return 0, nil
+	}
+	if err != nil {
+		log.Printf("[ERROR] Redis GET error for key %s: %v", userListGenerationKey, err)
+	}
+	return generation, err
+}
+
+func (cache *RedisUserListCache) GetUserList(
+	ctx context.Context,
+	generation int64,
+	options interfaces.ListOptions,
+) ([]entities.User, bool, error) {
+	var users []entities.User
+	cacheHit, err := cache.get(ctx, userListKey(generation, options), &users)
+	return users, cacheHit, err
+}
+
+func (cache *RedisUserListCache) SetUserList(
+	ctx context.Context,
+	generation int64,
+	options interfaces.ListOptions,
+	users []entities.User,
+) error {
+	return cache.set(ctx, userListKey(generation, options), users)
+}
+
+func (cache *RedisUserListCache) GetUserPage(
+	ctx context.Context,
+	generation int64,
+	options interfaces.ListOptions,
+) (*interfaces.UserPage, bool, error) {
+	var userPage interfaces.UserPage
+	cacheHit, err := cache.get(ctx, userPageKey(generation, options), &userPage)
+	if !cacheHit {
+		return nil, false, err
+	}
+	return &userPage, true, nil
+}
+
+func (cache *RedisUserListCache) SetUserPage(
+	ctx context.Context,
+	generation int64,
+	options interfaces.ListOptions,
+	userPage *interfaces.UserPage,
+) error {
+	return cache.set(ctx, userPageKey(generation, options), userPage)
+}
+
+func (cache *RedisUserListCache) GetUserCount(
+	ctx context.Context,
+	generation int64,
+	filters []interfaces.Filter,
+) (int64, bool, error) {
+	var count int64
+	cacheHit, err := cache.get(ctx, userCountKey(generation, filters), &count)
+	return count, cacheHit, err
+}
+
+func (cache *RedisUserListCache) SetUserCount(
+	ctx context.Context,
+	generation int64,
+	filters []interfaces.Filter,
+	count int64,
+) error {
+	return cache.set(ctx, userCountKey(generation, filters), count)
+}
+
+// get decodes the JSON stored at key into target and reports whether key
+// was found.
+func (cache *RedisUserListCache) get(ctx context.Context, key string, target interface{}) (bool, error) {
+	value, err := cache.redisClient.Get(ctx, key).Result()
+	if err == redis.Nil {
+		return false, nil
+	}
+	if err != nil {
+		log.Printf("[ERROR] Redis GET error for key %s: %v", key, err)
+		return false, err
+	}
+
+	if err := json.Unmarshal([]byte(value), target); err != nil {
+		log.Printf("[ERROR] Failed to unmarshal cache value for key %s: %v", key, err)
+		return false, err
+	}
+	return true, nil
+}
+
+func (cache *RedisUserListCache) set(ctx context.Context, key string, value interface{}) error {
+	bytes, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+
+	if err := cache.redisClient.Set(ctx, key, bytes, cache.ttl).Err(); err != nil {
+		log.Printf("[ERROR] Redis SET error for key %s: %v", key, err)
+		return err
+	}
+	return nil
+}
+
+func (cache *RedisUserListCache) InvalidateUserLists(ctx context.Context) error {
+	if err := cache.redisClient.Incr(ctx, userListGenerationKey).Err(); err != nil {
+		log.Printf("[ERROR] Redis INCR error for key %s: %v", userListGenerationKey, err)
+		return err
+	}
+	return nil
+}
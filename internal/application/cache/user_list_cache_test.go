@@ -0,0 +1,100 @@
+package cache
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	miniredis "github.com/alicebob/miniredis/v2"
+	"github.com/go-redis/redis/v8"
+	"github.com/stretchr/testify/require"
+
+	"github.com/unkabogaton/github-users/internal/domain/entities"
+	"github.com/unkabogaton/github-users/internal/domain/interfaces"
+)
+
+func TestRedisUserListCache_SetGetInvalidate(t *testing.T) {
+	t.Parallel()
+	mini, err := miniredis.Run()
+	require.NoError(t, err)
+	defer mini.Close()
+
+	client := redis.NewClient(&redis.Options{Addr: mini.Addr()})
+	cache := &RedisUserListCache{redisClient: client, ttl: time.Minute}
+	ctx := context.Background()
+
+	options := interfaces.ListOptions{Limit: 10, Page: 1, OrderBy: "id", OrderDirection: "asc"}
+	users := []entities.User{{ID: 1, Login: "a"}, {ID: 2, Login: "b"}}
+	generation, err := cache.UserListGeneration(ctx)
+	require.NoError(t, err)
+	require.NoError(t, cache.SetUserList(ctx, generation, options, users))
+	require.Equal(t, time.Minute, mini.TTL("users:list:0:id:ASC:10:1"))
+
+	got, hit, err := cache.GetUserList(ctx, generation, interfaces.ListOptions{Limit: 10, Page: 1, OrderBy: "id", OrderDirection: "ASC"})
+	require.NoError(t, err)
+	require.True(t, hit)
+	require.Equal(t, users, got)
+
+	_, hit, err = cache.GetUserList(ctx, generation, interfaces.ListOptions{Limit: 10, Page: 2, OrderBy: "id", OrderDirection: "ASC"})
+	require.NoError(t, err)
+	require.False(t, hit)
+
+	require.NoError(t, cache.InvalidateUserLists(ctx))
+	generation, err = cache.UserListGeneration(ctx)
+	require.NoError(t, err)
+	_, hit, err = cache.GetUserList(ctx, generation, options)
+	require.NoError(t, err)
+	require.False(t, hit)
+}
+
+func TestUserListKey_IncludesFilters(t *testing.T) {
+	t.Parallel()
+	options := interfaces.ListOptions{Limit: 10, Page: 1, OrderBy: "id", OrderDirection: "asc"}
+	unfiltered := userListKey(0, options)
+
+	siteAdmin := true
+	updatedSince := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	options.Filters = interfaces.UserListFilters{SiteAdmin: &siteAdmin, UpdatedSince: &updatedSince}.Filters()
+	filtered := userListKey(0, options)
+	require.NotEqual(t, unfiltered, filtered)
+
+	reordered := options
+	reordered.Filters = []interfaces.Filter{options.Filters[1], options.Filters[0]}
+	reordered.Filters[0].Value = updatedSince.In(time.FixedZone("UTC+2", 2*60*60))
+	require.Equal(t, filtered, userListKey(0, reordered))
+
+	options.Filters = interfaces.UserListFilters{LoginPrefix: "a,b"}.Filters()
+	require.NotEqual(t, userListKey(0, options), userListKey(0, interfaces.ListOptions{
+		Limit: 10, Page: 1, OrderBy: "id", OrderDirection: "asc",
+		Filters: []interfaces.Filter{
+			{Column: "login", Operator: interfaces.FilterPrefix, Value: "a"},
+			{Column: "b", Operator: interfaces.FilterEqual, Value: ""},
+		},
+	}))
+}
+
+func TestUserListKey_KeepsOrderByCase(t *testing.T) {
+	t.Parallel()
+	// "Login" is no column, so the repository sorts it by id, unlike "login".
+	lowerCase := interfaces.ListOptions{Limit: 10, Page: 1, OrderBy: "login", OrderDirection: "asc"}
+	mixedCase := lowerCase
+	mixedCase.OrderBy = "Login"
+	require.NotEqual(t, userListKey(0, lowerCase), userListKey(0, mixedCase))
+	require.NotEqual(t, userPageKey(0, lowerCase), userPageKey(0, mixedCase))
+}
+
+func TestUserPageKey_IncludesCursor(t *testing.T) {
+	t.Parallel()
+	options := interfaces.ListOptions{Limit: 10, Page: 1, OrderBy: "id", OrderDirection: "asc"}
+	require.NotEqual(t, userListKey(0, options), userPageKey(0, options))
+
+	firstCursor, secondCursor := options, options
+	firstCursor.Cursor = "first"
+	secondCursor.Cursor = "second"
+	require.NotEqual(t, userPageKey(0, firstCursor), userPageKey(0, secondCursor))
+
+	// Cursor pages ignore the page number.
+	otherPage := firstCursor
+	otherPage.Page = 3
+	require.Equal(t, userPageKey(0, firstCursor), userPageKey(0, otherPage))
+}
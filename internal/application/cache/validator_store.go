@@ -0,0 +1,92 @@
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/unkabogaton/github-users/internal/domain/interfaces"
+)
+
+type MemoryValidatorStore struct {
+	entries *lru[string, interfaces.ResponseValidators]
+}
+
+func NewMemoryValidatorStore(maximumEntries int) interfaces.ValidatorStore {
+	return &MemoryValidatorStore{entries: newLRU[string, interfaces.ResponseValidators](maximumEntries)}
+}
+
+func (store *MemoryValidatorStore) GetValidators(
+	ctx context.Context,
+	requestURL string,
+) (*interfaces.ResponseValidators, bool, error) {
+	validators, ok := store.entries.Get(requestURL)
+	if !ok {
+		return nil, false, nil
+	}
+	return &validators, true, nil
+}
+
+func (store *MemoryValidatorStore) SetValidators(
+	ctx context.Context,
+	requestURL string,
+	validators *interfaces.ResponseValidators,
+) error {
+	store.entries.Set(requestURL, *validators)
+	return nil
+}
+
+type RedisValidatorStore struct {
+	redisClient redis.UniversalClient
+	ttl         time.Duration
+}
+
+func NewRedisValidatorStore(redisClient redis.UniversalClient, ttlSeconds int) interfaces.ValidatorStore {
+	return &RedisValidatorStore{
+		redisClient: redisClient,
+		ttl:         time.Duration(ttlSeconds) * time.Second,
+	}
+}
+
+func (store *RedisValidatorStore) GetValidators(
+	ctx context.Context,
+	requestURL string,
+) (*interfaces.ResponseValidators, bool, error) {
+	key := "etag:" + requestURL
+	value, err := store.redisClient.Get(ctx, key).Bytes()
+	if err == redis.Nil {
+		return nil, false, nil
+	}
+	if err != nil {
+		log.Printf("[ERROR] Redis GET error for key %s: %v", key, err)
+		return nil, false, err
+	}
+
+	var validators interfaces.ResponseValidators
+	if err := json.Unmarshal(value, &validators); err != nil {
+		log.Printf("[ERROR] Failed to unmarshal validators for key %s: %v", key, err)
+		return nil, false, err
+	}
+	return &validators, true, nil
+}
+
+func (store *RedisValidatorStore) SetValidators(
+	ctx context.Context,
+	requestURL string,
+	validators *interfaces.ResponseValidators,
+) error {
+	key := "etag:" + requestURL
+	bytes, err := json.Marshal(validators)
+	if err != nil {
+		log.Printf("[ERROR] Failed to marshal validators for key %s: %v", key, err)
+		return err
+	}
+
+	if err := store.redisClient.Set(ctx, key, bytes, store.ttl).Err(); err != nil {
+		log.Printf("[ERROR] Redis SET error for key %s: %v", key, err)
+		return err
+	}
+	return nil
+}
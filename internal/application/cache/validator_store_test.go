@@ -0,0 +1,60 @@
+package cache
+
+import (
+	"context"
+	"testing"
+
+	miniredis "github.com/alicebob/miniredis/v2"
+	"github.com/go-redis/redis/v8"
+	"github.com/stretchr/testify/require"
+
+	"github.com/unkabogaton/github-users/internal/domain/interfaces"
+)
+
+func TestMemoryValidatorStore_SetGetAndEvict(t *testing.T) {
+	t.Parallel()
+	store := NewMemoryValidatorStore(1)
+	ctx := context.Background()
+
+	_, found, err := store.GetValidators(ctx, "https://api.github.com/users/a")
+	require.NoError(t, err)
+	require.False(t, found)
+
+	require.NoError(t, store.SetValidators(ctx, "https://api.github.com/users/a", &interfaces.ResponseValidators{ETag: `"a"`}))
+	got, found, err := store.GetValidators(ctx, "https://api.github.com/users/a")
+	require.NoError(t, err)
+	require.True(t, found)
+	require.Equal(t, `"a"`, got.ETag)
+
+	require.NoError(t, store.SetValidators(ctx, "https://api.github.com/users/b", &interfaces.ResponseValidators{ETag: `"b"`}))
+	_, found, err = store.GetValidators(ctx, "https://api.github.com/users/a")
+	require.NoError(t, err)
+	require.False(t, found)
+}
+
+func TestRedisValidatorStore_SetGet(t *testing.T) {
+	t.Parallel()
+	mini, err := miniredis.Run()
+	require.NoError(t, err)
+	defer mini.Close()
+
+	client := redis.NewClient(&redis.Options{Addr: mini.Addr()})
+	store := &RedisValidatorStore{redisClient: client, ttl: 0}
+	ctx := context.Background()
+
+	validators := &interfaces.ResponseValidators{
+		ETag:         `W/"abc"`,
+		LastModified: "Mon, 01 Jan 2024 00:00:00 GMT",
+		Body:         []byte(`{"login":"sample_username"}`),
+	}
+	require.NoError(t, store.SetValidators(ctx, "https://api.github.com/users/sample_username", validators))
+
+	got, found, err := store.GetValidators(ctx, "https://api.github.com/users/sample_username")
+	require.NoError(t, err)
+	require.True(t, found)
+	require.Equal(t, validators, got)
+
+	_, found, err = store.GetValidators(ctx, "https://api.github.com/users/other")
+	require.NoError(t, err)
+	require.False(t, found)
+}
@@ -0,0 +1,61 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/unkabogaton/github-users/internal/domain/entities"
+	derr "github.com/unkabogaton/github-users/internal/domain/errors"
+	"github.com/unkabogaton/github-users/internal/domain/interfaces"
+)
+
+type FollowService struct {
+	followRepository interfaces.FollowRepository
+	userRepository   interfaces.UserRepository
+}
+
+func NewFollowService(
+	followRepository interfaces.FollowRepository,
+	userRepository interfaces.UserRepository,
+) interfaces.FollowService {
+	return &FollowService{
+		followRepository: followRepository,
+		userRepository:   userRepository,
+	}
+}
+
+func (s *FollowService) ListFollowers(
+	ctx context.Context,
+	username string,
+	options interfaces.ListOptions,
+) ([]entities.User, error) {
+	if err := requireStoredUser(ctx, s.userRepository, username); err != nil {
+		return nil, err
+	}
+	return s.followRepository.ListFollowers(ctx, username, withListDefaults(options))
+}
+
+func (s *FollowService) ListFollowing(
+	ctx context.Context,
+	username string,
+	options interfaces.ListOptions,
+) ([]entities.User, error) {
+	if err := requireStoredUser(ctx, s.userRepository, username); err != nil {
+		return nil, err
+	}
+	return s.followRepository.ListFollowing(ctx, username, withListDefaults(options))
+}
+
+// requireStoredUser tells an unknown user apart from a stored user without
+// any crawled edges, which would otherwise both come back as an empty list.
+func requireStoredUser(ctx context.Context, userRepository interfaces.UserRepository, username string) error {
+	if _, err := userRepository.GetByLogin(ctx, username); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return derr.New(derr.ErrorCodeNotFound, fmt.Sprintf("user %s not found", username))
+		}
+		return derr.Wrap(derr.ErrorCodeInternal, "failed to load user", err)
+	}
+	return nil
+}
@@ -0,0 +1,66 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/unkabogaton/github-users/internal/domain/entities"
+	derr "github.com/unkabogaton/github-users/internal/domain/errors"
+	"github.com/unkabogaton/github-users/internal/domain/interfaces"
+)
+
+type fakeFollowRepository struct {
+	followers map[string][]entities.User
+	options   interfaces.ListOptions
+}
+
+func (f *fakeFollowRepository) ReplaceFollowers(ctx context.Context, userID int, follows []entities.UserFollow) error {
+	return nil
+}
+
+func (f *fakeFollowRepository) ReplaceFollowing(ctx context.Context, userID int, follows []entities.UserFollow) error {
+	return nil
+}
+
+func (f *fakeFollowRepository) ListFollowers(ctx context.Context, login string, options interfaces.ListOptions) ([]entities.User, error) {
+	f.options = options
+	return f.followers[login], nil
+}
+
+func (f *fakeFollowRepository) ListFollowing(ctx context.Context, login string, options interfaces.ListOptions) ([]entities.User, error) {
+	f.options = options
+	return nil, nil
+}
+
+type noRowsRepository struct{ fakeRepository }
+
+func (r *noRowsRepository) GetByLogin(ctx context.Context, login string) (*entities.User, error) {
+	if user, ok := r.stored[login]; ok {
+		return user, nil
+	}
+	return nil, sql.ErrNoRows
+}
+
+func TestFollowService_ListFollowers(t *testing.T) {
+	t.Parallel()
+	userRepository := &noRowsRepository{fakeRepository{stored: map[string]*entities.User{
+		"octocat": {ID: 1, Login: "octocat"},
+	}}}
+	followRepository := &fakeFollowRepository{followers: map[string][]entities.User{
+		"octocat": {{ID: 2, Login: "hubot"}},
+	}}
+	svc := NewFollowService(followRepository, userRepository)
+
+	followers, err := svc.ListFollowers(context.Background(), "octocat", interfaces.ListOptions{})
+	require.NoError(t, err)
+	require.Len(t, followers, 1)
+	require.Equal(t, "hubot", followers[0].Login)
+	require.Equal(t, 10, followRepository.options.Limit)
+	require.Equal(t, 1, followRepository.options.Page)
+
+	_, err = svc.ListFollowing(context.Background(), "ghost", interfaces.ListOptions{})
+	require.True(t, derr.IsCode(err, derr.ErrorCodeNotFound))
+}
@@ -0,0 +1,52 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/unkabogaton/github-users/internal/domain/entities"
+	derr "github.com/unkabogaton/github-users/internal/domain/errors"
+	"github.com/unkabogaton/github-users/internal/domain/interfaces"
+)
+
+type OrganizationService struct {
+	organizationRepository interfaces.OrganizationRepository
+	userRepository         interfaces.UserRepository
+}
+
+func NewOrganizationService(
+	organizationRepository interfaces.OrganizationRepository,
+	userRepository interfaces.UserRepository,
+) interfaces.OrganizationService {
+	return &OrganizationService{
+		organizationRepository: organizationRepository,
+		userRepository:         userRepository,
+	}
+}
+
+func (s *OrganizationService) ListMembers(
+	ctx context.Context,
+	organizationLogin string,
+	options interfaces.ListOptions,
+) ([]entities.User, error) {
+	if _, err := s.organizationRepository.GetByLogin(ctx, organizationLogin); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, derr.New(derr.ErrorCodeNotFound, fmt.Sprintf("organization %s not found", organizationLogin))
+		}
+		return nil, derr.Wrap(derr.ErrorCodeInternal, "failed to load organization", err)
+	}
+	return s.organizationRepository.ListMembers(ctx, organizationLogin, withListDefaults(options))
+}
+
+func (s *OrganizationService) ListForUser(
+	ctx context.Context,
+	username string,
+	options interfaces.ListOptions,
+) ([]entities.Organization, error) {
+	if err := requireStoredUser(ctx, s.userRepository, username); err != nil {
+		return nil, err
+	}
+	return s.organizationRepository.ListForUser(ctx, username, withListDefaults(options))
+}
@@ -0,0 +1,74 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/unkabogaton/github-users/internal/domain/entities"
+	derr "github.com/unkabogaton/github-users/internal/domain/errors"
+	"github.com/unkabogaton/github-users/internal/domain/interfaces"
+)
+
+type fakeOrganizationRepository struct {
+	organizations map[string]*entities.Organization
+	members       map[string][]entities.User
+}
+
+func (f *fakeOrganizationRepository) Upsert(ctx context.Context, organization *entities.Organization) error {
+	f.organizations[organization.Login] = organization
+	return nil
+}
+
+func (f *fakeOrganizationRepository) BatchUpsert(ctx context.Context, organizations *[]entities.Organization) error {
+	return nil
+}
+
+func (f *fakeOrganizationRepository) BatchUpsertSummaries(ctx context.Context, organizations *[]entities.Organization) error {
+	return nil
+}
+
+func (f *fakeOrganizationRepository) GetByLogin(ctx context.Context, login string) (*entities.Organization, error) {
+	if organization, ok := f.organizations[login]; ok {
+		return organization, nil
+	}
+	return nil, sql.ErrNoRows
+}
+
+func (f *fakeOrganizationRepository) ReplaceMembers(ctx context.Context, organizationID int, memberships []entities.UserOrganization) error {
+	return nil
+}
+
+func (f *fakeOrganizationRepository) ReplaceUserOrganizations(ctx context.Context, userID int, memberships []entities.UserOrganization) error {
+	return nil
+}
+
+func (f *fakeOrganizationRepository) ListMembers(ctx context.Context, organizationLogin string, options interfaces.ListOptions) ([]entities.User, error) {
+	return f.members[organizationLogin], nil
+}
+
+func (f *fakeOrganizationRepository) ListForUser(ctx context.Context, login string, options interfaces.ListOptions) ([]entities.Organization, error) {
+	return nil, nil
+}
+
+func TestOrganizationService_ListMembers(t *testing.T) {
+	t.Parallel()
+	organizationRepository := &fakeOrganizationRepository{
+		organizations: map[string]*entities.Organization{"github": {ID: 9919, Login: "github"}},
+		members:       map[string][]entities.User{"github": {{ID: 1, Login: "octocat"}}},
+	}
+	userRepository := &noRowsRepository{fakeRepository{stored: map[string]*entities.User{}}}
+	svc := NewOrganizationService(organizationRepository, userRepository)
+
+	members, err := svc.ListMembers(context.Background(), "github", interfaces.ListOptions{})
+	require.NoError(t, err)
+	require.Equal(t, "octocat", members[0].Login)
+
+	_, err = svc.ListMembers(context.Background(), "gitlab", interfaces.ListOptions{})
+	require.True(t, derr.IsCode(err, derr.ErrorCodeNotFound))
+
+	_, err = svc.ListForUser(context.Background(), "ghost", interfaces.ListOptions{})
+	require.True(t, derr.IsCode(err, derr.ErrorCodeNotFound))
+}
@@ -0,0 +1,43 @@
+package services
+
+import (
+	"context"
+
+	"github.com/unkabogaton/github-users/internal/domain/entities"
+	"github.com/unkabogaton/github-users/internal/domain/interfaces"
+)
+
+type RepositoryService struct {
+	repoRepository interfaces.RepoRepository
+	userRepository interfaces.UserRepository
+}
+
+func NewRepositoryService(
+	repoRepository interfaces.RepoRepository,
+	userRepository interfaces.UserRepository,
+) interfaces.RepositoryService {
+	return &RepositoryService{
+		repoRepository: repoRepository,
+		userRepository: userRepository,
+	}
+}
+
+// ListForUser lists the stored repositories of username, most starred first
+// unless the options ask for another order.
+func (s *RepositoryService) ListForUser(
+	ctx context.Context,
+	username string,
+	options interfaces.RepositoryListOptions,
+) ([]entities.Repository, error) {
+	if err := requireStoredUser(ctx, s.userRepository, username); err != nil {
+		return nil, err
+	}
+	if options.OrderBy == "" {
+		options.OrderBy = "stars"
+		if options.OrderDirection == "" {
+			options.OrderDirection = "DESC"
+		}
+	}
+	options.ListOptions = withListDefaults(options.ListOptions)
+	return s.repoRepository.ListForOwner(ctx, username, options)
+}
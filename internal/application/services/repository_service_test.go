@@ -0,0 +1,44 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/unkabogaton/github-users/internal/domain/entities"
+	derr "github.com/unkabogaton/github-users/internal/domain/errors"
+	"github.com/unkabogaton/github-users/internal/domain/interfaces"
+)
+
+type fakeRepoRepository struct {
+	options interfaces.RepositoryListOptions
+}
+
+func (f *fakeRepoRepository) ReplaceForOwner(ctx context.Context, ownerID int, repositories []entities.Repository) error {
+	return nil
+}
+
+func (f *fakeRepoRepository) ListForOwner(ctx context.Context, ownerLogin string, options interfaces.RepositoryListOptions) ([]entities.Repository, error) {
+	f.options = options
+	return []entities.Repository{{ID: 1, OwnerLogin: ownerLogin}}, nil
+}
+
+func TestRepositoryService_ListForUser_DefaultsToMostStarred(t *testing.T) {
+	t.Parallel()
+	userRepository := &noRowsRepository{fakeRepository{stored: map[string]*entities.User{
+		"octocat": {ID: 1, Login: "octocat"},
+	}}}
+	repoRepository := &fakeRepoRepository{}
+	svc := NewRepositoryService(repoRepository, userRepository)
+
+	_, err := svc.ListForUser(context.Background(), "octocat", interfaces.RepositoryListOptions{Language: "Go"})
+	require.NoError(t, err)
+	require.Equal(t, "stars", repoRepository.options.OrderBy)
+	require.Equal(t, "DESC", repoRepository.options.OrderDirection)
+	require.Equal(t, "Go", repoRepository.options.Language)
+	require.Equal(t, 10, repoRepository.options.Limit)
+
+	_, err = svc.ListForUser(context.Background(), "ghost", interfaces.RepositoryListOptions{})
+	require.True(t, derr.IsCode(err, derr.ErrorCodeNotFound))
+}
@@ -2,15 +2,35 @@ package services
 
 import (
 	"context"
+	"fmt"
+	"log"
+	"strings"
+	"sync"
+	"time"
 
 	"github.com/unkabogaton/github-users/internal/domain/entities"
+	derr "github.com/unkabogaton/github-users/internal/domain/errors"
 	"github.com/unkabogaton/github-users/internal/domain/interfaces"
+	"golang.org/x/sync/singleflight"
 )
 
+// defaultUserMaxAge is how old a stored user may get before Get refetches it
+// from GitHub, unless the caller asks for something else.
+const defaultUserMaxAge = 24 * time.Hour
+
+// detachedLoadTimeout bounds a load that runs apart from any one request: a
+// refresh of a stale cache entry, or a lookup shared by concurrent callers.
+const detachedLoadTimeout = 30 * time.Second
+
 type UserService struct {
 	repository interfaces.UserRepository
 	cache      interfaces.Cache
 	client     interfaces.GitHubClient
+	// refreshing holds the logins with a background refresh in flight.
+	refreshing sync.Map
+	// loads collapses concurrent uncached lookups of one login into a single
+	// database read and GitHub call.
+	loads singleflight.Group
 }
 
 func NewUserService(
@@ -26,6 +46,35 @@ func NewUserService(
 }
 
 func (s *UserService) List(ctx context.Context, options interfaces.ListOptions) ([]entities.User, error) {
+	return s.repository.List(ctx, withListDefaults(options))
+}
+
+// ListPage reads one page together with the number of matching users. The
+// two queries are not a snapshot, so Total may be off by the users written in
+// between.
+func (s *UserService) ListPage(ctx context.Context, options interfaces.ListOptions) (*interfaces.UserPage, error) {
+	options = withListDefaults(options)
+	userPage, err := s.repository.ListPage(ctx, options)
+	if err != nil {
+		return nil, err
+	}
+	total, err := s.repository.Count(ctx, options.Filters)
+	if err != nil {
+		return nil, err
+	}
+
+	userPage.Total = total
+	userPage.Limit = options.Limit
+	if options.Cursor == "" {
+		userPage.Page = options.Page
+		userPage.HasMore = int64(options.Page)*int64(options.Limit) < total
+	} else {
+		userPage.HasMore = userPage.NextCursor != ""
+	}
+	return userPage, nil
+}
+
+func withListDefaults(options interfaces.ListOptions) interfaces.ListOptions {
 	if options.Limit <= 0 {
 		options.Limit = 10
 	}
@@ -38,39 +87,166 @@ func (s *UserService) List(ctx context.Context, options interfaces.ListOptions)
 	if options.OrderDirection == "" {
 		options.OrderDirection = "ASC"
 	}
-	return s.repository.List(ctx, options)
+	return options
 }
 
-func (s *UserService) Get(ctx context.Context, username string) (*entities.User, error) {
-	if s.cache != nil {
-		cachedUser, cacheHit, cacheError := s.cache.GetUser(ctx, username)
+// Get reads through the cache, then the database, then GitHub. Whatever is
+// fetched from GitHub is written back to both. A stored user older than the
+// freshness policy allows is refetched, but still served if GitHub cannot be
+// reached. A cache entry past its soft TTL is served as is while one
+// background refresh per login brings it up to date, and a login GitHub
+// recently reported missing is answered from its cached tombstone.
+func (s *UserService) Get(
+	ctx context.Context,
+	username string,
+	options interfaces.GetUserOptions,
+) (*entities.User, error) {
+	maxAge := options.MaxAge
+	if maxAge <= 0 {
+		maxAge = defaultUserMaxAge
+	}
+
+	if s.cache != nil && !options.Refresh {
+		cacheEntry, cacheHit, cacheError := s.cache.GetUserEntry(ctx, username)
+		if cacheError == nil && cacheHit && cacheEntry.NotFound {
+			return nil, derr.New(derr.ErrorCodeNotFound, fmt.Sprintf("user %s not found", username))
+		}
 		if cacheError == nil && cacheHit {
-			return cachedUser, nil
+			// Cached copies are bounded by the cache TTL; only an explicit
+			// per-call MaxAge is stricter than that.
+			switch {
+			case options.MaxAge > 0:
+				if isFresh(cacheEntry.User, maxAge) {
+					return cacheEntry.User, nil
+				}
+			case cacheEntry.Stale:
+				s.refreshInBackground(ctx, username)
+				return cacheEntry.User, nil
+			default:
+				return cacheEntry.User, nil
+			}
+		}
+	}
+
+	return s.loadShared(ctx, username, maxAge, options.Refresh)
+}
+
+// loadShared runs loadUncached once for all concurrent callers asking for the
+// same login under the same policy. The shared load is detached from ctx, so
+// one caller giving up does not fail the others; each caller still returns as
+// soon as its own ctx is done.
+func (s *UserService) loadShared(
+	ctx context.Context,
+	username string,
+	maxAge time.Duration,
+	refresh bool,
+) (*entities.User, error) {
+	loadKey := fmt.Sprintf("%s|%d|%t", username, maxAge, refresh)
+	loadResult := s.loads.DoChan(loadKey, func() (interface{}, error) {
+		loadContext, cancel := context.WithTimeout(context.WithoutCancel(ctx), detachedLoadTimeout)
+		defer cancel()
+		return s.loadUncached(loadContext, username, maxAge, refresh)
+	})
+
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case result := <-loadResult:
+		if result.Err != nil {
+			return nil, result.Err
+		}
+		return result.Val.(*entities.User), nil
+	}
+}
+
+// loadUncached reads username from the database, falling back to GitHub when
+// the stored profile is missing, older than maxAge or skipped by refresh.
+func (s *UserService) loadUncached(
+	ctx context.Context,
+	username string,
+	maxAge time.Duration,
+	refresh bool,
+) (*entities.User, error) {
+	var storedUser *entities.User
+	if !refresh {
+		if repositoryUser, repositoryError := s.repository.GetByLogin(ctx, username); repositoryError == nil {
+			if isFresh(repositoryUser, maxAge) {
+				if s.cache != nil && !s.cachedMeanwhile(ctx, username, maxAge) {
+					_ = s.cache.SetUser(ctx, repositoryUser)
+				}
+				return repositoryUser, nil
+			}
+			storedUser = repositoryUser
 		}
 	}
 
 	ghUser, err := s.client.FetchOne(ctx, username)
 	if err != nil {
+		if !derr.IsCode(err, derr.ErrorCodeNotFound) {
+			if storedUser != nil {
+				return storedUser, nil
+			}
+			return nil, err
+		}
+		if s.cache != nil {
+			_ = s.cache.SetNotFound(ctx, username)
+		}
 		return nil, err
 	}
 
-	user := &entities.User{
-		ID:        ghUser.ID,
-		Login:     ghUser.Login,
-		NodeID:    ghUser.NodeID,
-		AvatarURL: ghUser.AvatarURL,
-		HTMLURL:   ghUser.HTMLURL,
-		URL:       ghUser.URL,
-		Type:      ghUser.Type,
-		SiteAdmin: ghUser.SiteAdmin,
+	fetchedUser := ghUser.ToUser()
+	user := &fetchedUser
+	fetchedAt := time.Now()
+	user.UpdatedAt = fetchedAt
+	user.ProfileFetchedAt = &fetchedAt
+	if storedUser != nil {
+		user.CreatedAt = storedUser.CreatedAt
 	}
 
+	_ = s.repository.Upsert(ctx, user)
 	if s.cache != nil {
 		_ = s.cache.SetUser(ctx, user)
 	}
 	return user, nil
 }
 
+// cachedMeanwhile reports whether username is already cached fresh, as when a
+// shared load that finished after this caller missed the cache wrote it back.
+// Writing the stored row again would only repeat that write.
+func (s *UserService) cachedMeanwhile(ctx context.Context, username string, maxAge time.Duration) bool {
+	cacheEntry, cacheHit, cacheError := s.cache.GetUserEntry(ctx, username)
+	if cacheError != nil || !cacheHit || cacheEntry.NotFound || cacheEntry.Stale {
+		return false
+	}
+	return isFresh(cacheEntry.User, maxAge)
+}
+
+// refreshInBackground refetches username from GitHub into the database and
+// the cache unless a refresh for it is already running. The stored row is
+// skipped: it is usually as old as the stale cache entry it was cached from.
+// The refresh is detached from ctx so that it survives the request that
+// noticed the stale entry.
+func (s *UserService) refreshInBackground(ctx context.Context, username string) {
+	if _, alreadyRefreshing := s.refreshing.LoadOrStore(username, struct{}{}); alreadyRefreshing {
+		return
+	}
+
+	go func() {
+		defer s.refreshing.Delete(username)
+
+		if _, err := s.loadShared(context.WithoutCancel(ctx), username, defaultUserMaxAge, true); err != nil {
+			log.Printf("[ERROR] Background refresh failed for user %s: %v", username, err)
+		}
+	}()
+}
+
+// isFresh reports whether the profile of user was fetched from GitHub within
+// maxAge. Users stored only as summaries have no profile and are never fresh;
+// their updated_at moves with every summary write, so it says nothing here.
+func isFresh(user *entities.User, maxAge time.Duration) bool {
+	return user.ProfileFetchedAt != nil && time.Since(*user.ProfileFetchedAt) <= maxAge
+}
+
 func (s *UserService) Update(
 	ctx context.Context,
 	username string,
@@ -103,6 +279,7 @@ func (s *UserService) Update(
 	}
 	existingUser.SiteAdmin = update.SiteAdmin
 
+	existingUser.UpdatedAt = time.Now()
 	if upsertError := s.repository.Upsert(ctx, existingUser); upsertError != nil {
 		return nil, upsertError
 	}
@@ -122,3 +299,49 @@ func (s *UserService) Delete(ctx context.Context, username string) error {
 	}
 	return nil
 }
+
+func (s *UserService) Search(
+	ctx context.Context,
+	request interfaces.SearchUsersRequest,
+) (*interfaces.SearchUsersResult, error) {
+	if strings.TrimSpace(request.Query) == "" {
+		return nil, derr.New(derr.ErrorCodeValidation, "search query is required")
+	}
+	switch request.Sort {
+	case "", "followers", "repositories", "joined":
+	default:
+		return nil, derr.New(derr.ErrorCodeValidation, "sort must be one of followers, repositories or joined")
+	}
+	request.Order = strings.ToLower(request.Order)
+	switch request.Order {
+	case "", "asc", "desc":
+	default:
+		return nil, derr.New(derr.ErrorCodeValidation, "order must be asc or desc")
+	}
+	if request.Page <= 0 {
+		request.Page = 1
+	}
+
+	searchResult, err := s.client.SearchUsers(ctx, request.Query, request.Sort, request.Order, request.Page)
+	if err != nil {
+		return nil, err
+	}
+
+	matchedUsers := make([]entities.User, 0, len(searchResult.Items))
+	for _, gitHubUser := range searchResult.Items {
+		matchedUsers = append(matchedUsers, gitHubUser.ToUser())
+	}
+
+	// Search results are summaries; users already stored keep their profile.
+	if request.Persist && len(matchedUsers) > 0 {
+		if _, err := s.repository.BatchUpsertSummaries(ctx, &matchedUsers); err != nil {
+			return nil, err
+		}
+	}
+
+	return &interfaces.SearchUsersResult{
+		TotalCount:        searchResult.TotalCount,
+		IncompleteResults: searchResult.IncompleteResults,
+		Users:             matchedUsers,
+	}, nil
+}
@@ -3,31 +3,58 @@ package services
 import (
 	"context"
 	"errors"
+	"iter"
+	"sync"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 
 	"github.com/unkabogaton/github-users/internal/domain/entities"
+	derr "github.com/unkabogaton/github-users/internal/domain/errors"
 	"github.com/unkabogaton/github-users/internal/domain/interfaces"
 )
 
 type fakeRepository struct {
+	mutex  sync.Mutex
 	stored map[string]*entities.User
 }
 
 func (f *fakeRepository) Upsert(ctx context.Context, user *entities.User) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
 	f.stored[user.Login] = user
 	return nil
 }
 
-func (f *fakeRepository) BatchUpsert(ctx context.Context, users *[]entities.User) error {
+func (f *fakeRepository) BatchUpsert(ctx context.Context, users *[]entities.User) (interfaces.BatchUpsertResult, error) {
 	for _, user := range *users {
 		f.stored[user.Login] = &user
 	}
-	return nil
+	return interfaces.BatchUpsertResult{Inserted: int64(len(*users))}, nil
+}
+
+// BatchUpsertSummaries only inserts missing users; it stands in for the
+// repository keeping the profile columns of stored users.
+func (f *fakeRepository) BatchUpsertSummaries(ctx context.Context, users *[]entities.User) (interfaces.BatchUpsertResult, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	var result interfaces.BatchUpsertResult
+	for _, user := range *users {
+		if _, ok := f.stored[user.Login]; ok {
+			result.Updated++
+			continue
+		}
+		f.stored[user.Login] = &user
+		result.Inserted++
+	}
+	return result, nil
 }
 
 func (f *fakeRepository) GetByLogin(ctx context.Context, login string) (*entities.User, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
 	if u, ok := f.stored[login]; ok {
 		return u, nil
 	}
@@ -40,33 +67,151 @@ func (f *fakeRepository) List(ctx context.Context, options interfaces.ListOption
 	}
 	return out, nil
 }
+func (f *fakeRepository) ListPage(ctx context.Context, options interfaces.ListOptions) (*interfaces.UserPage, error) {
+	users, err := f.List(ctx, options)
+	return &interfaces.UserPage{Users: users}, err
+}
+func (f *fakeRepository) Count(ctx context.Context, filters []interfaces.Filter) (int64, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	return int64(len(f.stored)), nil
+}
 func (f *fakeRepository) DeleteByLogin(ctx context.Context, login string) error {
 	delete(f.stored, login)
 	return nil
 }
 
-type fakeCache struct{ items map[string]*entities.User }
+type fakeCache struct {
+	mutex    sync.Mutex
+	items    map[string]*entities.User
+	stale    map[string]bool
+	notFound map[string]bool
+	lookups  atomic.Int32
+	sets     atomic.Int32
+}
 
 func (f *fakeCache) GetUser(ctx context.Context, login string) (*entities.User, bool, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
 	u, ok := f.items[login]
 	return u, ok, nil
 }
+func (f *fakeCache) GetUserEntry(ctx context.Context, login string) (*interfaces.CacheEntry, bool, error) {
+	f.lookups.Add(1)
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	u, ok := f.items[login]
+	if !ok {
+		if f.notFound[login] {
+			return &interfaces.CacheEntry{NotFound: true}, true, nil
+		}
+		return nil, false, nil
+	}
+	return &interfaces.CacheEntry{User: u, Stale: f.stale[login]}, true, nil
+}
 func (f *fakeCache) SetUser(ctx context.Context, user *entities.User) error {
+	f.sets.Add(1)
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
 	f.items[user.Login] = user
+	delete(f.stale, user.Login)
+	delete(f.notFound, user.Login)
+	return nil
+}
+func (f *fakeCache) SetNotFound(ctx context.Context, login string) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	if f.notFound == nil {
+		f.notFound = map[string]bool{}
+	}
+	f.notFound[login] = true
 	return nil
 }
+func (f *fakeCache) ClearNotFound(ctx context.Context, logins ...string) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	for _, login := range logins {
+		delete(f.notFound, login)
+	}
+	return nil
+}
+func (f *fakeCache) cachedName(login string) string {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	if u, ok := f.items[login]; ok {
+		return u.Name
+	}
+	return ""
+}
 func (f *fakeCache) DeleteUser(ctx context.Context, login string) error {
 	delete(f.items, login)
 	return nil
 }
 
-type fakeGitHubClient struct{}
+type fakeGitHubClient struct {
+	fetchOneCalls atomic.Int32
+	fetchOneErr   error
+	// fetchOneGate, when set, holds FetchOne until it is closed.
+	fetchOneGate chan struct{}
+}
 
 func (f *fakeGitHubClient) FetchUsersSince(ctx context.Context, lastUserID int, resultsPerPage int) ([]entities.GitHubUser, error) {
 	return nil, nil
 }
+func (f *fakeGitHubClient) IterateUsersSince(ctx context.Context, lastUserID, resultsPerPage int) iter.Seq2[[]entities.GitHubUser, error] {
+	return func(yield func([]entities.GitHubUser, error) bool) {}
+}
+
 func (f *fakeGitHubClient) FetchOne(ctx context.Context, username string) (*entities.GitHubUser, error) {
-	return &entities.GitHubUser{ID: 1, Login: username}, nil
+	f.fetchOneCalls.Add(1)
+	if f.fetchOneGate != nil {
+		select {
+		case <-f.fetchOneGate:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+	if f.fetchOneErr != nil {
+		return nil, f.fetchOneErr
+	}
+	return &entities.GitHubUser{
+		ID:        1,
+		Login:     username,
+		Name:      "The Octocat",
+		Company:   "@github",
+		Followers: 20,
+		CreatedAt: time.Date(2011, 1, 25, 18, 44, 36, 0, time.UTC),
+	}, nil
+}
+func (f *fakeGitHubClient) FetchFollowers(ctx context.Context, username string) ([]entities.GitHubUser, error) {
+	return nil, nil
+}
+func (f *fakeGitHubClient) FetchFollowing(ctx context.Context, username string) ([]entities.GitHubUser, error) {
+	return nil, nil
+}
+func (f *fakeGitHubClient) FetchOrganization(ctx context.Context, organizationLogin string) (*entities.GitHubOrganization, error) {
+	return nil, nil
+}
+func (f *fakeGitHubClient) FetchUserOrganizations(ctx context.Context, username string) ([]entities.GitHubOrganization, error) {
+	return nil, nil
+}
+func (f *fakeGitHubClient) FetchOrganizationMembers(ctx context.Context, organizationLogin string) ([]entities.GitHubUser, error) {
+	return nil, nil
+}
+func (f *fakeGitHubClient) FetchUserRepos(ctx context.Context, username string) ([]entities.GitHubRepository, error) {
+	return nil, nil
+}
+func (f *fakeGitHubClient) SearchUsers(ctx context.Context, query, sort, order string, page int) (*entities.GitHubUserSearchResult, error) {
+	return &entities.GitHubUserSearchResult{
+		TotalCount: 2,
+		Items: []entities.GitHubUser{
+			{ID: 1, Login: "octocat"},
+			{ID: 2, Login: "hubot"},
+		},
+	}, nil
+}
+func (f *fakeGitHubClient) RateLimits() []interfaces.RateLimitStatus {
+	return nil
 }
 
 func TestUserService_Get_UsesCacheThenClient(t *testing.T) {
@@ -76,11 +221,250 @@ func TestUserService_Get_UsesCacheThenClient(t *testing.T) {
 	client := &fakeGitHubClient{}
 	svc := NewUserService(repo, cache, client)
 
-	u, err := svc.Get(context.Background(), "octo")
+	u, err := svc.Get(context.Background(), "octo", interfaces.GetUserOptions{})
 	require.NoError(t, err)
 	require.Equal(t, "octo", u.Login)
 }
 
+func TestUserService_Get_FetchesFullProfileOnCacheMiss(t *testing.T) {
+	t.Parallel()
+	repo := &fakeRepository{stored: map[string]*entities.User{}}
+	cache := &fakeCache{items: map[string]*entities.User{}}
+	client := &fakeGitHubClient{}
+	svc := NewUserService(repo, cache, client)
+
+	u, err := svc.Get(context.Background(), "octocat", interfaces.GetUserOptions{})
+	require.NoError(t, err)
+	require.Equal(t, "The Octocat", u.Name)
+	require.Equal(t, "@github", u.Company)
+	require.Equal(t, 20, u.Followers)
+	require.NotNil(t, u.GitHubCreatedAt)
+	require.Equal(t, 2011, u.GitHubCreatedAt.Year())
+	require.Contains(t, cache.items, "octocat")
+}
+
+// fetchedAgo returns a ProfileFetchedAt that lies age in the past.
+func fetchedAgo(age time.Duration) *time.Time {
+	fetchedAt := time.Now().Add(-age)
+	return &fetchedAt
+}
+
+func TestUserService_Get_ServesFreshDatabaseRow(t *testing.T) {
+	t.Parallel()
+	repo := &fakeRepository{stored: map[string]*entities.User{
+		"octocat": {ID: 1, Login: "octocat", Name: "Stored", UpdatedAt: time.Now().Add(-time.Hour), ProfileFetchedAt: fetchedAgo(time.Hour)},
+	}}
+	cache := &fakeCache{items: map[string]*entities.User{}}
+	client := &fakeGitHubClient{}
+	svc := NewUserService(repo, cache, client)
+
+	u, err := svc.Get(context.Background(), "octocat", interfaces.GetUserOptions{})
+	require.NoError(t, err)
+	require.Equal(t, "Stored", u.Name)
+	require.Zero(t, client.fetchOneCalls.Load())
+	require.Contains(t, cache.items, "octocat")
+
+	u, err = svc.Get(context.Background(), "octocat", interfaces.GetUserOptions{MaxAge: time.Minute})
+	require.NoError(t, err)
+	require.Equal(t, "The Octocat", u.Name)
+	require.EqualValues(t, 1, client.fetchOneCalls.Load())
+	require.Equal(t, "The Octocat", repo.stored["octocat"].Name)
+}
+
+func TestUserService_Get_WritesFetchedUserBack(t *testing.T) {
+	t.Parallel()
+	repo := &fakeRepository{stored: map[string]*entities.User{}}
+	client := &fakeGitHubClient{}
+	svc := NewUserService(repo, nil, client)
+
+	_, err := svc.Get(context.Background(), "octocat", interfaces.GetUserOptions{})
+	require.NoError(t, err)
+	require.Contains(t, repo.stored, "octocat")
+	require.False(t, repo.stored["octocat"].UpdatedAt.IsZero())
+	require.NotNil(t, repo.stored["octocat"].ProfileFetchedAt)
+
+	_, err = svc.Get(context.Background(), "octocat", interfaces.GetUserOptions{})
+	require.NoError(t, err)
+	require.EqualValues(t, 1, client.fetchOneCalls.Load())
+
+	_, err = svc.Get(context.Background(), "octocat", interfaces.GetUserOptions{Refresh: true})
+	require.NoError(t, err)
+	require.EqualValues(t, 2, client.fetchOneCalls.Load())
+}
+
+func TestUserService_Get_RefetchesSummaryOnlyRow(t *testing.T) {
+	t.Parallel()
+	// A summary write has just bumped updated_at, but the profile was never
+	// fetched.
+	repo := &fakeRepository{stored: map[string]*entities.User{
+		"octocat": {ID: 1, Login: "octocat", UpdatedAt: time.Now()},
+	}}
+	client := &fakeGitHubClient{}
+	svc := NewUserService(repo, nil, client)
+
+	u, err := svc.Get(context.Background(), "octocat", interfaces.GetUserOptions{})
+	require.NoError(t, err)
+	require.Equal(t, "The Octocat", u.Name)
+	require.EqualValues(t, 1, client.fetchOneCalls.Load())
+	require.NotNil(t, repo.stored["octocat"].ProfileFetchedAt)
+}
+
+func TestUserService_Get_FallsBackToStaleRowWhenGitHubFails(t *testing.T) {
+	t.Parallel()
+	repo := &fakeRepository{stored: map[string]*entities.User{
+		"octocat": {ID: 1, Login: "octocat", Name: "Stored", UpdatedAt: time.Now().Add(-48 * time.Hour), ProfileFetchedAt: fetchedAgo(48 * time.Hour)},
+	}}
+	client := &fakeGitHubClient{fetchOneErr: derr.New(derr.ErrorCodeRateLimited, "rate limited")}
+	svc := NewUserService(repo, nil, client)
+
+	u, err := svc.Get(context.Background(), "octocat", interfaces.GetUserOptions{})
+	require.NoError(t, err)
+	require.Equal(t, "Stored", u.Name)
+
+	client.fetchOneErr = derr.New(derr.ErrorCodeNotFound, "gone")
+	_, err = svc.Get(context.Background(), "octocat", interfaces.GetUserOptions{})
+	require.True(t, derr.IsCode(err, derr.ErrorCodeNotFound))
+}
+
+func TestUserService_Get_ServesStaleCacheAndRefreshesOnce(t *testing.T) {
+	t.Parallel()
+	// The stored row is within defaultUserMaxAge; the refresh must still go
+	// to GitHub rather than recache it.
+	repo := &fakeRepository{stored: map[string]*entities.User{
+		"octocat": {ID: 1, Login: "octocat", Name: "Stored", ProfileFetchedAt: fetchedAgo(time.Hour)},
+	}}
+	cache := &fakeCache{
+		items: map[string]*entities.User{"octocat": {ID: 1, Login: "octocat", Name: "Cached"}},
+		stale: map[string]bool{"octocat": true},
+	}
+	client := &fakeGitHubClient{}
+	svc := NewUserService(repo, cache, client)
+
+	for range 5 {
+		u, err := svc.Get(context.Background(), "octocat", interfaces.GetUserOptions{})
+		require.NoError(t, err)
+		require.Contains(t, []string{"Cached", "The Octocat"}, u.Name)
+	}
+
+	require.Eventually(t, func() bool {
+		return cache.cachedName("octocat") == "The Octocat"
+	}, time.Second, 10*time.Millisecond)
+	require.EqualValues(t, 1, client.fetchOneCalls.Load())
+	require.Equal(t, "The Octocat", repo.stored["octocat"].Name)
+
+	u, err := svc.Get(context.Background(), "octocat", interfaces.GetUserOptions{})
+	require.NoError(t, err)
+	require.Equal(t, "The Octocat", u.Name)
+	require.EqualValues(t, 1, client.fetchOneCalls.Load())
+}
+
+func TestUserService_Get_CoalescesConcurrentLookups(t *testing.T) {
+	t.Parallel()
+	repo := &fakeRepository{stored: map[string]*entities.User{}}
+	cache := &fakeCache{items: map[string]*entities.User{}}
+	client := &fakeGitHubClient{fetchOneGate: make(chan struct{})}
+	svc := NewUserService(repo, cache, client)
+
+	const callers = 20
+	var waitGroup sync.WaitGroup
+	names := make([]string, callers)
+	for index := range callers {
+		waitGroup.Add(1)
+		go func() {
+			defer waitGroup.Done()
+			u, err := svc.Get(context.Background(), "octocat", interfaces.GetUserOptions{})
+			if err == nil {
+				names[index] = u.Name
+			}
+		}()
+	}
+
+	require.Eventually(t, func() bool {
+		return cache.lookups.Load() == callers
+	}, time.Second, time.Millisecond)
+	close(client.fetchOneGate)
+	waitGroup.Wait()
+
+	// A caller that missed the cache but had not joined the shared load yet
+	// when the gate opened loads again. It finds the written-back row and the
+	// cached copy, so neither GitHub nor the cache is asked twice.
+	require.EqualValues(t, 1, client.fetchOneCalls.Load())
+	require.EqualValues(t, 1, cache.sets.Load())
+	for _, name := range names {
+		require.Equal(t, "The Octocat", name)
+	}
+}
+
+func TestUserService_Get_CallerCancellationDoesNotFailSharedLookup(t *testing.T) {
+	t.Parallel()
+	repo := &fakeRepository{stored: map[string]*entities.User{}}
+	cache := &fakeCache{items: map[string]*entities.User{}}
+	client := &fakeGitHubClient{fetchOneGate: make(chan struct{})}
+	svc := NewUserService(repo, cache, client)
+
+	cancelledContext, cancel := context.WithCancel(context.Background())
+	cancelledResult := make(chan error, 1)
+	go func() {
+		_, err := svc.Get(cancelledContext, "octocat", interfaces.GetUserOptions{})
+		cancelledResult <- err
+	}()
+	require.Eventually(t, func() bool {
+		return client.fetchOneCalls.Load() == 1
+	}, time.Second, time.Millisecond)
+
+	patientResult := make(chan *entities.User, 1)
+	go func() {
+		u, _ := svc.Get(context.Background(), "octocat", interfaces.GetUserOptions{})
+		patientResult <- u
+	}()
+	require.Eventually(t, func() bool {
+		return cache.lookups.Load() == 2
+	}, time.Second, time.Millisecond)
+
+	cancel()
+	require.ErrorIs(t, <-cancelledResult, context.Canceled)
+
+	close(client.fetchOneGate)
+	u := <-patientResult
+	require.NotNil(t, u)
+	require.Equal(t, "The Octocat", u.Name)
+	require.EqualValues(t, 1, client.fetchOneCalls.Load())
+}
+
+func TestUserService_Get_CachesGitHubNotFound(t *testing.T) {
+	t.Parallel()
+	repo := &fakeRepository{stored: map[string]*entities.User{}}
+	cache := &fakeCache{items: map[string]*entities.User{}}
+	client := &fakeGitHubClient{fetchOneErr: derr.New(derr.ErrorCodeNotFound, "user ghost not found")}
+	svc := NewUserService(repo, cache, client)
+
+	_, err := svc.Get(context.Background(), "ghost", interfaces.GetUserOptions{})
+	require.True(t, derr.IsCode(err, derr.ErrorCodeNotFound))
+	require.True(t, cache.notFound["ghost"])
+
+	_, err = svc.Get(context.Background(), "ghost", interfaces.GetUserOptions{})
+	require.True(t, derr.IsCode(err, derr.ErrorCodeNotFound))
+	require.EqualValues(t, 1, client.fetchOneCalls.Load())
+
+	client.fetchOneErr = nil
+	u, err := svc.Get(context.Background(), "ghost", interfaces.GetUserOptions{Refresh: true})
+	require.NoError(t, err)
+	require.Equal(t, "ghost", u.Login)
+	require.False(t, cache.notFound["ghost"])
+}
+
+func TestUserService_Get_DoesNotCacheOtherGitHubErrors(t *testing.T) {
+	t.Parallel()
+	repo := &fakeRepository{stored: map[string]*entities.User{}}
+	cache := &fakeCache{items: map[string]*entities.User{}}
+	client := &fakeGitHubClient{fetchOneErr: derr.New(derr.ErrorCodeRateLimited, "rate limited")}
+	svc := NewUserService(repo, cache, client)
+
+	_, err := svc.Get(context.Background(), "octocat", interfaces.GetUserOptions{})
+	require.True(t, derr.IsCode(err, derr.ErrorCodeRateLimited))
+	require.Empty(t, cache.notFound)
+}
+
 func TestUserService_Update_PersistsAndCaches(t *testing.T) {
 	t.Parallel()
 	repo := &fakeRepository{stored: map[string]*entities.User{"octo": {ID: 1, Login: "octo"}}}
@@ -104,3 +488,67 @@ func TestUserService_List_Defaults(t *testing.T) {
 	require.NoError(t, err)
 	require.Len(t, users, 1)
 }
+
+func TestUserService_Search_PersistsMatches(t *testing.T) {
+	t.Parallel()
+	repo := &fakeRepository{stored: map[string]*entities.User{
+		"octocat": {ID: 1, Login: "octocat", Name: "The Octocat"},
+	}}
+	cache := &fakeCache{items: map[string]*entities.User{}}
+	client := &fakeGitHubClient{}
+	svc := NewUserService(repo, cache, client)
+
+	result, err := svc.Search(context.Background(), interfaces.SearchUsersRequest{Query: "location:berlin"})
+	require.NoError(t, err)
+	require.Equal(t, 2, result.TotalCount)
+	require.Len(t, result.Users, 2)
+	require.Len(t, repo.stored, 1)
+
+	_, err = svc.Search(context.Background(), interfaces.SearchUsersRequest{Query: "location:berlin", Persist: true})
+	require.NoError(t, err)
+	require.Equal(t, "The Octocat", repo.stored["octocat"].Name)
+	require.Contains(t, repo.stored, "hubot")
+}
+
+func TestUserService_Search_Validation(t *testing.T) {
+	t.Parallel()
+	repo := &fakeRepository{stored: map[string]*entities.User{}}
+	svc := NewUserService(repo, nil, &fakeGitHubClient{})
+
+	_, err := svc.Search(context.Background(), interfaces.SearchUsersRequest{Query: "  "})
+	require.True(t, derr.IsCode(err, derr.ErrorCodeValidation))
+
+	_, err = svc.Search(context.Background(), interfaces.SearchUsersRequest{Query: "tom", Sort: "stars"})
+	require.True(t, derr.IsCode(err, derr.ErrorCodeValidation))
+
+	_, err = svc.Search(context.Background(), interfaces.SearchUsersRequest{Query: "tom", Order: "sideways"})
+	require.True(t, derr.IsCode(err, derr.ErrorCodeValidation))
+}
+
+func TestUserService_ListPage_AddsPaginationMetadata(t *testing.T) {
+	t.Parallel()
+	repo := &fakeRepository{stored: map[string]*entities.User{
+		"alpha": {ID: 1, Login: "alpha"},
+		"bravo": {ID: 2, Login: "bravo"},
+		"delta": {ID: 3, Login: "delta"},
+	}}
+	svc := NewUserService(repo, nil, &fakeGitHubClient{})
+
+	firstPage, err := svc.ListPage(context.Background(), interfaces.ListOptions{Limit: 2})
+	require.NoError(t, err)
+	require.EqualValues(t, 3, firstPage.Total)
+	require.Equal(t, 1, firstPage.Page)
+	require.Equal(t, 2, firstPage.Limit)
+	require.True(t, firstPage.HasMore)
+
+	lastPage, err := svc.ListPage(context.Background(), interfaces.ListOptions{Limit: 2, Page: 2})
+	require.NoError(t, err)
+	require.False(t, lastPage.HasMore)
+
+	cursorPage, err := svc.ListPage(context.Background(), interfaces.ListOptions{Cursor: "position"})
+	require.NoError(t, err)
+	require.EqualValues(t, 3, cursorPage.Total)
+	require.Zero(t, cursorPage.Page)
+	require.Equal(t, 10, cursorPage.Limit)
+	require.False(t, cursorPage.HasMore)
+}
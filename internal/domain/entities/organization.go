@@ -0,0 +1,54 @@
+package entities
+
+import "time"
+
+type Organization struct {
+	ID          int       `db:"id"`
+	Login       string    `db:"login"`
+	NodeID      string    `db:"node_id"`
+	URL         string    `db:"url"`
+	HTMLURL     string    `db:"html_url"`
+	AvatarURL   string    `db:"avatar_url"`
+	Name        string    `db:"name"`
+	Description string    `db:"description"`
+	UpdatedAt   time.Time `db:"updated_at"`
+	CreatedAt   time.Time `db:"created_at"`
+}
+
+// UserOrganization records that UserID is a member of OrganizationID.
+type UserOrganization struct {
+	UserID         int       `db:"user_id"`
+	OrganizationID int       `db:"organization_id"`
+	UpdatedAt      time.Time `db:"updated_at"`
+	CreatedAt      time.Time `db:"created_at"`
+}
+
+type GitHubOrganization struct {
+	ID               int    `json:"id"`
+	Login            string `json:"login"`
+	NodeID           string `json:"node_id"`
+	URL              string `json:"url"`
+	HTMLURL          string `json:"html_url"`
+	ReposURL         string `json:"repos_url"`
+	MembersURL       string `json:"members_url"`
+	PublicMembersURL string `json:"public_members_url"`
+	AvatarURL        string `json:"avatar_url"`
+	Name             string `json:"name"`
+	Description      string `json:"description"`
+}
+
+// ToOrganization maps a GitHub API organization onto the stored one. Name and
+// HTMLURL stay empty for the summaries returned by /users/{username}/orgs,
+// which are therefore stored with BatchUpsertSummaries.
+func (gitHubOrganization GitHubOrganization) ToOrganization() Organization {
+	return Organization{
+		ID:          gitHubOrganization.ID,
+		Login:       gitHubOrganization.Login,
+		NodeID:      gitHubOrganization.NodeID,
+		URL:         gitHubOrganization.URL,
+		HTMLURL:     gitHubOrganization.HTMLURL,
+		AvatarURL:   gitHubOrganization.AvatarURL,
+		Name:        gitHubOrganization.Name,
+		Description: gitHubOrganization.Description,
+	}
+}
@@ -0,0 +1,78 @@
+package entities
+
+import "time"
+
+// Repository is a public GitHub repository owned by a stored user.
+type Repository struct {
+	ID              int        `db:"id"`
+	OwnerID         int        `db:"owner_id"`
+	OwnerLogin      string     `db:"owner_login"`
+	Name            string     `db:"name"`
+	FullName        string     `db:"full_name"`
+	HTMLURL         string     `db:"html_url"`
+	Description     string     `db:"description"`
+	Language        string     `db:"language"`
+	Fork            bool       `db:"fork"`
+	Archived        bool       `db:"archived"`
+	StargazersCount int        `db:"stargazers_count"`
+	ForksCount      int        `db:"forks_count"`
+	WatchersCount   int        `db:"watchers_count"`
+	OpenIssuesCount int        `db:"open_issues_count"`
+	PushedAt        *time.Time `db:"pushed_at"`
+	GitHubCreatedAt *time.Time `db:"github_created_at"`
+	GitHubUpdatedAt *time.Time `db:"github_updated_at"`
+	UpdatedAt       time.Time  `db:"updated_at"`
+	CreatedAt       time.Time  `db:"created_at"`
+}
+
+type GitHubRepository struct {
+	ID              int        `json:"id"`
+	NodeID          string     `json:"node_id"`
+	Name            string     `json:"name"`
+	FullName        string     `json:"full_name"`
+	Owner           GitHubUser `json:"owner"`
+	HTMLURL         string     `json:"html_url"`
+	Description     string     `json:"description"`
+	Language        string     `json:"language"`
+	Fork            bool       `json:"fork"`
+	Archived        bool       `json:"archived"`
+	StargazersCount int        `json:"stargazers_count"`
+	ForksCount      int        `json:"forks_count"`
+	WatchersCount   int        `json:"watchers_count"`
+	OpenIssuesCount int        `json:"open_issues_count"`
+	PushedAt        time.Time  `json:"pushed_at"`
+	CreatedAt       time.Time  `json:"created_at"`
+	UpdatedAt       time.Time  `json:"updated_at"`
+}
+
+func (gitHubRepository GitHubRepository) ToRepository() Repository {
+	repository := Repository{
+		ID:              gitHubRepository.ID,
+		OwnerID:         gitHubRepository.Owner.ID,
+		OwnerLogin:      gitHubRepository.Owner.Login,
+		Name:            gitHubRepository.Name,
+		FullName:        gitHubRepository.FullName,
+		HTMLURL:         gitHubRepository.HTMLURL,
+		Description:     gitHubRepository.Description,
+		Language:        gitHubRepository.Language,
+		Fork:            gitHubRepository.Fork,
+		Archived:        gitHubRepository.Archived,
+		StargazersCount: gitHubRepository.StargazersCount,
+		ForksCount:      gitHubRepository.ForksCount,
+		WatchersCount:   gitHubRepository.WatchersCount,
+		OpenIssuesCount: gitHubRepository.OpenIssuesCount,
+	}
+	if !gitHubRepository.PushedAt.IsZero() {
+		pushedAt := gitHubRepository.PushedAt
+		repository.PushedAt = &pushedAt
+	}
+	if !gitHubRepository.CreatedAt.IsZero() {
+		gitHubCreatedAt := gitHubRepository.CreatedAt
+		repository.GitHubCreatedAt = &gitHubCreatedAt
+	}
+	if !gitHubRepository.UpdatedAt.IsZero() {
+		gitHubUpdatedAt := gitHubRepository.UpdatedAt
+		repository.GitHubUpdatedAt = &gitHubUpdatedAt
+	}
+	return repository
+}
@@ -3,36 +3,107 @@ package entities
 import "time"
 
 type User struct {
-	ID           int       `db:"id"`
-	Login        string    `db:"login"`
-	NodeID       string    `db:"node_id"`
-	AvatarURL    string    `db:"avatar_url"`
-	URL          string    `db:"url"`
-	HTMLURL      string    `db:"html_url"`
-	Type         string    `db:"type"`
-	UserViewType string    `db:"user_view_type"`
-	SiteAdmin    bool      `db:"site_admin"`
-	UpdatedAt    time.Time `db:"updated_at"`
-	CreatedAt    time.Time `db:"created_at"`
+	ID              int        `db:"id"`
+	Login           string     `db:"login"`
+	NodeID          string     `db:"node_id"`
+	AvatarURL       string     `db:"avatar_url"`
+	URL             string     `db:"url"`
+	HTMLURL         string     `db:"html_url"`
+	Type            string     `db:"type"`
+	UserViewType    string     `db:"user_view_type"`
+	SiteAdmin       bool       `db:"site_admin"`
+	Name            string     `db:"name"`
+	Company         string     `db:"company"`
+	Blog            string     `db:"blog"`
+	Location        string     `db:"location"`
+	Email           string     `db:"email"`
+	Bio             string     `db:"bio"`
+	TwitterUsername string     `db:"twitter_username"`
+	PublicRepos     int        `db:"public_repos"`
+	PublicGists     int        `db:"public_gists"`
+	Followers       int        `db:"followers"`
+	Following       int        `db:"following"`
+	GitHubCreatedAt *time.Time `db:"github_created_at"`
+	GitHubUpdatedAt *time.Time `db:"github_updated_at"`
+	// ProfileFetchedAt is when the full profile was last read from GitHub,
+	// nil for users only ever stored as summaries.
+	ProfileFetchedAt *time.Time `db:"profile_fetched_at"`
+	UpdatedAt        time.Time  `db:"updated_at"`
+	CreatedAt        time.Time  `db:"created_at"`
 }
 
 type GitHubUser struct {
-	ID                int    `json:"id"`
-	Login             string `json:"login"`
-	NodeID            string `json:"node_id"`
-	AvatarURL         string `json:"avatar_url"`
-	URL               string `json:"url"`
-	HTMLURL           string `json:"html_url"`
-	FollowersURL      string `json:"followers_url"`
-	FollowingURL      string `json:"following_url"`
-	GistsURL          string `json:"gists_url"`
-	StarredURL        string `json:"starred_url"`
-	SubscriptionsURL  string `json:"subscriptions_url"`
-	OrganizationsURL  string `json:"organizations_url"`
-	ReposURL          string `json:"repos_url"`
-	EventsURL         string `json:"events_url"`
-	ReceivedEventsURL string `json:"received_events_url"`
-	Type              string `json:"type"`
-	UserViewType      string `json:"user_view_type"`
-	SiteAdmin         bool   `json:"site_admin"`
+	ID                int       `json:"id"`
+	Login             string    `json:"login"`
+	NodeID            string    `json:"node_id"`
+	AvatarURL         string    `json:"avatar_url"`
+	URL               string    `json:"url"`
+	HTMLURL           string    `json:"html_url"`
+	FollowersURL      string    `json:"followers_url"`
+	FollowingURL      string    `json:"following_url"`
+	GistsURL          string    `json:"gists_url"`
+	StarredURL        string    `json:"starred_url"`
+	SubscriptionsURL  string    `json:"subscriptions_url"`
+	OrganizationsURL  string    `json:"organizations_url"`
+	ReposURL          string    `json:"repos_url"`
+	EventsURL         string    `json:"events_url"`
+	ReceivedEventsURL string    `json:"received_events_url"`
+	Type              string    `json:"type"`
+	UserViewType      string    `json:"user_view_type"`
+	SiteAdmin         bool      `json:"site_admin"`
+	Name              string    `json:"name"`
+	Company           string    `json:"company"`
+	Blog              string    `json:"blog"`
+	Location          string    `json:"location"`
+	Email             string    `json:"email"`
+	Bio               string    `json:"bio"`
+	TwitterUsername   string    `json:"twitter_username"`
+	PublicRepos       int       `json:"public_repos"`
+	PublicGists       int       `json:"public_gists"`
+	Followers         int       `json:"followers"`
+	Following         int       `json:"following"`
+	CreatedAt         time.Time `json:"created_at"`
+	UpdatedAt         time.Time `json:"updated_at"`
+}
+
+// ToUser maps a GitHub API user onto the stored user. Profile fields stay
+// empty for the summary objects returned by list endpoints such as /users.
+func (gitHubUser GitHubUser) ToUser() User {
+	user := User{
+		ID:              gitHubUser.ID,
+		Login:           gitHubUser.Login,
+		NodeID:          gitHubUser.NodeID,
+		AvatarURL:       gitHubUser.AvatarURL,
+		URL:             gitHubUser.URL,
+		HTMLURL:         gitHubUser.HTMLURL,
+		Type:            gitHubUser.Type,
+		UserViewType:    gitHubUser.UserViewType,
+		SiteAdmin:       gitHubUser.SiteAdmin,
+		Name:            gitHubUser.Name,
+		Company:         gitHubUser.Company,
+		Blog:            gitHubUser.Blog,
+		Location:        gitHubUser.Location,
+		Email:           gitHubUser.Email,
+		Bio:             gitHubUser.Bio,
+		TwitterUsername: gitHubUser.TwitterUsername,
+		PublicRepos:     gitHubUser.PublicRepos,
+		PublicGists:     gitHubUser.PublicGists,
+		Followers:       gitHubUser.Followers,
+		Following:       gitHubUser.Following,
+	}
+	if !gitHubUser.CreatedAt.IsZero() {
+		gitHubCreatedAt := gitHubUser.CreatedAt
+		user.GitHubCreatedAt = &gitHubCreatedAt
+	}
+	if !gitHubUser.UpdatedAt.IsZero() {
+		gitHubUpdatedAt := gitHubUser.UpdatedAt
+		user.GitHubUpdatedAt = &gitHubUpdatedAt
+	}
+	return user
+}
+
+type GitHubUserSearchResult struct {
+	TotalCount        int          `json:"total_count"`
+	IncompleteResults bool         `json:"incomplete_results"`
+	Items             []GitHubUser `json:"items"`
 }
@@ -0,0 +1,12 @@
+package entities
+
+import "time"
+
+// UserFollow is a directed edge of the follower graph: FollowerID follows
+// FollowingID.
+type UserFollow struct {
+	FollowerID  int       `db:"follower_id"`
+	FollowingID int       `db:"following_id"`
+	UpdatedAt   time.Time `db:"updated_at"`
+	CreatedAt   time.Time `db:"created_at"`
+}
@@ -6,8 +6,23 @@ import (
 	"github.com/unkabogaton/github-users/internal/domain/entities"
 )
 
+// CacheEntry is a cached user together with its freshness. A stale entry is
+// past its soft TTL but not yet evicted, and may be served while it is being
+// refreshed. A NotFound entry is a tombstone for a login GitHub does not know
+// and carries no user.
+type CacheEntry struct {
+	User     *entities.User
+	Stale    bool
+	NotFound bool
+}
+
 type Cache interface {
 	GetUser(ctx context.Context, login string) (*entities.User, bool, error)
+	GetUserEntry(ctx context.Context, login string) (*CacheEntry, bool, error)
 	SetUser(ctx context.Context, user *entities.User) error
 	DeleteUser(ctx context.Context, login string) error
+	// SetNotFound records that login does not exist on GitHub. Tombstones
+	// expire sooner than users and are cleared by SetUser and ClearNotFound.
+	SetNotFound(ctx context.Context, login string) error
+	ClearNotFound(ctx context.Context, logins ...string) error
 }
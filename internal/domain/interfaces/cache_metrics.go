@@ -0,0 +1,51 @@
+package interfaces
+
+import (
+	"context"
+	"time"
+)
+
+// Operations and outcomes reported to CacheMetrics.
+const (
+	CacheOperationGet           = "get"
+	CacheOperationSet           = "set"
+	CacheOperationDelete        = "delete"
+	CacheOperationSetNotFound   = "set_not_found"
+	CacheOperationClearNotFound = "clear_not_found"
+
+	CacheOutcomeHit         = "hit"
+	CacheOutcomeStaleHit    = "stale_hit"
+	CacheOutcomeNotFoundHit = "not_found_hit"
+	CacheOutcomeMiss        = "miss"
+	CacheOutcomeOK          = "ok"
+	CacheOutcomeError       = "error"
+)
+
+// CacheMetrics records the outcome and latency of every call made to a named
+// cache.
+type CacheMetrics interface {
+	ObserveCacheOperation(cacheName, operation, outcome string, duration time.Duration)
+}
+
+// CacheStats sums up the calls one cache has seen since the process started.
+type CacheStats struct {
+	Name         string  `json:"name"`
+	Hits         int64   `json:"hits"`
+	StaleHits    int64   `json:"stale_hits"`
+	NotFoundHits int64   `json:"not_found_hits"`
+	Misses       int64   `json:"misses"`
+	Errors       int64   `json:"errors"`
+	Sets         int64   `json:"sets"`
+	Deletes      int64   `json:"deletes"`
+	HitRatio     float64 `json:"hit_ratio"`
+}
+
+type CacheStatsProvider interface {
+	CacheStats() []CacheStats
+}
+
+// CacheKeyCounter is implemented by caches that can tell how many keys they
+// hold.
+type CacheKeyCounter interface {
+	CountKeys(ctx context.Context) (int64, error)
+}
@@ -0,0 +1,14 @@
+package interfaces
+
+import (
+	"context"
+
+	"github.com/unkabogaton/github-users/internal/domain/entities"
+)
+
+type FollowRepository interface {
+	ReplaceFollowers(ctx context.Context, userID int, follows []entities.UserFollow) error
+	ReplaceFollowing(ctx context.Context, userID int, follows []entities.UserFollow) error
+	ListFollowers(ctx context.Context, login string, options ListOptions) ([]entities.User, error)
+	ListFollowing(ctx context.Context, login string, options ListOptions) ([]entities.User, error)
+}
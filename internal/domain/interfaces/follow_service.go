@@ -0,0 +1,12 @@
+package interfaces
+
+import (
+	"context"
+
+	"github.com/unkabogaton/github-users/internal/domain/entities"
+)
+
+type FollowService interface {
+	ListFollowers(ctx context.Context, username string, options ListOptions) ([]entities.User, error)
+	ListFollowing(ctx context.Context, username string, options ListOptions) ([]entities.User, error)
+}
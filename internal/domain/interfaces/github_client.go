@@ -2,11 +2,21 @@ package interfaces
 
 import (
 	"context"
+	"iter"
 
 	"github.com/unkabogaton/github-users/internal/domain/entities"
 )
 
 type GitHubClient interface {
 	FetchUsersSince(ctx context.Context, lastUserID, resultsPerPage int) ([]entities.GitHubUser, error)
+	IterateUsersSince(ctx context.Context, lastUserID, resultsPerPage int) iter.Seq2[[]entities.GitHubUser, error]
 	FetchOne(ctx context.Context, username string) (*entities.GitHubUser, error)
+	FetchFollowers(ctx context.Context, username string) ([]entities.GitHubUser, error)
+	FetchFollowing(ctx context.Context, username string) ([]entities.GitHubUser, error)
+	FetchOrganization(ctx context.Context, organizationLogin string) (*entities.GitHubOrganization, error)
+	FetchUserOrganizations(ctx context.Context, username string) ([]entities.GitHubOrganization, error)
+	FetchOrganizationMembers(ctx context.Context, organizationLogin string) ([]entities.GitHubUser, error)
+	FetchUserRepos(ctx context.Context, username string) ([]entities.GitHubRepository, error)
+	SearchUsers(ctx context.Context, query, sort, order string, page int) (*entities.GitHubUserSearchResult, error)
+	RateLimits() []RateLimitStatus
 }
@@ -0,0 +1,30 @@
+package interfaces
+
+import (
+	"context"
+	"time"
+)
+
+// GitHubCredentials yields the Authorization header value for the next API
+// request. An empty value means the request is sent unauthenticated.
+type GitHubCredentials interface {
+	Authorization(ctx context.Context) (string, error)
+}
+
+// RateLimitObserver is implemented by credentials that spread requests over
+// several tokens and need the rate-limit headers of the responses they signed.
+type RateLimitObserver interface {
+	ObserveRateLimit(authorization string, status RateLimitStatus)
+}
+
+type TokenUsage struct {
+	Token     string    `json:"token"`
+	Requests  int       `json:"requests"`
+	Limit     int       `json:"limit"`
+	Remaining int       `json:"remaining"`
+	ResetAt   time.Time `json:"reset_at"`
+}
+
+type TokenUsageReporter interface {
+	TokenUsage() []TokenUsage
+}
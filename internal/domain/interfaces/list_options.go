@@ -1,8 +1,37 @@
 package interfaces
 
+// FilterOperator compares a column with a filter value.
+type FilterOperator string
+
+const (
+	FilterEqual          FilterOperator = "eq"
+	FilterNotEqual       FilterOperator = "ne"
+	FilterGreaterThan    FilterOperator = "gt"
+	FilterGreaterOrEqual FilterOperator = "gte"
+	FilterLessThan       FilterOperator = "lt"
+	FilterLessOrEqual    FilterOperator = "lte"
+	// FilterPrefix matches strings starting with Value. Whether the match is
+	// case-sensitive follows the column collation of the database.
+	FilterPrefix FilterOperator = "prefix"
+)
+
+// Filter narrows a listing to rows whose Column compares to Value with
+// Operator. Value is bound as a query parameter, so it takes the Go type of
+// the column: a bool for site_admin, a time.Time for updated_at and so on.
+type Filter struct {
+	Column   string
+	Operator FilterOperator
+	Value    interface{}
+}
+
+// ListOptions select one page of a listing. User listings only return rows
+// for which all Filters hold. Cursor continues a user listing after the page
+// that returned it as NextCursor; Page is ignored then.
 type ListOptions struct {
 	Limit          int
 	Page           int
 	OrderBy        string
 	OrderDirection string
+	Filters        []Filter
+	Cursor         string
 }
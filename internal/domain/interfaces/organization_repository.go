@@ -0,0 +1,20 @@
+package interfaces
+
+import (
+	"context"
+
+	"github.com/unkabogaton/github-users/internal/domain/entities"
+)
+
+type OrganizationRepository interface {
+	Upsert(ctx context.Context, organization *entities.Organization) error
+	BatchUpsert(ctx context.Context, organizations *[]entities.Organization) error
+	// BatchUpsertSummaries stores the summaries of /users/{username}/orgs
+	// without blanking the name and html_url of stored organizations.
+	BatchUpsertSummaries(ctx context.Context, organizations *[]entities.Organization) error
+	GetByLogin(ctx context.Context, login string) (*entities.Organization, error)
+	ReplaceMembers(ctx context.Context, organizationID int, memberships []entities.UserOrganization) error
+	ReplaceUserOrganizations(ctx context.Context, userID int, memberships []entities.UserOrganization) error
+	ListMembers(ctx context.Context, organizationLogin string, options ListOptions) ([]entities.User, error)
+	ListForUser(ctx context.Context, login string, options ListOptions) ([]entities.Organization, error)
+}
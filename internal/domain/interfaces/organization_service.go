@@ -0,0 +1,12 @@
+package interfaces
+
+import (
+	"context"
+
+	"github.com/unkabogaton/github-users/internal/domain/entities"
+)
+
+type OrganizationService interface {
+	ListMembers(ctx context.Context, organizationLogin string, options ListOptions) ([]entities.User, error)
+	ListForUser(ctx context.Context, username string, options ListOptions) ([]entities.Organization, error)
+}
@@ -0,0 +1,12 @@
+package interfaces
+
+import "time"
+
+type RateLimitStatus struct {
+	Resource     string    `json:"resource"`
+	Limit        int       `json:"limit"`
+	Remaining    int       `json:"remaining"`
+	Used         int       `json:"used"`
+	ResetAt      time.Time `json:"reset_at"`
+	BlockedUntil time.Time `json:"blocked_until"`
+}
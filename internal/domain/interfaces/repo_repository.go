@@ -0,0 +1,19 @@
+package interfaces
+
+import (
+	"context"
+
+	"github.com/unkabogaton/github-users/internal/domain/entities"
+)
+
+// RepositoryListOptions narrows a repository listing to one language. OrderBy
+// also accepts "stars" and "forks" as shorthands for the count columns.
+type RepositoryListOptions struct {
+	ListOptions
+	Language string
+}
+
+type RepoRepository interface {
+	ReplaceForOwner(ctx context.Context, ownerID int, repositories []entities.Repository) error
+	ListForOwner(ctx context.Context, ownerLogin string, options RepositoryListOptions) ([]entities.Repository, error)
+}
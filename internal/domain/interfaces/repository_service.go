@@ -0,0 +1,11 @@
+package interfaces
+
+import (
+	"context"
+
+	"github.com/unkabogaton/github-users/internal/domain/entities"
+)
+
+type RepositoryService interface {
+	ListForUser(ctx context.Context, username string, options RepositoryListOptions) ([]entities.Repository, error)
+}
@@ -0,0 +1,23 @@
+package interfaces
+
+import (
+	"context"
+
+	"github.com/unkabogaton/github-users/internal/domain/entities"
+)
+
+// UserListCache keeps pages of listed users and the counts behind them, keyed
+// on their ListOptions or filters and a generation. InvalidateUserLists moves on to a new generation, which makes
+// every cached page stale at once. Callers read the generation before querying
+// the database and store the page under it, so that a page read before a
+// write never lands in the generation after it.
+type UserListCache interface {
+	UserListGeneration(ctx context.Context) (int64, error)
+	GetUserList(ctx context.Context, generation int64, options ListOptions) ([]entities.User, bool, error)
+	SetUserList(ctx context.Context, generation int64, options ListOptions, users []entities.User) error
+	GetUserPage(ctx context.Context, generation int64, options ListOptions) (*UserPage, bool, error)
+	SetUserPage(ctx context.Context, generation int64, options ListOptions, page *UserPage) error
+	GetUserCount(ctx context.Context, generation int64, filters []Filter) (int64, bool, error)
+	SetUserCount(ctx context.Context, generation int64, filters []Filter, count int64) error
+	InvalidateUserLists(ctx context.Context) error
+}
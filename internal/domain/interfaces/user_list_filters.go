@@ -0,0 +1,34 @@
+package interfaces
+
+import "time"
+
+// UserListFilters are the user listing filters offered by the APIs. Unset
+// fields do not filter.
+type UserListFilters struct {
+	SiteAdmin     *bool
+	Type          string
+	LoginPrefix   string
+	UpdatedSince  *time.Time
+	UpdatedBefore *time.Time
+}
+
+// Filters translates the set fields into repository filters.
+func (userListFilters UserListFilters) Filters() []Filter {
+	var filters []Filter
+	if userListFilters.SiteAdmin != nil {
+		filters = append(filters, Filter{Column: "site_admin", Operator: FilterEqual, Value: *userListFilters.SiteAdmin})
+	}
+	if userListFilters.Type != "" {
+		filters = append(filters, Filter{Column: "type", Operator: FilterEqual, Value: userListFilters.Type})
+	}
+	if userListFilters.LoginPrefix != "" {
+		filters = append(filters, Filter{Column: "login", Operator: FilterPrefix, Value: userListFilters.LoginPrefix})
+	}
+	if userListFilters.UpdatedSince != nil {
+		filters = append(filters, Filter{Column: "updated_at", Operator: FilterGreaterOrEqual, Value: *userListFilters.UpdatedSince})
+	}
+	if userListFilters.UpdatedBefore != nil {
+		filters = append(filters, Filter{Column: "updated_at", Operator: FilterLessThan, Value: *userListFilters.UpdatedBefore})
+	}
+	return filters
+}
@@ -6,10 +6,22 @@ import (
 	"github.com/unkabogaton/github-users/internal/domain/entities"
 )
 
+// BatchUpsertResult counts the rows a batch upsert created and the existing
+// rows it overwrote.
+type BatchUpsertResult struct {
+	Inserted int64
+	Updated  int64
+}
+
 type UserRepository interface {
 	Upsert(ctx context.Context, user *entities.User) error
-	BatchUpsert(ctx context.Context, users *[]entities.User) error
+	BatchUpsert(ctx context.Context, users *[]entities.User) (BatchUpsertResult, error)
+	// BatchUpsertSummaries stores the summary users of list endpoints without
+	// overwriting the profile columns of users already stored.
+	BatchUpsertSummaries(ctx context.Context, users *[]entities.User) (BatchUpsertResult, error)
 	GetByLogin(ctx context.Context, login string) (*entities.User, error)
 	List(ctx context.Context, options ListOptions) ([]entities.User, error)
+	ListPage(ctx context.Context, options ListOptions) (*UserPage, error)
+	Count(ctx context.Context, filters []Filter) (int64, error)
 	DeleteByLogin(ctx context.Context, login string) error
 }
@@ -2,15 +2,38 @@ package interfaces
 
 import (
 	"context"
+	"time"
 
 	"github.com/unkabogaton/github-users/internal/domain/entities"
 )
 
 type UserService interface {
-	Get(ctx context.Context, username string) (*entities.User, error)
+	Get(ctx context.Context, username string, options GetUserOptions) (*entities.User, error)
 	List(ctx context.Context, options ListOptions) ([]entities.User, error)
+	ListPage(ctx context.Context, options ListOptions) (*UserPage, error)
 	Update(ctx context.Context, username string, update UpdateUserRequest) (*entities.User, error)
 	Delete(ctx context.Context, username string) error
+	Search(ctx context.Context, request SearchUsersRequest) (*SearchUsersResult, error)
+}
+
+// GetUserOptions is the freshness policy of a single lookup. A stored user
+// older than MaxAge is refetched from GitHub; zero MaxAge applies the service
+// default. Refresh skips the cache and the database altogether.
+type GetUserOptions struct {
+	MaxAge  time.Duration
+	Refresh bool
+}
+
+// UserPage is one page of a user listing. NextCursor selects the following
+// page and is empty on the last one. Total counts every user matching the
+// filters, not just this page; Page is zero for pages read by cursor.
+type UserPage struct {
+	Users      []entities.User `json:"users"`
+	Total      int64           `json:"total"`
+	Page       int             `json:"page,omitempty"`
+	Limit      int             `json:"limit"`
+	HasMore    bool            `json:"has_more"`
+	NextCursor string          `json:"next_cursor,omitempty"`
 }
 
 type UpdateUserRequest struct {
@@ -23,3 +46,17 @@ type UpdateUserRequest struct {
 	UserViewType string `json:"UserViewType"`
 	SiteAdmin    bool   `json:"SiteAdmin"`
 }
+
+type SearchUsersRequest struct {
+	Query   string
+	Sort    string
+	Order   string
+	Page    int
+	Persist bool
+}
+
+type SearchUsersResult struct {
+	TotalCount        int             `json:"total_count"`
+	IncompleteResults bool            `json:"incomplete_results"`
+	Users             []entities.User `json:"users"`
+}
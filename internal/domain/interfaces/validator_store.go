@@ -0,0 +1,17 @@
+package interfaces
+
+import "context"
+
+// ResponseValidators are the conditional-request validators GitHub returned
+// for a URL, kept together with the body they describe so that a
+// 304 Not Modified can be answered from the stored copy.
+type ResponseValidators struct {
+	ETag         string `json:"etag"`
+	LastModified string `json:"last_modified"`
+	Body         []byte `json:"body"`
+}
+
+type ValidatorStore interface {
+	GetValidators(ctx context.Context, requestURL string) (*ResponseValidators, bool, error)
+	SetValidators(ctx context.Context, requestURL string, validators *ResponseValidators) error
+}
@@ -0,0 +1,118 @@
+package database
+
+import (
+	"fmt"
+	"net"
+	"net/url"
+	"os"
+
+	_ "github.com/go-sql-driver/mysql"
+	"github.com/jmoiron/sqlx"
+	_ "github.com/lib/pq"
+	_ "github.com/mattn/go-sqlite3"
+	derr "github.com/unkabogaton/github-users/internal/domain/errors"
+)
+
+const (
+	DriverMySQL    = "mysql"
+	DriverPostgres = "postgres"
+	DriverSQLite   = "sqlite"
+)
+
+// ConnectionOptions describe the database the services store users in. For
+// SQLite, Name is the path of the database file.
+type ConnectionOptions struct {
+	Driver   string
+	Host     string
+	Port     string
+	User     string
+	Password string
+	Name     string
+	SSLMode  string
+}
+
+// ConnectionOptionsFromEnvironment reads DB_DRIVER, DB_USER, DB_PASSWORD,
+// DB_PORT, DB_NAME and DB_SSL_MODE, and the host from hostVariable.
+func ConnectionOptionsFromEnvironment(hostVariable string) ConnectionOptions {
+	options := ConnectionOptions{
+		Driver:   os.Getenv("DB_DRIVER"),
+		Host:     os.Getenv(hostVariable),
+		Port:     os.Getenv("DB_PORT"),
+		User:     os.Getenv("DB_USER"),
+		Password: os.Getenv("DB_PASSWORD"),
+		Name:     os.Getenv("DB_NAME"),
+		SSLMode:  os.Getenv("DB_SSL_MODE"),
+	}
+	if options.Driver == "" {
+		options.Driver = DriverMySQL
+	}
+	return options
+}
+
+// sqlxDriverName maps DB_DRIVER onto the name the driver registered with
+// database/sql.
+func (options ConnectionOptions) sqlxDriverName() (string, error) {
+	switch options.Driver {
+	case DriverMySQL:
+		return "mysql", nil
+	case DriverPostgres:
+		return "postgres", nil
+	case DriverSQLite:
+		return "sqlite3", nil
+	default:
+		return "", derr.New(derr.ErrorCodeValidation, fmt.Sprintf("unknown DB_DRIVER %q", options.Driver))
+	}
+}
+
+// DataSourceName formats options for the selected driver.
+func (options ConnectionOptions) DataSourceName() (string, error) {
+	switch options.Driver {
+	case DriverMySQL:
+		return fmt.Sprintf("%s:%s@tcp(%s:%s)/%s?parseTime=true&multiStatements=true",
+			options.User, options.Password, options.Host, options.Port, options.Name), nil
+	case DriverPostgres:
+		sslMode := options.SSLMode
+		if sslMode == "" {
+			sslMode = "disable"
+		}
+		dataSource := url.URL{
+			Scheme:   "postgres",
+			User:     url.UserPassword(options.User, options.Password),
+			Host:     net.JoinHostPort(options.Host, options.Port),
+			Path:     "/" + options.Name,
+			RawQuery: url.Values{"sslmode": {sslMode}}.Encode(),
+		}
+		return dataSource.String(), nil
+	case DriverSQLite:
+		if options.Name == "" {
+			return "", derr.New(derr.ErrorCodeValidation, "DB_NAME must name the SQLite database file")
+		}
+		return fmt.Sprintf("file:%s?_busy_timeout=5000&_journal_mode=WAL", options.Name), nil
+	default:
+		_, err := options.sqlxDriverName()
+		return "", err
+	}
+}
+
+// Open opens the database described by options. Like sql.Open it does not
+// connect until the first query.
+func Open(options ConnectionOptions) (*sqlx.DB, error) {
+	driverName, err := options.sqlxDriverName()
+	if err != nil {
+		return nil, err
+	}
+	dataSourceName, err := options.DataSourceName()
+	if err != nil {
+		return nil, err
+	}
+
+	database, err := sqlx.Open(driverName, dataSourceName)
+	if err != nil {
+		return nil, err
+	}
+	if options.Driver == DriverSQLite {
+		// SQLite allows a single writer; one connection avoids SQLITE_BUSY.
+		database.SetMaxOpenConns(1)
+	}
+	return database, nil
+}
@@ -0,0 +1,36 @@
+package database
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	derr "github.com/unkabogaton/github-users/internal/domain/errors"
+)
+
+func TestConnectionOptions_DataSourceName(t *testing.T) {
+	t.Parallel()
+	options := ConnectionOptions{Host: "db", Port: "5432", User: "app", Password: "p@ss", Name: "github_users"}
+
+	options.Driver = DriverMySQL
+	dataSourceName, err := options.DataSourceName()
+	require.NoError(t, err)
+	require.Equal(t, "app:p@ss@tcp(db:5432)/github_users?parseTime=true&multiStatements=true", dataSourceName)
+
+	options.Driver = DriverPostgres
+	dataSourceName, err = options.DataSourceName()
+	require.NoError(t, err)
+	require.Equal(t, "postgres://app:p%40ss@db:5432/github_users?sslmode=disable", dataSourceName)
+
+	options.Driver = DriverSQLite
+	options.Name = "/tmp/users.db"
+	dataSourceName, err = options.DataSourceName()
+	require.NoError(t, err)
+	require.Equal(t, "file:/tmp/users.db?_busy_timeout=5000&_journal_mode=WAL", dataSourceName)
+}
+
+func TestOpen_UnknownDriver(t *testing.T) {
+	t.Parallel()
+	_, err := Open(ConnectionOptions{Driver: "oracle"})
+	require.True(t, derr.IsCode(err, derr.ErrorCodeValidation))
+}
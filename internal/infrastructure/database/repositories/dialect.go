@@ -0,0 +1,255 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// dialect hides the SQL differences between the supported databases. Queries
+// are written with ? placeholders and rebound before they run.
+type dialect interface {
+	rebind(query string) string
+	quoteIdentifier(name string) string
+	// upsertClause turns an INSERT into an upsert that overwrites
+	// updateColumns when a row with the same conflictColumns exists.
+	upsertClause(conflictColumns, updateColumns []string) string
+	// maxPlaceholders is the most parameters one statement may bind.
+	maxPlaceholders() int
+	// execUpsert runs a multi-row upsert and reports how many of its rows
+	// were inserted and how many updated.
+	execUpsert(ctx context.Context, transaction *sqlx.Tx, statement upsertStatement) (int64, int64, error)
+}
+
+// upsertStatement is one multi-row upsert of rowCount rows into tableName.
+// conflictArguments holds the conflict column values of every row, row after
+// row, for dialects that cannot tell inserted rows from updated ones by
+// themselves.
+type upsertStatement struct {
+	tableName         string
+	query             string
+	arguments         []interface{}
+	rowCount          int
+	conflictColumns   []string
+	conflictArguments []interface{}
+}
+
+// dialectForDriver picks the dialect for an sqlx driver name. Unknown drivers
+// get MySQL, which the schema was written for first.
+func dialectForDriver(driverName string) dialect {
+	switch driverName {
+	case "postgres", "pgx":
+		return postgresDialect{}
+	case "sqlite3", "sqlite":
+		return sqliteDialect{}
+	default:
+		return mysqlDialect{}
+	}
+}
+
+var plainIdentifier = regexp.MustCompile(`^[a-z_][a-z0-9_]*$`)
+
+// quoteIdentifier leaves lower-case identifiers that are not reserved as they
+// are, so that generated SQL stays readable, and quotes everything else.
+func quoteIdentifier(name string, quote string, reservedWords map[string]bool) string {
+	if plainIdentifier.MatchString(name) && !reservedWords[name] {
+		return name
+	}
+	return quote + strings.ReplaceAll(name, quote, quote+quote) + quote
+}
+
+func quoteIdentifiers(sqlDialect dialect, names []string) []string {
+	quoted := make([]string, 0, len(names))
+	for _, name := range names {
+		quoted = append(quoted, sqlDialect.quoteIdentifier(name))
+	}
+	return quoted
+}
+
+// sharedReservedWords are reserved by all of MySQL, PostgreSQL and SQLite.
+var sharedReservedWords = map[string]bool{
+	"all": true, "and": true, "as": true, "by": true, "case": true, "check": true,
+	"column": true, "create": true, "default": true, "delete": true, "distinct": true,
+	"drop": true, "else": true, "from": true, "group": true, "having": true, "in": true,
+	"insert": true, "into": true, "is": true, "join": true, "limit": true, "not": true,
+	"null": true, "on": true, "or": true, "order": true, "primary": true, "references": true,
+	"select": true, "set": true, "table": true, "then": true, "to": true, "union": true,
+	"unique": true, "update": true, "using": true, "values": true, "when": true, "where": true,
+}
+
+func withReservedWords(words ...string) map[string]bool {
+	reservedWords := make(map[string]bool, len(sharedReservedWords)+len(words))
+	for word := range sharedReservedWords {
+		reservedWords[word] = true
+	}
+	for _, word := range words {
+		reservedWords[word] = true
+	}
+	return reservedWords
+}
+
+var (
+	mysqlReservedWords    = withReservedWords("key", "keys", "index", "interval", "rank", "read", "release", "groups")
+	postgresReservedWords = withReservedWords("user", "offset", "analyse", "analyze", "current_user", "window")
+	sqliteReservedWords   = withReservedWords("index", "offset", "transaction", "trigger", "action")
+)
+
+type mysqlDialect struct{}
+
+func (mysqlDialect) rebind(query string) string { return query }
+
+func (mysqlDialect) quoteIdentifier(name string) string {
+	return quoteIdentifier(name, "`", mysqlReservedWords)
+}
+
+func (sqlDialect mysqlDialect) upsertClause(conflictColumns, updateColumns []string) string {
+	assignments := make([]string, 0, len(updateColumns)+1)
+	for _, column := range quoteIdentifiers(sqlDialect, updateColumns) {
+		assignments = append(assignments, fmt.Sprintf("%s = VALUES(%s)", column, column))
+	}
+	assignments = append(assignments, "updated_at = CURRENT_TIMESTAMP")
+	return "ON DUPLICATE KEY UPDATE " + strings.Join(assignments, ", ")
+}
+
+func (mysqlDialect) maxPlaceholders() int { return 65535 }
+
+// execUpsert looks up which of the rows already exist before running the
+// statement. The affected-rows count cannot tell them apart: MySQL reports an
+// update that leaves a row as it was, updated_at included, as no row at all,
+// or as one row like an insert under clientFoundRows. A row another writer
+// inserts between the lookup and the statement is counted as inserted.
+func (sqlDialect mysqlDialect) execUpsert(
+	ctx context.Context,
+	transaction *sqlx.Tx,
+	statement upsertStatement,
+) (int64, int64, error) {
+	existing, err := countExistingKeys(ctx, transaction, sqlDialect, statement, "")
+	if err != nil {
+		return 0, 0, err
+	}
+	if _, err := transaction.ExecContext(ctx, statement.query, statement.arguments...); err != nil {
+		return 0, 0, err
+	}
+	return int64(statement.rowCount) - existing, existing, nil
+}
+
+// countExistingKeys counts the stored rows whose conflict columns match a row
+// of statement, through the unique index on those columns. Composite keys are
+// listed as row values after compositeListPrefix, which SQLite needs to be
+// VALUES and MySQL to be empty.
+func countExistingKeys(
+	ctx context.Context,
+	transaction *sqlx.Tx,
+	sqlDialect dialect,
+	statement upsertStatement,
+	compositeListPrefix string,
+) (int64, error) {
+	keyPlaceholders := "?"
+	if len(statement.conflictColumns) > 1 {
+		keyPlaceholders = "(" + strings.TrimSuffix(strings.Repeat("?, ", len(statement.conflictColumns)), ", ") + ")"
+	}
+	keyList := strings.TrimSuffix(strings.Repeat(keyPlaceholders+", ", statement.rowCount), ", ")
+	keyColumns := strings.Join(quoteIdentifiers(sqlDialect, statement.conflictColumns), ", ")
+	if len(statement.conflictColumns) > 1 {
+		keyColumns = "(" + keyColumns + ")"
+		keyList = compositeListPrefix + keyList
+	}
+	existingQuery := sqlDialect.rebind(fmt.Sprintf(
+		"SELECT COUNT(*) FROM %s WHERE %s IN (%s)",
+		sqlDialect.quoteIdentifier(statement.tableName),
+		keyColumns,
+		keyList,
+	))
+
+	var existing int64
+	err := transaction.GetContext(ctx, &existing, existingQuery, statement.conflictArguments...)
+	return existing, err
+}
+
+// conflictUpsertClause is the ON CONFLICT form shared by PostgreSQL and
+// SQLite.
+func conflictUpsertClause(sqlDialect dialect, conflictColumns, updateColumns []string) string {
+	assignments := make([]string, 0, len(updateColumns)+1)
+	for _, column := range quoteIdentifiers(sqlDialect, updateColumns) {
+		assignments = append(assignments, fmt.Sprintf("%s = excluded.%s", column, column))
+	}
+	assignments = append(assignments, "updated_at = CURRENT_TIMESTAMP")
+	return fmt.Sprintf(
+		"ON CONFLICT (%s) DO UPDATE SET %s",
+		strings.Join(quoteIdentifiers(sqlDialect, conflictColumns), ", "),
+		strings.Join(assignments, ", "),
+	)
+}
+
+type postgresDialect struct{}
+
+func (postgresDialect) rebind(query string) string { return sqlx.Rebind(sqlx.DOLLAR, query) }
+
+func (postgresDialect) quoteIdentifier(name string) string {
+	return quoteIdentifier(name, `"`, postgresReservedWords)
+}
+
+func (sqlDialect postgresDialect) upsertClause(conflictColumns, updateColumns []string) string {
+	return conflictUpsertClause(sqlDialect, conflictColumns, updateColumns)
+}
+
+func (postgresDialect) maxPlaceholders() int { return 65535 }
+
+// execUpsert asks PostgreSQL for every row whether it was inserted: a freshly
+// inserted row version has no deleting transaction, so its xmax is 0.
+func (postgresDialect) execUpsert(
+	ctx context.Context,
+	transaction *sqlx.Tx,
+	statement upsertStatement,
+) (int64, int64, error) {
+	var insertedFlags []bool
+	if err := transaction.SelectContext(ctx, &insertedFlags, statement.query+" RETURNING (xmax = 0)", statement.arguments...); err != nil {
+		return 0, 0, err
+	}
+
+	var inserted, updated int64
+	for _, wasInserted := range insertedFlags {
+		if wasInserted {
+			inserted++
+		} else {
+			updated++
+		}
+	}
+	return inserted, updated, nil
+}
+
+type sqliteDialect struct{}
+
+func (sqliteDialect) rebind(query string) string { return query }
+
+func (sqliteDialect) quoteIdentifier(name string) string {
+	return quoteIdentifier(name, `"`, sqliteReservedWords)
+}
+
+func (sqlDialect sqliteDialect) upsertClause(conflictColumns, updateColumns []string) string {
+	return conflictUpsertClause(sqlDialect, conflictColumns, updateColumns)
+}
+
+// maxPlaceholders is SQLITE_MAX_VARIABLE_NUMBER of SQLite 3.32 and later.
+func (sqliteDialect) maxPlaceholders() int { return 32766 }
+
+// execUpsert looks up which of the rows already exist before running the
+// statement. SQLite allows one writer at a time, so every row not found is
+// inserted.
+func (sqlDialect sqliteDialect) execUpsert(
+	ctx context.Context,
+	transaction *sqlx.Tx,
+	statement upsertStatement,
+) (int64, int64, error) {
+	existing, err := countExistingKeys(ctx, transaction, sqlDialect, statement, "VALUES ")
+	if err != nil {
+		return 0, 0, err
+	}
+	if _, err := transaction.ExecContext(ctx, statement.query, statement.arguments...); err != nil {
+		return 0, 0, err
+	}
+	return int64(statement.rowCount) - existing, existing, nil
+}
@@ -0,0 +1,77 @@
+package repositories
+
+import (
+	"context"
+	"regexp"
+	"testing"
+
+	sqlmock "github.com/DATA-DOG/go-sqlmock"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDialectForDriver(t *testing.T) {
+	t.Parallel()
+	require.IsType(t, mysqlDialect{}, dialectForDriver("mysql"))
+	require.IsType(t, postgresDialect{}, dialectForDriver("postgres"))
+	require.IsType(t, sqliteDialect{}, dialectForDriver("sqlite3"))
+	require.IsType(t, mysqlDialect{}, dialectForDriver("sqlmock"))
+}
+
+func TestDialect_UpsertClause(t *testing.T) {
+	t.Parallel()
+	require.Equal(t,
+		"ON DUPLICATE KEY UPDATE login = VALUES(login), updated_at = CURRENT_TIMESTAMP",
+		mysqlDialect{}.upsertClause([]string{"id"}, []string{"login"}),
+	)
+	require.Equal(t,
+		"ON CONFLICT (follower_id, following_id) DO UPDATE SET updated_at = CURRENT_TIMESTAMP",
+		postgresDialect{}.upsertClause([]string{"follower_id", "following_id"}, nil),
+	)
+	require.Equal(t,
+		"ON CONFLICT (id) DO UPDATE SET login = excluded.login, updated_at = CURRENT_TIMESTAMP",
+		sqliteDialect{}.upsertClause([]string{"id"}, []string{"login"}),
+	)
+}
+
+func TestDialect_QuoteIdentifierAndRebind(t *testing.T) {
+	t.Parallel()
+	require.Equal(t, "login", mysqlDialect{}.quoteIdentifier("login"))
+	require.Equal(t, "`key`", mysqlDialect{}.quoteIdentifier("key"))
+	require.Equal(t, `"user"`, postgresDialect{}.quoteIdentifier("user"))
+	require.Equal(t, `"Odd""Name"`, sqliteDialect{}.quoteIdentifier(`Odd"Name`))
+
+	query := "SELECT id FROM github_users WHERE login = ? LIMIT ? OFFSET ?"
+	require.Equal(t, query, mysqlDialect{}.rebind(query))
+	require.Equal(t, "SELECT id FROM github_users WHERE login = $1 LIMIT $2 OFFSET $3", postgresDialect{}.rebind(query))
+}
+
+func TestMySQLDialect_ExecUpsertCountsUnchangedRowsAsUpdated(t *testing.T) {
+	t.Parallel()
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT COUNT(*) FROM github_users WHERE id IN (?, ?)")).
+		WithArgs(1, 2).
+		WillReturnRows(sqlmock.NewRows([]string{"COUNT(*)"}).AddRow(2))
+	// Both rows already held these values, so none was affected.
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO github_users")).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+
+	transaction, err := sqlx.NewDb(db, "mysql").Beginx()
+	require.NoError(t, err)
+	inserted, updated, err := mysqlDialect{}.execUpsert(context.Background(), transaction, upsertStatement{
+		tableName:         "github_users",
+		query:             "INSERT INTO github_users (id, login) VALUES (?, ?), (?, ?) ON DUPLICATE KEY UPDATE login = VALUES(login)",
+		arguments:         []interface{}{1, "alpha", 2, "bravo"},
+		rowCount:          2,
+		conflictColumns:   []string{"id"},
+		conflictArguments: []interface{}{1, 2},
+	})
+	require.NoError(t, err)
+	require.Zero(t, inserted)
+	require.EqualValues(t, 2, updated)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,111 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/unkabogaton/github-users/internal/domain/entities"
+	"github.com/unkabogaton/github-users/internal/domain/interfaces"
+)
+
+type FollowRepository struct {
+	*GenericRepository[entities.UserFollow]
+	userColumnList []string
+}
+
+func NewFollowRepository(database *sqlx.DB) interfaces.FollowRepository {
+	genericRepository := NewGenericRepository[entities.UserFollow](database, "user_follows", "follower_id", "follower_id", "following_id")
+	return &FollowRepository{
+		GenericRepository: genericRepository,
+		userColumnList:    extractColumnNames(entities.User{}),
+	}
+}
+
+// ReplaceFollowers swaps the stored followers of userID for follows, so that
+// users who unfollowed since the last crawl drop out of the graph.
+func (followRepository *FollowRepository) ReplaceFollowers(
+	ctx context.Context,
+	userID int,
+	follows []entities.UserFollow,
+) error {
+	return followRepository.replace(ctx, "following_id", userID, follows)
+}
+
+// ReplaceFollowing swaps the stored accounts userID follows for follows.
+func (followRepository *FollowRepository) ReplaceFollowing(
+	ctx context.Context,
+	userID int,
+	follows []entities.UserFollow,
+) error {
+	return followRepository.replace(ctx, "follower_id", userID, follows)
+}
+
+// replace swaps the edges whose userColumn is userID for follows in one
+// transaction.
+func (followRepository *FollowRepository) replace(
+	ctx context.Context,
+	userColumn string,
+	userID int,
+	follows []entities.UserFollow,
+) error {
+	// The upsert writes every column, so leave no zero timestamps behind.
+	now := time.Now()
+	for index := range follows {
+		if follows[index].CreatedAt.IsZero() {
+			follows[index].CreatedAt = now
+		}
+		if follows[index].UpdatedAt.IsZero() {
+			follows[index].UpdatedAt = now
+		}
+	}
+	return followRepository.ReplaceByField(ctx, userColumn, fmt.Sprint(userID), follows)
+}
+
+// ListFollowers returns the stored users following login. Followers that were
+// crawled as edges but never synced as users are left out.
+func (followRepository *FollowRepository) ListFollowers(
+	ctx context.Context,
+	login string,
+	listOptions interfaces.ListOptions,
+) ([]entities.User, error) {
+	return followRepository.listUsers(ctx, "follower_id", "following_id", login, listOptions)
+}
+
+// ListFollowing returns the stored users login follows.
+func (followRepository *FollowRepository) ListFollowing(
+	ctx context.Context,
+	login string,
+	listOptions interfaces.ListOptions,
+) ([]entities.User, error) {
+	return followRepository.listUsers(ctx, "following_id", "follower_id", login, listOptions)
+}
+
+// listUsers joins user_follows onto github_users twice: once to find the
+// edges of login through targetColumn, once to load the users on the other
+// end through userColumn.
+func (followRepository *FollowRepository) listUsers(
+	ctx context.Context,
+	userColumn string,
+	targetColumn string,
+	login string,
+	listOptions interfaces.ListOptions,
+) ([]entities.User, error) {
+	var results []entities.User
+
+	orderClause, limit, offset := orderAndPage(followRepository.dialect, listOptions, followRepository.userColumnList, "u")
+	query := followRepository.dialect.rebind(fmt.Sprintf(
+		"SELECT %s FROM user_follows f JOIN github_users u ON u.id = f.%s JOIN github_users target ON target.id = f.%s WHERE target.login = ? %s LIMIT ? OFFSET ?",
+		qualifiedColumns(followRepository.dialect, followRepository.userColumnList, "u"),
+		userColumn,
+		targetColumn,
+		orderClause,
+	))
+
+	if err := followRepository.database.SelectContext(ctx, &results, query, login, limit, offset); err != nil {
+		return nil, err
+	}
+
+	return results, nil
+}
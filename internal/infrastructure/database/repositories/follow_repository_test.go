@@ -0,0 +1,97 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"testing"
+
+	sqlmock "github.com/DATA-DOG/go-sqlmock"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/require"
+
+	"github.com/unkabogaton/github-users/internal/domain/entities"
+	"github.com/unkabogaton/github-users/internal/domain/interfaces"
+)
+
+func TestFollowRepository_ReplaceFollowers(t *testing.T) {
+	t.Parallel()
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, "mysql")
+	repository := NewFollowRepository(sqlxDB)
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta("DELETE FROM user_follows WHERE following_id = ?")).
+		WithArgs("1").
+		WillReturnResult(sqlmock.NewResult(0, 3))
+	expectExistingKeys(mock, "user_follows", 0)
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO user_follows (follower_id, following_id, updated_at, created_at)")).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
+
+	err = repository.ReplaceFollowers(context.Background(), 1, []entities.UserFollow{{FollowerID: 2, FollowingID: 1}})
+	require.NoError(t, err)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestFollowRepository_ReplaceFollowingRollsBackFailedInsert(t *testing.T) {
+	t.Parallel()
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	repository := NewFollowRepository(sqlx.NewDb(db, "mysql"))
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta("DELETE FROM user_follows WHERE follower_id = ?")).
+		WithArgs("1").
+		WillReturnResult(sqlmock.NewResult(0, 3))
+	expectExistingKeys(mock, "user_follows", 0)
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO user_follows")).
+		WillReturnError(errors.New("connection reset"))
+	mock.ExpectRollback()
+
+	err = repository.ReplaceFollowing(context.Background(), 1, []entities.UserFollow{{FollowerID: 1, FollowingID: 2}})
+	require.Error(t, err)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestFollowRepository_ListFollowers(t *testing.T) {
+	t.Parallel()
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, "mysql")
+	repository := NewFollowRepository(sqlxDB)
+
+	rows := sqlmock.NewRows([]string{
+		"id", "login", "node_id", "avatar_url", "url", "html_url", "type", "user_view_type", "site_admin",
+		"name", "company", "blog", "location", "email", "bio", "twitter_username",
+		"public_repos", "public_gists", "followers", "following", "github_created_at", "github_updated_at",
+		"updated_at", "created_at",
+	}).AddRow(
+		sampleUser.ID, sampleUser.Login, sampleUser.NodeID, sampleUser.AvatarURL, sampleUser.URL, sampleUser.HTMLURL,
+		sampleUser.Type, sampleUser.UserViewType, sampleUser.SiteAdmin,
+		sampleUser.Name, sampleUser.Company, sampleUser.Blog, sampleUser.Location, sampleUser.Email, sampleUser.Bio,
+		sampleUser.TwitterUsername, sampleUser.PublicRepos, sampleUser.PublicGists, sampleUser.Followers,
+		sampleUser.Following, sampleUser.GitHubCreatedAt, sampleUser.GitHubUpdatedAt,
+		sampleUser.UpdatedAt, sampleUser.CreatedAt,
+	)
+
+	mock.ExpectQuery(regexp.QuoteMeta(
+		"FROM user_follows f JOIN github_users u ON u.id = f.follower_id JOIN github_users target ON target.id = f.following_id WHERE target.login = ? ORDER BY u.login DESC LIMIT ? OFFSET ?",
+	)).
+		WithArgs("octocat", 5, 5).
+		WillReturnRows(rows)
+
+	options := interfaces.ListOptions{Limit: 5, Page: 2, OrderBy: "login", OrderDirection: "desc"}
+	followers, err := repository.ListFollowers(context.Background(), "octocat", options)
+	require.NoError(t, err)
+	require.Len(t, followers, 1)
+	require.Equal(t, sampleUser.Login, followers[0].Login)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
@@ -4,27 +4,48 @@ import (
 	"context"
 	"fmt"
 	"reflect"
+	"slices"
 	"strings"
+	"time"
 
 	"github.com/jmoiron/sqlx"
+	derr "github.com/unkabogaton/github-users/internal/domain/errors"
+	"github.com/unkabogaton/github-users/internal/domain/interfaces"
 )
 
+// defaultMaxStatementBytes keeps batches under the 4 MiB max_allowed_packet
+// of older MySQL servers.
+const defaultMaxStatementBytes = 4 << 20
+
 type GenericRepository[T any] struct {
-	database   *sqlx.DB
-	tableName  string
-	columnList []string
-	keyColumn  string
+	database          *sqlx.DB
+	dialect           dialect
+	tableName         string
+	columnList        []string
+	keyColumn         string
+	conflictColumns   []string
+	maxPlaceholders   int
+	maxStatementBytes int
 }
 
-func NewGenericRepository[T any](database *sqlx.DB, tableName, keyColumn string) *GenericRepository[T] {
+// NewGenericRepository stores T in tableName. The SQL dialect follows the
+// driver database was opened with. Upserts treat rows with equal
+// conflictColumns as the same row; they default to keyColumn and must match
+// the primary key or a unique index.
+func NewGenericRepository[T any](database *sqlx.DB, tableName, keyColumn string, conflictColumns ...string) *GenericRepository[T] {
 	var zeroValue T
 	columnList := extractColumnNames(zeroValue)
+	if len(conflictColumns) == 0 {
+		conflictColumns = []string{keyColumn}
+	}
 
 	return &GenericRepository[T]{
-		database:   database,
-		tableName:  tableName,
-		columnList: columnList,
-		keyColumn:  keyColumn,
+		database:        database,
+		dialect:         dialectForDriver(database.DriverName()),
+		tableName:       tableName,
+		columnList:      columnList,
+		keyColumn:       keyColumn,
+		conflictColumns: conflictColumns,
 	}
 }
 
@@ -44,7 +65,7 @@ func extractColumnNames[T any](zeroValue T) []string {
 func (repository *GenericRepository[T]) Upsert(ctx context.Context, entity T) error {
 	columns := []string{}
 	placeholders := []string{}
-	updateAssignments := []string{}
+	updateColumns := []string{}
 
 	entityValue := reflect.ValueOf(entity)
 	entityType := reflect.TypeOf(entity)
@@ -64,33 +85,21 @@ func (repository *GenericRepository[T]) Upsert(ctx context.Context, entity T) er
 		columns = append(columns, dbTag)
 		placeholders = append(placeholders, ":"+dbTag)
 
-		if dbTag != repository.keyColumn && dbTag != "updated_at" {
-			updateAssignments = append(updateAssignments, fmt.Sprintf("%s = VALUES(%s)", dbTag, dbTag))
+		if !repository.isConflictColumn(dbTag) && dbTag != "updated_at" {
+			updateColumns = append(updateColumns, dbTag)
 		}
 	}
 
-	columnsStr := strings.Join(columns, ", ")
+	columnsStr := strings.Join(quoteIdentifiers(repository.dialect, columns), ", ")
 	valuesStr := strings.Join(placeholders, ", ")
 
-	var updateClause string
-	if len(updateAssignments) > 0 {
-		updateClause = strings.Join(updateAssignments, ", ") + ", updated_at = CURRENT_TIMESTAMP"
-	} else {
-		updateClause = "updated_at = CURRENT_TIMESTAMP"
-	}
-
 	query := fmt.Sprintf(`
 		INSERT INTO %s (%s) VALUES (%s)
-		ON DUPLICATE KEY UPDATE %s`,
-		repository.tableName, columnsStr, valuesStr, updateClause)
-
-	fmt.Printf("Entity Values: %+v\n", entity)
-	fmt.Printf("Query: %+v\n", query)
-
-	result, err := repository.database.NamedExecContext(ctx, query, entity)
-
-	fmt.Printf("Update Query: %s\n", result)
+		%s`,
+		repository.quotedTableName(), columnsStr, valuesStr,
+		repository.dialect.upsertClause(repository.conflictColumns, updateColumns))
 
+	_, err := repository.database.NamedExecContext(ctx, query, entity)
 	return err
 }
 
@@ -109,7 +118,7 @@ func (repository *GenericRepository[T]) Update(ctx context.Context, entity T) er
 			continue
 		}
 
-		setAssignments = append(setAssignments, fmt.Sprintf("%s = :%s", dbTag, dbTag))
+		setAssignments = append(setAssignments, fmt.Sprintf("%s = :%s", repository.dialect.quoteIdentifier(dbTag), dbTag))
 	}
 
 	if len(setAssignments) == 0 {
@@ -122,129 +131,314 @@ func (repository *GenericRepository[T]) Update(ctx context.Context, entity T) er
 		UPDATE %s
 		SET %s
 		WHERE %s = :%s`,
-		repository.tableName,
+		repository.quotedTableName(),
 		setClause,
-		repository.keyColumn,
+		repository.dialect.quoteIdentifier(repository.keyColumn),
 		repository.keyColumn,
 	)
 
-	result, err := repository.database.NamedExecContext(ctx, query, entity)
+	_, err := repository.database.NamedExecContext(ctx, query, entity)
+	return err
+}
+
+// BatchUpsert writes entitiesToUpsert with multi-row upsert statements. Rows
+// are split into chunks that stay under the dialect's placeholder limit and
+// maxStatementBytes, and every chunk runs in its own
+// transaction, so a failure leaves earlier chunks stored. Zero created_at and
+// updated_at values are written as the current time; created_at is never
+// overwritten on update. Of several entities with the same conflict columns
+// only the last is written.
+func (repository *GenericRepository[T]) BatchUpsert(ctx context.Context, entitiesToUpsert []T) (interfaces.BatchUpsertResult, error) {
+	return repository.BatchUpsertColumns(ctx, entitiesToUpsert, repository.allUpdateColumns())
+}
 
-	fmt.Printf("Entity Values: %+v\n", entity)
-	fmt.Printf("Update Query: %s\n", result)
+// allUpdateColumns are the columns an upsert overwrites on existing rows: all
+// but the conflict columns and the timestamps.
+func (repository *GenericRepository[T]) allUpdateColumns() []string {
+	updateColumns := []string{}
+	for _, columnName := range repository.columnList {
+		if !repository.isConflictColumn(columnName) && columnName != "updated_at" && columnName != "created_at" {
+			updateColumns = append(updateColumns, columnName)
+		}
+	}
+	return updateColumns
+}
 
-	return err
+// BatchUpsertColumns works like BatchUpsert, but overwrites only
+// updateColumns of rows that already exist. New rows are inserted in full.
+// It stores partial records, such as the summaries of list endpoints, without
+// blanking the columns they do not carry.
+func (repository *GenericRepository[T]) BatchUpsertColumns(
+	ctx context.Context,
+	entitiesToUpsert []T,
+	updateColumns []string,
+) (interfaces.BatchUpsertResult, error) {
+	return repository.upsertChunks(ctx, entitiesToUpsert, updateColumns, repository.execChunk)
 }
 
-func (repository *GenericRepository[T]) BatchUpsert(ctx context.Context, entitiesToUpsert []T) error {
+// ReplaceByField deletes the rows whose fieldName equals fieldValue and
+// upserts replacements in their place. Both steps run in one transaction, so
+// readers never see the rows missing and a failed write keeps the old ones.
+func (repository *GenericRepository[T]) ReplaceByField(
+	ctx context.Context,
+	fieldName string,
+	fieldValue string,
+	replacements []T,
+) error {
+	transaction, err := repository.database.BeginTxx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	deleteQuery := repository.dialect.rebind(fmt.Sprintf(
+		"DELETE FROM %s WHERE %s = ?",
+		repository.quotedTableName(),
+		repository.dialect.quoteIdentifier(fieldName),
+	))
+	if _, err := transaction.ExecContext(ctx, deleteQuery, fieldValue); err != nil {
+		_ = transaction.Rollback()
+		return err
+	}
+
+	execInTransaction := func(ctx context.Context, statement upsertStatement) (int64, int64, error) {
+		return repository.dialect.execUpsert(ctx, transaction, statement)
+	}
+	if _, err := repository.upsertChunks(ctx, replacements, repository.allUpdateColumns(), execInTransaction); err != nil {
+		_ = transaction.Rollback()
+		return err
+	}
+	return transaction.Commit()
+}
+
+// upsertChunks builds the multi-row upsert statements of entitiesToUpsert and
+// hands every chunk to execChunk.
+func (repository *GenericRepository[T]) upsertChunks(
+	ctx context.Context,
+	entitiesToUpsert []T,
+	updateColumns []string,
+	execChunk func(ctx context.Context, statement upsertStatement) (int64, int64, error),
+) (interfaces.BatchUpsertResult, error) {
+	var batchResult interfaces.BatchUpsertResult
 	if len(entitiesToUpsert) == 0 {
-		return nil
+		return batchResult, nil
 	}
 
 	columns := []string{}
-	updateAssignments := []string{}
+	fieldIndexes := []int{}
 
 	entityType := reflect.TypeOf(entitiesToUpsert[0])
 	for fieldIndex := 0; fieldIndex < entityType.NumField(); fieldIndex++ {
-		field := entityType.Field(fieldIndex)
-		dbColumnName := field.Tag.Get("db")
+		dbColumnName := entityType.Field(fieldIndex).Tag.Get("db")
 		if dbColumnName == "" || dbColumnName == "-" {
 			continue
 		}
 
 		columns = append(columns, dbColumnName)
+		fieldIndexes = append(fieldIndexes, fieldIndex)
+	}
 
-		if dbColumnName != repository.keyColumn && dbColumnName != "updated_at" {
-			updateAssignments = append(updateAssignments, fmt.Sprintf("%s = VALUES(%s)", dbColumnName, dbColumnName))
-		}
+	queryPrefix := fmt.Sprintf(
+		"INSERT INTO %s (%s) VALUES ",
+		repository.quotedTableName(),
+		strings.Join(quoteIdentifiers(repository.dialect, columns), ", "),
+	)
+	querySuffix := " " + repository.dialect.upsertClause(repository.conflictColumns, updateColumns)
+	rowPlaceholders := "(" + strings.TrimSuffix(strings.Repeat("?, ", len(columns)), ", ") + ")"
+
+	maximumPlaceholders := repository.maxPlaceholders
+	if maximumPlaceholders <= 0 {
+		maximumPlaceholders = repository.dialect.maxPlaceholders()
+	}
+	maximumStatementBytes := repository.maxStatementBytes
+	if maximumStatementBytes <= 0 {
+		maximumStatementBytes = defaultMaxStatementBytes
 	}
 
-	columnsString := strings.Join(columns, ", ")
-	updateClause := "updated_at = CURRENT_TIMESTAMP"
-	if len(updateAssignments) > 0 {
-		updateClause = strings.Join(updateAssignments, ", ") + ", " + updateClause
+	conflictPositions := make(map[string]int, len(repository.conflictColumns))
+	for position, conflictColumn := range repository.conflictColumns {
+		conflictPositions[conflictColumn] = position
 	}
 
-	valuePlaceholdersList := []string{}
-	argumentMaps := []map[string]interface{}{}
-	for _, singleEntity := range entitiesToUpsert {
+	now := time.Now()
+	var chunkArguments, chunkConflictArguments []interface{}
+	chunkRows := 0
+	chunkBytes := len(queryPrefix) + len(querySuffix)
+
+	flush := func() error {
+		if chunkRows == 0 {
+			return nil
+		}
+		query := repository.dialect.rebind(queryPrefix +
+			strings.TrimSuffix(strings.Repeat(rowPlaceholders+", ", chunkRows), ", ") +
+			querySuffix)
+		inserted, updated, err := execChunk(ctx, upsertStatement{
+			tableName:         repository.tableName,
+			query:             query,
+			arguments:         chunkArguments,
+			rowCount:          chunkRows,
+			conflictColumns:   repository.conflictColumns,
+			conflictArguments: chunkConflictArguments,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to upsert %d rows into %s: %w", chunkRows, repository.tableName, err)
+		}
+		batchResult.Inserted += inserted
+		batchResult.Updated += updated
+
+		chunkArguments = nil
+		chunkConflictArguments = nil
+		chunkRows = 0
+		chunkBytes = len(queryPrefix) + len(querySuffix)
+		return nil
+	}
+
+	for _, singleEntity := range repository.lastPerConflictKey(entitiesToUpsert) {
 		singleEntityValue := reflect.ValueOf(singleEntity)
-		singleEntityMap := map[string]interface{}{}
-		placeholders := []string{}
-
-		for fieldIndex := 0; fieldIndex < entityType.NumField(); fieldIndex++ {
-			field := entityType.Field(fieldIndex)
-			dbColumnName := field.Tag.Get("db")
-			if dbColumnName == "" || dbColumnName == "-" {
-				continue
+		rowArguments := make([]interface{}, 0, len(columns))
+		rowConflictArguments := make([]interface{}, len(repository.conflictColumns))
+		rowBytes := len(rowPlaceholders) + len(", ")
+
+		for columnIndex, fieldIndex := range fieldIndexes {
+			fieldValue := singleEntityValue.Field(fieldIndex)
+			columnName := columns[columnIndex]
+			if (columnName == "created_at" || columnName == "updated_at") && fieldValue.IsZero() {
+				rowArguments = append(rowArguments, now)
+			} else {
+				rowArguments = append(rowArguments, fieldValue.Interface())
+			}
+			if position, isConflictColumn := conflictPositions[columnName]; isConflictColumn {
+				rowConflictArguments[position] = rowArguments[len(rowArguments)-1]
 			}
+			rowBytes += estimatedArgumentBytes(fieldValue)
+		}
 
-			fieldValue := singleEntityValue.Field(fieldIndex).Interface()
-			singleEntityMap[dbColumnName] = fieldValue
-			placeholders = append(placeholders, ":"+dbColumnName)
+		if chunkRows > 0 &&
+			((chunkRows+1)*len(columns) > maximumPlaceholders || chunkBytes+rowBytes > maximumStatementBytes) {
+			if err := flush(); err != nil {
+				return batchResult, err
+			}
 		}
+		chunkArguments = append(chunkArguments, rowArguments...)
+		chunkConflictArguments = append(chunkConflictArguments, rowConflictArguments...)
+		chunkRows++
+		chunkBytes += rowBytes
+	}
 
-		argumentMaps = append(argumentMaps, singleEntityMap)
-		valuePlaceholdersList = append(valuePlaceholdersList, "("+strings.Join(placeholders, ", ")+")")
+	if err := flush(); err != nil {
+		return batchResult, err
 	}
+	return batchResult, nil
+}
 
-	query := fmt.Sprintf(`
-		INSERT INTO %s (%s) VALUES %s
-		ON DUPLICATE KEY UPDATE %s`,
-		repository.tableName,
-		columnsString,
-		strings.Join(valuePlaceholdersList, ", "),
-		updateClause,
-	)
+// lastPerConflictKey keeps the last of the entities sharing conflict column
+// values, at the position of the first. PostgreSQL rejects an upsert that
+// would update the same row twice, which paging through a shifting GitHub
+// listing can produce.
+func (repository *GenericRepository[T]) lastPerConflictKey(entitiesToUpsert []T) []T {
+	conflictFieldIndexes := make([][]int, 0, len(repository.conflictColumns))
+	for _, conflictColumn := range repository.conflictColumns {
+		field, ok := repository.columnField(conflictColumn)
+		if !ok {
+			return entitiesToUpsert
+		}
+		conflictFieldIndexes = append(conflictFieldIndexes, field.Index)
+	}
 
-	fmt.Printf("Entities being upserted")
+	positions := make(map[string]int, len(entitiesToUpsert))
+	distinctEntities := make([]T, 0, len(entitiesToUpsert))
+	for _, singleEntity := range entitiesToUpsert {
+		entityValue := reflect.ValueOf(singleEntity)
+		keyParts := make([]string, 0, len(conflictFieldIndexes))
+		for _, fieldIndex := range conflictFieldIndexes {
+			keyParts = append(keyParts, fmt.Sprintf("%q", fmt.Sprint(entityValue.FieldByIndex(fieldIndex).Interface())))
+		}
+		conflictKey := strings.Join(keyParts, ",")
 
-	for _, argumentMap := range argumentMaps {
-		if _, err := repository.database.NamedExecContext(ctx, query, argumentMap); err != nil {
-			return fmt.Errorf("failed to upsert entity: %w", err)
+		if position, seen := positions[conflictKey]; seen {
+			distinctEntities[position] = singleEntity
+			continue
 		}
+		positions[conflictKey] = len(distinctEntities)
+		distinctEntities = append(distinctEntities, singleEntity)
+	}
+	return distinctEntities
+}
+
+// execChunk runs one multi-row upsert in a transaction.
+func (repository *GenericRepository[T]) execChunk(
+	ctx context.Context,
+	statement upsertStatement,
+) (int64, int64, error) {
+	transaction, err := repository.database.BeginTxx(ctx, nil)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	inserted, updated, err := repository.dialect.execUpsert(ctx, transaction, statement)
+	if err != nil {
+		_ = transaction.Rollback()
+		return 0, 0, err
+	}
+	if err := transaction.Commit(); err != nil {
+		return 0, 0, err
 	}
+	return inserted, updated, nil
+}
 
-	return nil
+// estimatedArgumentBytes approximates how many bytes a bound value adds to a
+// statement sent over the wire.
+func estimatedArgumentBytes(fieldValue reflect.Value) int {
+	for fieldValue.Kind() == reflect.Pointer {
+		if fieldValue.IsNil() {
+			return 1
+		}
+		fieldValue = fieldValue.Elem()
+	}
+	switch fieldValue.Kind() {
+	case reflect.String, reflect.Slice:
+		return fieldValue.Len() + 9
+	default:
+		return 16
+	}
 }
 
 func (repository *GenericRepository[T]) GetByField(ctx context.Context, fieldName, fieldValue string) (*T, error) {
 	var entity T
-	query := fmt.Sprintf(
+	query := repository.dialect.rebind(fmt.Sprintf(
 		"SELECT %s FROM %s WHERE %s = ? LIMIT 1",
-		strings.Join(repository.columnList, ", "),
-		repository.tableName,
-		fieldName,
-	)
+		repository.quotedColumns(),
+		repository.quotedTableName(),
+		repository.dialect.quoteIdentifier(fieldName),
+	))
 
 	if err := repository.database.GetContext(ctx, &entity, query, fieldValue); err != nil {
 		return nil, err
 	}
 
-	fmt.Printf("Query: %+v\n", query)
-
 	return &entity, nil
 }
 
+// List returns one page of rows matching all filters.
 func (repository *GenericRepository[T]) List(
 	ctx context.Context,
 	limit int,
 	page int,
 	orderBy string,
 	orderDirection string,
+	filters []interfaces.Filter,
 ) ([]T, error) {
 	var results []T
 
-	sortColumn := orderBy
-	if sortColumn == "" || !repository.isValidColumn(orderBy) {
-		sortColumn = repository.keyColumn
+	filterClause, queryArguments, err := whereClause(repository.dialect, filters, repository.columnList, "")
+	if err != nil {
+		return nil, err
 	}
-
-	sortDirection := strings.ToUpper(orderDirection)
-	if sortDirection != "DESC" {
-		sortDirection = "ASC"
+	if filterClause != "" {
+		filterClause = " " + filterClause
 	}
 
+	sortColumn, sortDirection := repository.sortOrder(orderBy, orderDirection)
+
 	if limit <= 0 {
 		limit = 10
 	}
@@ -253,29 +447,174 @@ func (repository *GenericRepository[T]) List(
 	}
 	offset := (page - 1) * limit
 
-	query := fmt.Sprintf(
-		"SELECT %s FROM %s ORDER BY %s %s LIMIT ? OFFSET ?",
-		strings.Join(repository.columnList, ", "),
-		repository.tableName,
-		sortColumn,
+	query := repository.dialect.rebind(fmt.Sprintf(
+		"SELECT %s FROM %s%s ORDER BY %s %s LIMIT ? OFFSET ?",
+		repository.quotedColumns(),
+		repository.quotedTableName(),
+		filterClause,
+		repository.dialect.quoteIdentifier(sortColumn),
 		sortDirection,
-	)
+	))
+	queryArguments = append(queryArguments, limit, offset)
 
-	if err := repository.database.SelectContext(ctx, &results, query, limit, offset); err != nil {
+	if err := repository.database.SelectContext(ctx, &results, query, queryArguments...); err != nil {
 		return nil, err
 	}
 
-	fmt.Printf("Query: %+v\n", query)
-
 	return results, nil
 }
 
-func (repository *GenericRepository[T]) DeleteByField(ctx context.Context, fieldName, fieldValue string) error {
-	query := fmt.Sprintf("DELETE FROM %s WHERE %s = ?", repository.tableName, fieldName)
-	_, err := repository.database.ExecContext(ctx, query, fieldValue)
+// Count returns how many rows match all filters.
+func (repository *GenericRepository[T]) Count(ctx context.Context, filters []interfaces.Filter) (int64, error) {
+	filterClause, queryArguments, err := whereClause(repository.dialect, filters, repository.columnList, "")
+	if err != nil {
+		return 0, err
+	}
+	if filterClause != "" {
+		filterClause = " " + filterClause
+	}
+
+	query := repository.dialect.rebind(fmt.Sprintf(
+		"SELECT COUNT(*) FROM %s%s",
+		repository.quotedTableName(),
+		filterClause,
+	))
+
+	var count int64
+	if err := repository.database.GetContext(ctx, &count, query, queryArguments...); err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+// ListPage returns up to limit rows matching filters, ordered by orderBy with
+// the key column breaking ties, and a cursor for the rows after them. The
+// cursor is empty on the last page. A non-empty cursor continues a previous
+// scan by keyset, which stays fast on deep pages and neither skips nor
+// repeats rows written meanwhile; page is then ignored. Without a cursor page
+// selects rows by offset. Keyset paging needs a sort column that cannot be
+// NULL, so pages sorted by nullable columns come without cursors.
+func (repository *GenericRepository[T]) ListPage(
+	ctx context.Context,
+	limit int,
+	page int,
+	orderBy string,
+	orderDirection string,
+	filters []interfaces.Filter,
+	cursor string,
+) ([]T, string, error) {
+	sortColumn, sortDirection := repository.sortOrder(orderBy, orderDirection)
+	sortField, _ := repository.columnField(sortColumn)
+	keyField, _ := repository.columnField(repository.keyColumn)
+	keysetPaging := sortField.Type.Kind() != reflect.Pointer
+
+	if limit <= 0 {
+		limit = 10
+	}
+	if page <= 0 {
+		page = 1
+	}
+
+	conditions, queryArguments, err := whereClause(repository.dialect, filters, repository.columnList, "")
+	if err != nil {
+		return nil, "", err
+	}
+
+	offset := (page - 1) * limit
+	if cursor != "" {
+		if !keysetPaging {
+			return nil, "", derr.New(derr.ErrorCodeValidation, fmt.Sprintf("cannot page by cursor over %s", sortColumn))
+		}
+		position, err := decodeListCursor(cursor)
+		if err != nil {
+			return nil, "", err
+		}
+		if position.SortColumn != sortColumn || position.SortDirection != sortDirection {
+			return nil, "", derr.New(derr.ErrorCodeValidation, "cursor does not match the requested order")
+		}
+		keyValue, err := decodeCursorValue(position.KeyValue, keyField.Type)
+		if err != nil {
+			return nil, "", err
+		}
+
+		comparison := ">"
+		if sortDirection == "DESC" {
+			comparison = "<"
+		}
+		quotedKeyColumn := repository.dialect.quoteIdentifier(repository.keyColumn)
+		keysetCondition := fmt.Sprintf("%s %s ?", quotedKeyColumn, comparison)
+		keysetArguments := []interface{}{keyValue}
+		if sortColumn != repository.keyColumn {
+			sortValue, err := decodeCursorValue(position.SortValue, sortField.Type)
+			if err != nil {
+				return nil, "", err
+			}
+			quotedSortColumn := repository.dialect.quoteIdentifier(sortColumn)
+			keysetCondition = fmt.Sprintf(
+				"(%s %s ? OR (%s = ? AND %s))",
+				quotedSortColumn, comparison, quotedSortColumn, keysetCondition,
+			)
+			keysetArguments = []interface{}{sortValue, sortValue, keyValue}
+		}
+
+		if conditions == "" {
+			conditions = "WHERE " + keysetCondition
+		} else {
+			conditions += " AND " + keysetCondition
+		}
+		queryArguments = append(queryArguments, keysetArguments...)
+		offset = 0
+	}
+	if conditions != "" {
+		conditions = " " + conditions
+	}
 
-	fmt.Printf("Query: %+v\n", query)
+	orderClause := fmt.Sprintf("%s %s", repository.dialect.quoteIdentifier(sortColumn), sortDirection)
+	if sortColumn != repository.keyColumn {
+		orderClause += fmt.Sprintf(", %s %s", repository.dialect.quoteIdentifier(repository.keyColumn), sortDirection)
+	}
+	query := repository.dialect.rebind(fmt.Sprintf(
+		"SELECT %s FROM %s%s ORDER BY %s LIMIT ? OFFSET ?",
+		repository.quotedColumns(),
+		repository.quotedTableName(),
+		conditions,
+		orderClause,
+	))
+	// One row more than asked tells whether another page follows.
+	queryArguments = append(queryArguments, limit+1, offset)
+
+	var results []T
+	if err := repository.database.SelectContext(ctx, &results, query, queryArguments...); err != nil {
+		return nil, "", err
+	}
+	if len(results) <= limit {
+		return results, "", nil
+	}
 
+	results = results[:limit]
+	if !keysetPaging {
+		return results, "", nil
+	}
+	lastRow := reflect.ValueOf(results[limit-1])
+	nextCursor, err := encodeListCursor(
+		sortColumn,
+		sortDirection,
+		lastRow.FieldByIndex(sortField.Index).Interface(),
+		lastRow.FieldByIndex(keyField.Index).Interface(),
+	)
+	if err != nil {
+		return nil, "", err
+	}
+	return results, nextCursor, nil
+}
+
+func (repository *GenericRepository[T]) DeleteByField(ctx context.Context, fieldName, fieldValue string) error {
+	query := repository.dialect.rebind(fmt.Sprintf(
+		"DELETE FROM %s WHERE %s = ?",
+		repository.quotedTableName(),
+		repository.dialect.quoteIdentifier(fieldName),
+	))
+	_, err := repository.database.ExecContext(ctx, query, fieldValue)
 	return err
 }
 
@@ -287,6 +626,45 @@ func (repository *GenericRepository[T]) DeleteByID(ctx context.Context, identifi
 	return repository.DeleteByField(ctx, repository.keyColumn, fmt.Sprintf("%v", identifier))
 }
 
+// sortOrder falls back to the key column for unknown columns and to
+// ascending order for anything but DESC.
+func (repository *GenericRepository[T]) sortOrder(orderBy, orderDirection string) (string, string) {
+	sortColumn := orderBy
+	if sortColumn == "" || !repository.isValidColumn(orderBy) {
+		sortColumn = repository.keyColumn
+	}
+
+	sortDirection := strings.ToUpper(orderDirection)
+	if sortDirection != "DESC" {
+		sortDirection = "ASC"
+	}
+	return sortColumn, sortDirection
+}
+
+// columnField finds the field of T stored in columnName.
+func (repository *GenericRepository[T]) columnField(columnName string) (reflect.StructField, bool) {
+	var zeroValue T
+	entityType := reflect.TypeOf(zeroValue)
+	for index := 0; index < entityType.NumField(); index++ {
+		if field := entityType.Field(index); field.Tag.Get("db") == columnName {
+			return field, true
+		}
+	}
+	return reflect.StructField{}, false
+}
+
+func (repository *GenericRepository[T]) quotedTableName() string {
+	return repository.dialect.quoteIdentifier(repository.tableName)
+}
+
+func (repository *GenericRepository[T]) quotedColumns() string {
+	return strings.Join(quoteIdentifiers(repository.dialect, repository.columnList), ", ")
+}
+
+func (repository *GenericRepository[T]) isConflictColumn(columnName string) bool {
+	return slices.Contains(repository.conflictColumns, columnName)
+}
+
 func (repository *GenericRepository[T]) isValidColumn(columnName string) bool {
 	for _, column := range repository.columnList {
 		if column == columnName {
@@ -0,0 +1,275 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	sqlmock "github.com/DATA-DOG/go-sqlmock"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/require"
+
+	"github.com/unkabogaton/github-users/internal/domain/entities"
+	derr "github.com/unkabogaton/github-users/internal/domain/errors"
+	"github.com/unkabogaton/github-users/internal/domain/interfaces"
+)
+
+const followUpsertPrefix = "INSERT INTO user_follows (follower_id, following_id, updated_at, created_at) VALUES "
+
+// expectExistingKeys expects the lookup of stored keys a MySQL upsert runs
+// before its statement, and answers that existing rows were found.
+func expectExistingKeys(mock sqlmock.Sqlmock, tableName string, existing int) {
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT COUNT(*) FROM " + tableName + " WHERE ")).
+		WillReturnRows(sqlmock.NewRows([]string{"COUNT(*)"}).AddRow(existing))
+}
+
+func TestGenericRepository_BatchUpsert_SingleStatement(t *testing.T) {
+	t.Parallel()
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	repository := NewGenericRepository[entities.UserFollow](sqlx.NewDb(db, "mysql"), "user_follows", "follower_id", "follower_id", "following_id")
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta(
+		"SELECT COUNT(*) FROM user_follows WHERE (follower_id, following_id) IN ((?, ?), (?, ?), (?, ?))",
+	)).
+		WithArgs(1, 10, 2, 10, 3, 10).
+		WillReturnRows(sqlmock.NewRows([]string{"COUNT(*)"}).AddRow(2))
+	mock.ExpectExec(regexp.QuoteMeta(
+		followUpsertPrefix+"(?, ?, ?, ?), (?, ?, ?, ?), (?, ?, ?, ?) "+
+			"ON DUPLICATE KEY UPDATE updated_at = CURRENT_TIMESTAMP",
+	)).
+		WithArgs(
+			1, 10, sqlmock.AnyArg(), sqlmock.AnyArg(),
+			2, 10, sqlmock.AnyArg(), sqlmock.AnyArg(),
+			3, 10, sqlmock.AnyArg(), sqlmock.AnyArg(),
+		).
+		// The two existing rows were left as they were, so MySQL reports
+		// only the inserted row as affected.
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	result, err := repository.BatchUpsert(context.Background(), []entities.UserFollow{
+		{FollowerID: 1, FollowingID: 10},
+		{FollowerID: 2, FollowingID: 10},
+		{FollowerID: 3, FollowingID: 10},
+	})
+	require.NoError(t, err)
+	require.Equal(t, interfaces.BatchUpsertResult{Inserted: 1, Updated: 2}, result)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGenericRepository_BatchUpsert_KeepsLastRowPerConflictKey(t *testing.T) {
+	t.Parallel()
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	repository := NewGenericRepository[entities.UserFollow](sqlx.NewDb(db, "postgres"), "user_follows", "follower_id", "follower_id", "following_id")
+	followedAt := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	// PostgreSQL fails the whole statement if it names one row twice.
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta(
+		"INSERT INTO user_follows (follower_id, following_id, updated_at, created_at) VALUES ($1, $2, $3, $4), ($5, $6, $7, $8) "+
+			"ON CONFLICT (follower_id, following_id) DO UPDATE SET updated_at = CURRENT_TIMESTAMP RETURNING (xmax = 0)",
+	)).
+		WithArgs(
+			1, 10, sqlmock.AnyArg(), followedAt,
+			2, 10, sqlmock.AnyArg(), sqlmock.AnyArg(),
+		).
+		WillReturnRows(sqlmock.NewRows([]string{"inserted"}).AddRow(true).AddRow(true))
+	mock.ExpectCommit()
+
+	result, err := repository.BatchUpsert(context.Background(), []entities.UserFollow{
+		{FollowerID: 1, FollowingID: 10},
+		{FollowerID: 2, FollowingID: 10},
+		{FollowerID: 1, FollowingID: 10, CreatedAt: followedAt},
+	})
+	require.NoError(t, err)
+	require.Equal(t, interfaces.BatchUpsertResult{Inserted: 2}, result)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGenericRepository_BatchUpsert_SplitsChunksAtPlaceholderLimit(t *testing.T) {
+	t.Parallel()
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	repository := NewGenericRepository[entities.UserFollow](sqlx.NewDb(db, "mysql"), "user_follows", "follower_id", "follower_id", "following_id")
+	repository.maxPlaceholders = 8
+
+	for _, rowCount := range []int{2, 2, 1} {
+		mock.ExpectBegin()
+		expectExistingKeys(mock, "user_follows", 0)
+		mock.ExpectExec(regexp.QuoteMeta(followUpsertPrefix)).
+			WillReturnResult(sqlmock.NewResult(0, int64(rowCount)))
+		mock.ExpectCommit()
+	}
+
+	follows := make([]entities.UserFollow, 5)
+	for index := range follows {
+		follows[index] = entities.UserFollow{FollowerID: index + 1, FollowingID: 10}
+	}
+	result, err := repository.BatchUpsert(context.Background(), follows)
+	require.NoError(t, err)
+	require.Equal(t, interfaces.BatchUpsertResult{Inserted: 5}, result)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGenericRepository_BatchUpsert_SplitsChunksAtStatementSize(t *testing.T) {
+	t.Parallel()
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	repository := NewGenericRepository[entities.User](sqlx.NewDb(db, "mysql"), "github_users", "id")
+	repository.maxStatementBytes = 1500
+
+	for range 2 {
+		mock.ExpectBegin()
+		expectExistingKeys(mock, "github_users", 0)
+		mock.ExpectExec(regexp.QuoteMeta("INSERT INTO github_users")).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+	}
+
+	longBio := string(make([]byte, 600))
+	result, err := repository.BatchUpsert(context.Background(), []entities.User{
+		{ID: 1, Login: "first", Bio: longBio},
+		{ID: 2, Login: "second", Bio: longBio},
+	})
+	require.NoError(t, err)
+	require.Equal(t, interfaces.BatchUpsertResult{Inserted: 2}, result)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGenericRepository_BatchUpsert_RollsBackFailedChunk(t *testing.T) {
+	t.Parallel()
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	repository := NewGenericRepository[entities.UserFollow](sqlx.NewDb(db, "mysql"), "user_follows", "follower_id", "follower_id", "following_id")
+	repository.maxPlaceholders = 4
+
+	mock.ExpectBegin()
+	expectExistingKeys(mock, "user_follows", 1)
+	mock.ExpectExec(regexp.QuoteMeta(followUpsertPrefix)).
+		WillReturnResult(sqlmock.NewResult(0, 2))
+	mock.ExpectCommit()
+	mock.ExpectBegin()
+	expectExistingKeys(mock, "user_follows", 0)
+	mock.ExpectExec(regexp.QuoteMeta(followUpsertPrefix)).
+		WillReturnError(errors.New("deadlock found"))
+	mock.ExpectRollback()
+
+	result, err := repository.BatchUpsert(context.Background(), []entities.UserFollow{
+		{FollowerID: 1, FollowingID: 10},
+		{FollowerID: 2, FollowingID: 10},
+		{FollowerID: 3, FollowingID: 10},
+	})
+	require.ErrorContains(t, err, "deadlock found")
+	require.Equal(t, interfaces.BatchUpsertResult{Updated: 1}, result)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGenericRepository_List_WithFilters(t *testing.T) {
+	t.Parallel()
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	repository := NewGenericRepository[entities.User](sqlx.NewDb(db, "mysql"), "github_users", "id")
+	updatedSince := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	mock.ExpectQuery(regexp.QuoteMeta(
+		"FROM github_users WHERE site_admin = ? AND login LIKE ? ESCAPE '!' AND updated_at >= ? ORDER BY id ASC LIMIT ? OFFSET ?",
+	)).
+		WithArgs(true, "a!_b%", updatedSince, 10, 0).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "login"}))
+
+	_, err = repository.List(context.Background(), 10, 1, "", "", []interfaces.Filter{
+		{Column: "site_admin", Operator: interfaces.FilterEqual, Value: true},
+		{Column: "login", Operator: interfaces.FilterPrefix, Value: "a_b"},
+		{Column: "updated_at", Operator: interfaces.FilterGreaterOrEqual, Value: updatedSince},
+	})
+	require.NoError(t, err)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGenericRepository_List_RejectsUnknownFilters(t *testing.T) {
+	t.Parallel()
+	db, _, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	repository := NewGenericRepository[entities.User](sqlx.NewDb(db, "mysql"), "github_users", "id")
+
+	_, err = repository.List(context.Background(), 10, 1, "", "", []interfaces.Filter{
+		{Column: "password; DROP TABLE github_users", Operator: interfaces.FilterEqual, Value: "x"},
+	})
+	require.True(t, derr.IsCode(err, derr.ErrorCodeValidation))
+
+	_, err = repository.List(context.Background(), 10, 1, "", "", []interfaces.Filter{
+		{Column: "login", Operator: "like", Value: "x"},
+	})
+	require.True(t, derr.IsCode(err, derr.ErrorCodeValidation))
+}
+
+func TestGenericRepository_ListPage_KeysetQuery(t *testing.T) {
+	t.Parallel()
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	repository := NewGenericRepository[entities.User](sqlx.NewDb(db, "mysql"), "github_users", "id")
+	cursor, err := encodeListCursor("followers", "DESC", 12, 40)
+	require.NoError(t, err)
+
+	mock.ExpectQuery(regexp.QuoteMeta(
+		"FROM github_users WHERE type = ? AND (followers < ? OR (followers = ? AND id < ?)) ORDER BY followers DESC, id DESC LIMIT ? OFFSET ?",
+	)).
+		WithArgs("User", 12, 12, 40, 3, 0).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "followers"}).
+			AddRow(41, 12).
+			AddRow(7, 11).
+			AddRow(9, 10))
+
+	users, nextCursor, err := repository.ListPage(context.Background(), 2, 5, "followers", "desc", []interfaces.Filter{
+		{Column: "type", Operator: interfaces.FilterEqual, Value: "User"},
+	}, cursor)
+	require.NoError(t, err)
+	require.Len(t, users, 2)
+
+	position, err := decodeListCursor(nextCursor)
+	require.NoError(t, err)
+	require.JSONEq(t, "11", string(position.SortValue))
+	require.JSONEq(t, "7", string(position.KeyValue))
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGenericRepository_Count(t *testing.T) {
+	t.Parallel()
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	repository := NewGenericRepository[entities.User](sqlx.NewDb(db, "mysql"), "github_users", "id")
+
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT COUNT(*) FROM github_users WHERE site_admin = ?")).
+		WithArgs(true).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(42))
+
+	count, err := repository.Count(context.Background(), []interfaces.Filter{
+		{Column: "site_admin", Operator: interfaces.FilterEqual, Value: true},
+	})
+	require.NoError(t, err)
+	require.EqualValues(t, 42, count)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
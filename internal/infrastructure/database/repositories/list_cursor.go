@@ -0,0 +1,64 @@
+package repositories
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"reflect"
+
+	derr "github.com/unkabogaton/github-users/internal/domain/errors"
+)
+
+// listCursor is the position after the last row of a keyset page: the sort
+// column and direction the page was read with, and the sort and key values of
+// its last row. Tokens are base64 JSON; they are opaque to clients but not
+// secret, and every value in them is bound as a query parameter.
+type listCursor struct {
+	SortColumn    string          `json:"o"`
+	SortDirection string          `json:"d"`
+	SortValue     json.RawMessage `json:"v"`
+	KeyValue      json.RawMessage `json:"k"`
+}
+
+func encodeListCursor(sortColumn, sortDirection string, sortValue, keyValue interface{}) (string, error) {
+	encodedSortValue, err := json.Marshal(sortValue)
+	if err != nil {
+		return "", err
+	}
+	encodedKeyValue, err := json.Marshal(keyValue)
+	if err != nil {
+		return "", err
+	}
+
+	payload, err := json.Marshal(listCursor{
+		SortColumn:    sortColumn,
+		SortDirection: sortDirection,
+		SortValue:     encodedSortValue,
+		KeyValue:      encodedKeyValue,
+	})
+	if err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(payload), nil
+}
+
+func decodeListCursor(token string) (listCursor, error) {
+	var cursor listCursor
+	payload, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return cursor, derr.Wrap(derr.ErrorCodeValidation, "invalid cursor", err)
+	}
+	if err := json.Unmarshal(payload, &cursor); err != nil {
+		return cursor, derr.Wrap(derr.ErrorCodeValidation, "invalid cursor", err)
+	}
+	return cursor, nil
+}
+
+// decodeCursorValue reads a value of the cursor back into the Go type of the
+// column, so that it binds exactly like the column does.
+func decodeCursorValue(encodedValue json.RawMessage, valueType reflect.Type) (interface{}, error) {
+	value := reflect.New(valueType)
+	if err := json.Unmarshal(encodedValue, value.Interface()); err != nil {
+		return nil, derr.Wrap(derr.ErrorCodeValidation, "invalid cursor", err)
+	}
+	return value.Elem().Interface(), nil
+}
@@ -0,0 +1,108 @@
+package repositories
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	derr "github.com/unkabogaton/github-users/internal/domain/errors"
+	"github.com/unkabogaton/github-users/internal/domain/interfaces"
+)
+
+var filterComparisons = map[interfaces.FilterOperator]string{
+	interfaces.FilterEqual:          "=",
+	interfaces.FilterNotEqual:       "<>",
+	interfaces.FilterGreaterThan:    ">",
+	interfaces.FilterGreaterOrEqual: ">=",
+	interfaces.FilterLessThan:       "<",
+	interfaces.FilterLessOrEqual:    "<=",
+}
+
+// likeEscaper escapes the LIKE wildcards in a prefix. '!' is used as the escape
+// character because backslashes are themselves escapes in MySQL literals.
+var likeEscaper = strings.NewReplacer("!", "!!", "%", "!%", "_", "!_")
+
+// whereClause turns filters into a WHERE clause on tableAlias with ?
+// placeholders and the matching arguments. Filters on columns outside
+// columnList or with unknown operators are rejected rather than ignored, so
+// that a typo cannot widen a listing.
+func whereClause(
+	sqlDialect dialect,
+	filters []interfaces.Filter,
+	columnList []string,
+	tableAlias string,
+) (string, []interface{}, error) {
+	if len(filters) == 0 {
+		return "", nil, nil
+	}
+
+	conditions := make([]string, 0, len(filters))
+	arguments := make([]interface{}, 0, len(filters))
+	for _, filter := range filters {
+		if !slices.Contains(columnList, filter.Column) {
+			return "", nil, derr.New(derr.ErrorCodeValidation, fmt.Sprintf("cannot filter on %q", filter.Column))
+		}
+		column := sqlDialect.quoteIdentifier(filter.Column)
+		if tableAlias != "" {
+			column = sqlDialect.quoteIdentifier(tableAlias) + "." + column
+		}
+
+		if filter.Operator == interfaces.FilterPrefix {
+			prefix, ok := filter.Value.(string)
+			if !ok {
+				return "", nil, derr.New(derr.ErrorCodeValidation, fmt.Sprintf("prefix filter on %q needs a string", filter.Column))
+			}
+			conditions = append(conditions, column+" LIKE ? ESCAPE '!'")
+			arguments = append(arguments, likeEscaper.Replace(prefix)+"%")
+			continue
+		}
+
+		comparison, ok := filterComparisons[filter.Operator]
+		if !ok {
+			return "", nil, derr.New(derr.ErrorCodeValidation, fmt.Sprintf("unknown filter operator %q", filter.Operator))
+		}
+		conditions = append(conditions, fmt.Sprintf("%s %s ?", column, comparison))
+		arguments = append(arguments, filter.Value)
+	}
+
+	return "WHERE " + strings.Join(conditions, " AND "), arguments, nil
+}
+
+// orderAndPage turns list options into an ORDER BY clause on tableAlias and
+// the LIMIT/OFFSET arguments, falling back to the id column and the first page
+// of ten for anything missing or not in columnList.
+func orderAndPage(sqlDialect dialect, listOptions interfaces.ListOptions, columnList []string, tableAlias string) (string, int, int) {
+	sortColumn := listOptions.OrderBy
+	if !slices.Contains(columnList, sortColumn) {
+		sortColumn = "id"
+	}
+
+	sortDirection := strings.ToUpper(listOptions.OrderDirection)
+	if sortDirection != "DESC" {
+		sortDirection = "ASC"
+	}
+
+	limit := listOptions.Limit
+	if limit <= 0 {
+		limit = 10
+	}
+	page := listOptions.Page
+	if page <= 0 {
+		page = 1
+	}
+
+	return fmt.Sprintf(
+		"ORDER BY %s.%s %s",
+		sqlDialect.quoteIdentifier(tableAlias),
+		sqlDialect.quoteIdentifier(sortColumn),
+		sortDirection,
+	), limit, (page - 1) * limit
+}
+
+func qualifiedColumns(sqlDialect dialect, columnList []string, tableAlias string) string {
+	qualified := make([]string, 0, len(columnList))
+	for _, column := range columnList {
+		qualified = append(qualified, sqlDialect.quoteIdentifier(tableAlias)+"."+sqlDialect.quoteIdentifier(column))
+	}
+	return strings.Join(qualified, ", ")
+}
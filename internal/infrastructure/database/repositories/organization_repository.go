@@ -0,0 +1,162 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/unkabogaton/github-users/internal/domain/entities"
+	"github.com/unkabogaton/github-users/internal/domain/interfaces"
+)
+
+type OrganizationRepository struct {
+	*GenericRepository[entities.Organization]
+	membershipRepository *GenericRepository[entities.UserOrganization]
+	userColumnList       []string
+}
+
+func NewOrganizationRepository(database *sqlx.DB) interfaces.OrganizationRepository {
+	return &OrganizationRepository{
+		GenericRepository:    NewGenericRepository[entities.Organization](database, "organizations", "id"),
+		membershipRepository: NewGenericRepository[entities.UserOrganization](database, "user_organizations", "user_id", "user_id", "organization_id"),
+		userColumnList:       extractColumnNames(entities.User{}),
+	}
+}
+
+func (organizationRepository *OrganizationRepository) Upsert(
+	ctx context.Context,
+	organization *entities.Organization,
+) error {
+	return organizationRepository.GenericRepository.Upsert(ctx, *organization)
+}
+
+// organizationSummaryColumns are the columns the summaries returned by
+// /users/{username}/orgs carry; they have no name or html_url.
+var organizationSummaryColumns = []string{"login", "node_id", "url", "avatar_url", "description"}
+
+func (organizationRepository *OrganizationRepository) BatchUpsert(
+	ctx context.Context,
+	organizations *[]entities.Organization,
+) error {
+	_, err := organizationRepository.GenericRepository.BatchUpsert(ctx, withOrganizationTimestamps(*organizations))
+	return err
+}
+
+// BatchUpsertSummaries stores organization summaries. Organizations already
+// stored in full keep their name and html_url.
+func (organizationRepository *OrganizationRepository) BatchUpsertSummaries(
+	ctx context.Context,
+	organizations *[]entities.Organization,
+) error {
+	_, err := organizationRepository.GenericRepository.BatchUpsertColumns(
+		ctx,
+		withOrganizationTimestamps(*organizations),
+		organizationSummaryColumns,
+	)
+	return err
+}
+
+func withOrganizationTimestamps(organizations []entities.Organization) []entities.Organization {
+	now := time.Now()
+	for index := range organizations {
+		if organizations[index].CreatedAt.IsZero() {
+			organizations[index].CreatedAt = now
+		}
+		if organizations[index].UpdatedAt.IsZero() {
+			organizations[index].UpdatedAt = now
+		}
+	}
+	return organizations
+}
+
+func (organizationRepository *OrganizationRepository) GetByLogin(
+	ctx context.Context,
+	login string,
+) (*entities.Organization, error) {
+	return organizationRepository.GetByField(ctx, "login", login)
+}
+
+// ReplaceMembers swaps the stored members of organizationID for memberships.
+func (organizationRepository *OrganizationRepository) ReplaceMembers(
+	ctx context.Context,
+	organizationID int,
+	memberships []entities.UserOrganization,
+) error {
+	return organizationRepository.replaceMemberships(ctx, "organization_id", organizationID, memberships)
+}
+
+// ReplaceUserOrganizations swaps the stored organizations of userID for
+// memberships.
+func (organizationRepository *OrganizationRepository) ReplaceUserOrganizations(
+	ctx context.Context,
+	userID int,
+	memberships []entities.UserOrganization,
+) error {
+	return organizationRepository.replaceMemberships(ctx, "user_id", userID, memberships)
+}
+
+// replaceMemberships swaps the memberships whose column is identifier for
+// memberships in one transaction.
+func (organizationRepository *OrganizationRepository) replaceMemberships(
+	ctx context.Context,
+	column string,
+	identifier int,
+	memberships []entities.UserOrganization,
+) error {
+	// The upsert writes every column, so leave no zero timestamps behind.
+	now := time.Now()
+	for index := range memberships {
+		if memberships[index].CreatedAt.IsZero() {
+			memberships[index].CreatedAt = now
+		}
+		if memberships[index].UpdatedAt.IsZero() {
+			memberships[index].UpdatedAt = now
+		}
+	}
+	return organizationRepository.membershipRepository.ReplaceByField(ctx, column, fmt.Sprint(identifier), memberships)
+}
+
+// ListMembers returns the stored users that belong to organizationLogin.
+func (organizationRepository *OrganizationRepository) ListMembers(
+	ctx context.Context,
+	organizationLogin string,
+	listOptions interfaces.ListOptions,
+) ([]entities.User, error) {
+	var results []entities.User
+
+	orderClause, limit, offset := orderAndPage(organizationRepository.dialect, listOptions, organizationRepository.userColumnList, "u")
+	query := organizationRepository.dialect.rebind(fmt.Sprintf(
+		"SELECT %s FROM user_organizations m JOIN github_users u ON u.id = m.user_id JOIN organizations o ON o.id = m.organization_id WHERE o.login = ? %s LIMIT ? OFFSET ?",
+		qualifiedColumns(organizationRepository.dialect, organizationRepository.userColumnList, "u"),
+		orderClause,
+	))
+
+	if err := organizationRepository.database.SelectContext(ctx, &results, query, organizationLogin, limit, offset); err != nil {
+		return nil, err
+	}
+
+	return results, nil
+}
+
+// ListForUser returns the stored organizations login belongs to.
+func (organizationRepository *OrganizationRepository) ListForUser(
+	ctx context.Context,
+	login string,
+	listOptions interfaces.ListOptions,
+) ([]entities.Organization, error) {
+	var results []entities.Organization
+
+	orderClause, limit, offset := orderAndPage(organizationRepository.dialect, listOptions, organizationRepository.columnList, "o")
+	query := organizationRepository.dialect.rebind(fmt.Sprintf(
+		"SELECT %s FROM user_organizations m JOIN organizations o ON o.id = m.organization_id JOIN github_users u ON u.id = m.user_id WHERE u.login = ? %s LIMIT ? OFFSET ?",
+		qualifiedColumns(organizationRepository.dialect, organizationRepository.columnList, "o"),
+		orderClause,
+	))
+
+	if err := organizationRepository.database.SelectContext(ctx, &results, query, login, limit, offset); err != nil {
+		return nil, err
+	}
+
+	return results, nil
+}
@@ -0,0 +1,86 @@
+package repositories
+
+import (
+	"context"
+	"regexp"
+	"testing"
+	"time"
+
+	sqlmock "github.com/DATA-DOG/go-sqlmock"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/require"
+
+	"github.com/unkabogaton/github-users/internal/domain/entities"
+	"github.com/unkabogaton/github-users/internal/domain/interfaces"
+)
+
+func TestOrganizationRepository_ReplaceMembers(t *testing.T) {
+	t.Parallel()
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, "mysql")
+	repository := NewOrganizationRepository(sqlxDB)
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta("DELETE FROM user_organizations WHERE organization_id = ?")).
+		WithArgs("9919").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	expectExistingKeys(mock, "user_organizations", 0)
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO user_organizations (user_id, organization_id, updated_at, created_at)")).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
+
+	err = repository.ReplaceMembers(context.Background(), 9919, []entities.UserOrganization{{UserID: 1, OrganizationID: 9919}})
+	require.NoError(t, err)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestOrganizationRepository_ReplaceUserOrganizationsRollsBackFailedInsert(t *testing.T) {
+	t.Parallel()
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	repository := NewOrganizationRepository(sqlx.NewDb(db, "mysql"))
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta("DELETE FROM user_organizations WHERE user_id = ?")).
+		WithArgs("1").
+		WillReturnResult(sqlmock.NewResult(0, 2))
+	expectExistingKeys(mock, "user_organizations", 0)
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO user_organizations")).
+		WillReturnError(context.Canceled)
+	mock.ExpectRollback()
+
+	err = repository.ReplaceUserOrganizations(context.Background(), 1, []entities.UserOrganization{{UserID: 1, OrganizationID: 9919}})
+	require.ErrorIs(t, err, context.Canceled)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestOrganizationRepository_ListForUser(t *testing.T) {
+	t.Parallel()
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, "mysql")
+	repository := NewOrganizationRepository(sqlxDB)
+
+	rows := sqlmock.NewRows([]string{
+		"id", "login", "node_id", "url", "html_url", "avatar_url", "name", "description", "updated_at", "created_at",
+	}).AddRow(9919, "github", "O1", "http//", "http//", "http//", "GitHub", "", time.Now(), time.Now())
+
+	mock.ExpectQuery(regexp.QuoteMeta(
+		"SELECT o.id, o.login, o.node_id, o.url, o.html_url, o.avatar_url, o.name, o.description, o.updated_at, o.created_at FROM user_organizations m JOIN organizations o ON o.id = m.organization_id JOIN github_users u ON u.id = m.user_id WHERE u.login = ? ORDER BY o.login ASC LIMIT ? OFFSET ?",
+	)).
+		WithArgs("octocat", 10, 0).
+		WillReturnRows(rows)
+
+	organizations, err := repository.ListForUser(context.Background(), "octocat", interfaces.ListOptions{OrderBy: "login"})
+	require.NoError(t, err)
+	require.Len(t, organizations, 1)
+	require.Equal(t, "GitHub", organizations[0].Name)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,86 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/unkabogaton/github-users/internal/domain/entities"
+	"github.com/unkabogaton/github-users/internal/domain/interfaces"
+)
+
+// RepoRepository stores GitHub repositories. The name keeps it apart from the
+// repository pattern types in this package.
+type RepoRepository struct {
+	*GenericRepository[entities.Repository]
+}
+
+// repositorySortAliases maps the sort keys accepted by the API onto columns.
+var repositorySortAliases = map[string]string{
+	"stars": "stargazers_count",
+	"forks": "forks_count",
+}
+
+func NewRepoRepository(database *sqlx.DB) interfaces.RepoRepository {
+	genericRepository := NewGenericRepository[entities.Repository](database, "repositories", "id")
+	return &RepoRepository{GenericRepository: genericRepository}
+}
+
+// ReplaceForOwner swaps the stored repositories of ownerID for repositories,
+// dropping the ones that were deleted, renamed away or made private. Readers
+// see either the old or the new set, never neither.
+func (repoRepository *RepoRepository) ReplaceForOwner(
+	ctx context.Context,
+	ownerID int,
+	repositories []entities.Repository,
+) error {
+	// The upsert writes every column, so leave no zero timestamps behind.
+	now := time.Now()
+	for index := range repositories {
+		if repositories[index].CreatedAt.IsZero() {
+			repositories[index].CreatedAt = now
+		}
+		if repositories[index].UpdatedAt.IsZero() {
+			repositories[index].UpdatedAt = now
+		}
+	}
+	return repoRepository.ReplaceByField(ctx, "owner_id", fmt.Sprint(ownerID), repositories)
+}
+
+// ListForOwner resolves ownerLogin through github_users and selects by
+// owner_id, which the repository indexes cover and which survives renames of
+// the owner.
+func (repoRepository *RepoRepository) ListForOwner(
+	ctx context.Context,
+	ownerLogin string,
+	listOptions interfaces.RepositoryListOptions,
+) ([]entities.Repository, error) {
+	var results []entities.Repository
+
+	if sortColumn, ok := repositorySortAliases[listOptions.OrderBy]; ok {
+		listOptions.OrderBy = sortColumn
+	}
+	orderClause, limit, offset := orderAndPage(repoRepository.dialect, listOptions.ListOptions, repoRepository.columnList, "r")
+
+	whereClause := "WHERE u.login = ?"
+	queryArguments := []interface{}{ownerLogin}
+	if listOptions.Language != "" {
+		whereClause += " AND r.language = ?"
+		queryArguments = append(queryArguments, listOptions.Language)
+	}
+	queryArguments = append(queryArguments, limit, offset)
+
+	query := repoRepository.dialect.rebind(fmt.Sprintf(
+		"SELECT %s FROM repositories r JOIN github_users u ON u.id = r.owner_id %s %s LIMIT ? OFFSET ?",
+		qualifiedColumns(repoRepository.dialect, repoRepository.columnList, "r"),
+		whereClause,
+		orderClause,
+	))
+
+	if err := repoRepository.database.SelectContext(ctx, &results, query, queryArguments...); err != nil {
+		return nil, err
+	}
+
+	return results, nil
+}
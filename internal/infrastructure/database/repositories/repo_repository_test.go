@@ -0,0 +1,67 @@
+package repositories
+
+import (
+	"context"
+	"regexp"
+	"testing"
+
+	sqlmock "github.com/DATA-DOG/go-sqlmock"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/require"
+
+	"github.com/unkabogaton/github-users/internal/domain/entities"
+	"github.com/unkabogaton/github-users/internal/domain/interfaces"
+)
+
+func TestRepoRepository_ListForOwner_FiltersByLanguageAndSortsByStars(t *testing.T) {
+	t.Parallel()
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, "mysql")
+	repository := NewRepoRepository(sqlxDB)
+
+	rows := sqlmock.NewRows([]string{"id", "owner_id", "owner_login", "name", "language", "stargazers_count"}).
+		AddRow(1296269, 1, "octocat", "Hello-World", "Go", 80)
+
+	mock.ExpectQuery(regexp.QuoteMeta(
+		"FROM repositories r JOIN github_users u ON u.id = r.owner_id WHERE u.login = ? AND r.language = ? ORDER BY r.stargazers_count DESC LIMIT ? OFFSET ?",
+	)).
+		WithArgs("octocat", "Go", 10, 0).
+		WillReturnRows(rows)
+
+	options := interfaces.RepositoryListOptions{
+		ListOptions: interfaces.ListOptions{OrderBy: "stars", OrderDirection: "desc"},
+		Language:    "Go",
+	}
+	repositories, err := repository.ListForOwner(context.Background(), "octocat", options)
+	require.NoError(t, err)
+	require.Len(t, repositories, 1)
+	require.Equal(t, 80, repositories[0].StargazersCount)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestRepoRepository_ReplaceForOwner_RunsInOneTransaction(t *testing.T) {
+	t.Parallel()
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	repository := NewRepoRepository(sqlx.NewDb(db, "mysql"))
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta("DELETE FROM repositories WHERE owner_id = ?")).
+		WithArgs("1").
+		WillReturnResult(sqlmock.NewResult(0, 4))
+	expectExistingKeys(mock, "repositories", 0)
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO repositories")).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
+
+	err = repository.ReplaceForOwner(context.Background(), 1, []entities.Repository{
+		{ID: 1296269, OwnerID: 1, OwnerLogin: "octocat", Name: "Hello-World", FullName: "octocat/Hello-World"},
+	})
+	require.NoError(t, err)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
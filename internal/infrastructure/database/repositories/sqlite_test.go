@@ -0,0 +1,320 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/require"
+
+	"github.com/unkabogaton/github-users/internal/domain/entities"
+	derr "github.com/unkabogaton/github-users/internal/domain/errors"
+	"github.com/unkabogaton/github-users/internal/domain/interfaces"
+	"github.com/unkabogaton/github-users/internal/infrastructure/database"
+)
+
+// newSQLiteDatabase opens a fresh SQLite file with the sqlite migrations
+// applied.
+func newSQLiteDatabase(t *testing.T) *sqlx.DB {
+	t.Helper()
+	sqliteDatabase, err := database.Open(database.ConnectionOptions{
+		Driver: database.DriverSQLite,
+		Name:   filepath.Join(t.TempDir(), "github_users.db"),
+	})
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = sqliteDatabase.Close() })
+
+	migrationFiles, err := filepath.Glob("../../migrations/sqlite/*.sql")
+	require.NoError(t, err)
+	require.NotEmpty(t, migrationFiles)
+	for _, migrationFile := range migrationFiles {
+		migration, err := os.ReadFile(migrationFile)
+		require.NoError(t, err)
+		upMigration, _, _ := strings.Cut(string(migration), "-- +goose Down")
+		_, err = sqliteDatabase.Exec(upMigration)
+		require.NoError(t, err, migrationFile)
+	}
+	return sqliteDatabase
+}
+
+func TestSQLiteUserRepository_RoundTrip(t *testing.T) {
+	t.Parallel()
+	repository := NewUserRepository(newSQLiteDatabase(t))
+	ctx := context.Background()
+
+	require.NoError(t, repository.Upsert(ctx, &entities.User{ID: 2, Login: "bravo", SiteAdmin: true, Followers: 5}))
+	require.NoError(t, repository.Upsert(ctx, &entities.User{ID: 1, Login: "alpha", Followers: 9}))
+	require.NoError(t, repository.Upsert(ctx, &entities.User{ID: 2, Login: "bravo", SiteAdmin: true, Followers: 6}))
+
+	stored, err := repository.GetByLogin(ctx, "bravo")
+	require.NoError(t, err)
+	require.True(t, stored.SiteAdmin)
+	require.Equal(t, 6, stored.Followers)
+	require.False(t, stored.CreatedAt.IsZero())
+
+	users, err := repository.List(ctx, interfaces.ListOptions{Limit: 10, OrderBy: "followers", OrderDirection: "desc"})
+	require.NoError(t, err)
+	require.Len(t, users, 2)
+	require.Equal(t, "alpha", users[0].Login)
+
+	require.NoError(t, repository.DeleteByLogin(ctx, "alpha"))
+	_, err = repository.GetByLogin(ctx, "alpha")
+	require.Error(t, err)
+}
+
+func TestSQLiteUserRepository_BatchUpsertCountsAndKeepsCreatedAt(t *testing.T) {
+	t.Parallel()
+	repository := NewUserRepository(newSQLiteDatabase(t))
+	ctx := context.Background()
+
+	createdAt := time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)
+	users := []entities.User{
+		{ID: 1, Login: "alpha", CreatedAt: createdAt},
+		{ID: 2, Login: "bravo", CreatedAt: createdAt},
+	}
+	result, err := repository.BatchUpsert(ctx, &users)
+	require.NoError(t, err)
+	require.Equal(t, interfaces.BatchUpsertResult{Inserted: 2}, result)
+
+	users = []entities.User{
+		{ID: 2, Login: "bravo", Name: "Bravo"},
+		{ID: 3, Login: "charlie"},
+	}
+	result, err = repository.BatchUpsert(ctx, &users)
+	require.NoError(t, err)
+	require.Equal(t, interfaces.BatchUpsertResult{Inserted: 1, Updated: 1}, result)
+
+	stored, err := repository.GetByLogin(ctx, "bravo")
+	require.NoError(t, err)
+	require.Equal(t, "Bravo", stored.Name)
+	require.True(t, createdAt.Equal(stored.CreatedAt))
+}
+
+func TestSQLiteUserRepository_ListByIDDescendingFindsHighestID(t *testing.T) {
+	t.Parallel()
+	repository := NewUserRepository(newSQLiteDatabase(t))
+	ctx := context.Background()
+
+	// The highest ID was stored first, as when an earlier run stored it out of
+	// order, so the newest row is not the one to resume after.
+	users := []entities.User{
+		{ID: 900, Login: "early", CreatedAt: time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)},
+		{ID: 12, Login: "late", CreatedAt: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)},
+	}
+	_, err := repository.BatchUpsert(ctx, &users)
+	require.NoError(t, err)
+
+	lastUsers, err := repository.List(ctx, interfaces.ListOptions{Limit: 1, OrderBy: "id", OrderDirection: "DESC"})
+	require.NoError(t, err)
+	require.Len(t, lastUsers, 1)
+	require.Equal(t, 900, lastUsers[0].ID)
+}
+
+func TestSQLiteGenericRepository_BatchUpsertCountsCompositeKeys(t *testing.T) {
+	t.Parallel()
+	repository := NewGenericRepository[entities.UserFollow](newSQLiteDatabase(t), "user_follows", "follower_id", "follower_id", "following_id")
+	ctx := context.Background()
+
+	result, err := repository.BatchUpsert(ctx, []entities.UserFollow{
+		{FollowerID: 1, FollowingID: 10},
+		{FollowerID: 2, FollowingID: 10},
+	})
+	require.NoError(t, err)
+	require.Equal(t, interfaces.BatchUpsertResult{Inserted: 2}, result)
+
+	result, err = repository.BatchUpsert(ctx, []entities.UserFollow{
+		{FollowerID: 2, FollowingID: 10},
+		{FollowerID: 10, FollowingID: 2},
+		{FollowerID: 2, FollowingID: 10},
+	})
+	require.NoError(t, err)
+	require.Equal(t, interfaces.BatchUpsertResult{Inserted: 1, Updated: 1}, result)
+}
+
+func TestSQLiteFollowRepository_ReplaceAndList(t *testing.T) {
+	t.Parallel()
+	sqliteDatabase := newSQLiteDatabase(t)
+	userRepository := NewUserRepository(sqliteDatabase)
+	followRepository := NewFollowRepository(sqliteDatabase)
+	ctx := context.Background()
+
+	users := []entities.User{{ID: 1, Login: "alpha"}, {ID: 2, Login: "bravo"}, {ID: 3, Login: "charlie"}}
+	_, err := userRepository.BatchUpsert(ctx, &users)
+	require.NoError(t, err)
+
+	require.NoError(t, followRepository.ReplaceFollowers(ctx, 1, []entities.UserFollow{
+		{FollowerID: 2, FollowingID: 1},
+		{FollowerID: 3, FollowingID: 1},
+	}))
+	require.NoError(t, followRepository.ReplaceFollowers(ctx, 1, []entities.UserFollow{
+		{FollowerID: 3, FollowingID: 1},
+	}))
+
+	followers, err := followRepository.ListFollowers(ctx, "alpha", interfaces.ListOptions{OrderBy: "login"})
+	require.NoError(t, err)
+	require.Len(t, followers, 1)
+	require.Equal(t, "charlie", followers[0].Login)
+}
+
+func TestSQLiteUserRepository_ListWithFilters(t *testing.T) {
+	t.Parallel()
+	sqliteDatabase := newSQLiteDatabase(t)
+	repository := NewUserRepository(sqliteDatabase)
+	ctx := context.Background()
+
+	users := []entities.User{
+		{ID: 1, Login: "octo_cat", Type: "User", SiteAdmin: true},
+		{ID: 2, Login: "octoXcat", Type: "User"},
+		{ID: 3, Login: "octo-org", Type: "Organization"},
+		{ID: 4, Login: "other", Type: "User", SiteAdmin: true},
+	}
+	_, err := repository.BatchUpsert(ctx, &users)
+	require.NoError(t, err)
+	_, err = sqliteDatabase.Exec("UPDATE github_users SET updated_at = ? WHERE id = 4", time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC))
+	require.NoError(t, err)
+
+	listLogins := func(filters interfaces.UserListFilters) []string {
+		listed, err := repository.List(ctx, interfaces.ListOptions{Limit: 10, Filters: filters.Filters()})
+		require.NoError(t, err)
+		logins := []string{}
+		for _, user := range listed {
+			logins = append(logins, user.Login)
+		}
+		return logins
+	}
+
+	siteAdmin := true
+	updatedSince := time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+	require.Equal(t, []string{"octo_cat"}, listLogins(interfaces.UserListFilters{LoginPrefix: "octo_"}))
+	require.Equal(t, []string{"octo-org"}, listLogins(interfaces.UserListFilters{Type: "Organization"}))
+	require.Equal(t, []string{"octo_cat", "other"}, listLogins(interfaces.UserListFilters{SiteAdmin: &siteAdmin}))
+	require.Equal(t, []string{"octo_cat"}, listLogins(interfaces.UserListFilters{SiteAdmin: &siteAdmin, UpdatedSince: &updatedSince}))
+}
+
+func TestSQLiteUserRepository_ListPageWalksEveryRowOnce(t *testing.T) {
+	t.Parallel()
+	repository := NewUserRepository(newSQLiteDatabase(t))
+	ctx := context.Background()
+
+	users := make([]entities.User, 0, 25)
+	for index := 1; index <= 25; index++ {
+		// Followers repeat, so that the id has to break ties between pages.
+		users = append(users, entities.User{ID: index, Login: fmt.Sprintf("user%02d", index), Followers: index % 4})
+	}
+	_, err := repository.BatchUpsert(ctx, &users)
+	require.NoError(t, err)
+
+	options := interfaces.ListOptions{Limit: 7, OrderBy: "followers", OrderDirection: "desc", Cursor: ""}
+	firstPage, err := repository.ListPage(ctx, options)
+	require.NoError(t, err)
+	require.Len(t, firstPage.Users, 7)
+	require.NotEmpty(t, firstPage.NextCursor)
+
+	// Rows written in front of the scan position must not shift later pages.
+	inserted := []entities.User{{ID: 100, Login: "late", Followers: 3}}
+	_, err = repository.BatchUpsert(ctx, &inserted)
+	require.NoError(t, err)
+
+	seen := map[int]bool{}
+	for _, user := range firstPage.Users {
+		seen[user.ID] = true
+	}
+	options.Cursor = firstPage.NextCursor
+	for options.Cursor != "" {
+		page, err := repository.ListPage(ctx, options)
+		require.NoError(t, err)
+		for _, user := range page.Users {
+			require.False(t, seen[user.ID], "user %d listed twice", user.ID)
+			seen[user.ID] = true
+		}
+		options.Cursor = page.NextCursor
+	}
+	require.Len(t, seen, 25)
+	require.False(t, seen[100])
+}
+
+func TestSQLiteUserRepository_ListPageRejectsBadCursors(t *testing.T) {
+	t.Parallel()
+	repository := NewUserRepository(newSQLiteDatabase(t))
+	ctx := context.Background()
+
+	users := []entities.User{{ID: 1, Login: "alpha"}, {ID: 2, Login: "bravo"}}
+	_, err := repository.BatchUpsert(ctx, &users)
+	require.NoError(t, err)
+
+	page, err := repository.ListPage(ctx, interfaces.ListOptions{Limit: 1, OrderBy: "login"})
+	require.NoError(t, err)
+	require.NotEmpty(t, page.NextCursor)
+
+	for _, options := range []interfaces.ListOptions{
+		{Limit: 1, OrderBy: "login", OrderDirection: "desc", Cursor: page.NextCursor},
+		{Limit: 1, OrderBy: "id", Cursor: page.NextCursor},
+		{Limit: 1, OrderBy: "login", Cursor: "not a cursor"},
+		{Limit: 1, OrderBy: "github_created_at", Cursor: page.NextCursor},
+	} {
+		_, err := repository.ListPage(ctx, options)
+		require.True(t, derr.IsCode(err, derr.ErrorCodeValidation), "%+v: %v", options, err)
+	}
+
+	page, err = repository.ListPage(ctx, interfaces.ListOptions{Limit: 1, OrderBy: "github_created_at"})
+	require.NoError(t, err)
+	require.Len(t, page.Users, 1)
+	require.Empty(t, page.NextCursor)
+}
+
+func TestSQLiteUserRepository_BatchUpsertSummariesKeepsProfiles(t *testing.T) {
+	t.Parallel()
+	repository := NewUserRepository(newSQLiteDatabase(t))
+	ctx := context.Background()
+
+	profileFetchedAt := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+	profiles := []entities.User{{ID: 1, Login: "octocat", AvatarURL: "old", Name: "The Octocat", Company: "GitHub", Followers: 42, ProfileFetchedAt: &profileFetchedAt}}
+	_, err := repository.BatchUpsert(ctx, &profiles)
+	require.NoError(t, err)
+
+	summaries := []entities.User{
+		{ID: 1, Login: "octocat", AvatarURL: "new"},
+		{ID: 2, Login: "hubot", AvatarURL: "robot"},
+	}
+	result, err := repository.BatchUpsertSummaries(ctx, &summaries)
+	require.NoError(t, err)
+	require.Equal(t, interfaces.BatchUpsertResult{Inserted: 1, Updated: 1}, result)
+
+	stored, err := repository.GetByLogin(ctx, "octocat")
+	require.NoError(t, err)
+	require.Equal(t, "new", stored.AvatarURL)
+	require.Equal(t, "The Octocat", stored.Name)
+	require.Equal(t, "GitHub", stored.Company)
+	require.Equal(t, 42, stored.Followers)
+	require.NotNil(t, stored.ProfileFetchedAt)
+	require.True(t, profileFetchedAt.Equal(*stored.ProfileFetchedAt))
+
+	inserted, err := repository.GetByLogin(ctx, "hubot")
+	require.NoError(t, err)
+	require.Equal(t, "robot", inserted.AvatarURL)
+	require.Nil(t, inserted.ProfileFetchedAt)
+}
+
+func TestSQLiteOrganizationRepository_BatchUpsertSummariesKeepsNames(t *testing.T) {
+	t.Parallel()
+	repository := NewOrganizationRepository(newSQLiteDatabase(t))
+	ctx := context.Background()
+
+	require.NoError(t, repository.Upsert(ctx, &entities.Organization{
+		ID: 9919, Login: "github", Name: "GitHub", HTMLURL: "https://github.com/github", Description: "old",
+	}))
+
+	summaries := []entities.Organization{{ID: 9919, Login: "github", Description: "new"}}
+	require.NoError(t, repository.BatchUpsertSummaries(ctx, &summaries))
+
+	stored, err := repository.GetByLogin(ctx, "github")
+	require.NoError(t, err)
+	require.Equal(t, "GitHub", stored.Name)
+	require.Equal(t, "https://github.com/github", stored.HTMLURL)
+	require.Equal(t, "new", stored.Description)
+}
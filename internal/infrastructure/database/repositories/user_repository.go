@@ -8,6 +8,12 @@ import (
 	"github.com/unkabogaton/github-users/internal/domain/interfaces"
 )
 
+// userSummaryColumns are the columns the summary objects of list endpoints
+// such as /users and /search/users carry.
+var userSummaryColumns = []string{
+	"login", "node_id", "avatar_url", "url", "html_url", "type", "user_view_type", "site_admin",
+}
+
 type UserRepository struct {
 	*GenericRepository[entities.User]
 }
@@ -27,10 +33,19 @@ func (userRepository *UserRepository) Upsert(
 func (userRepository *UserRepository) BatchUpsert(
 	ctx context.Context,
 	userEntities *[]entities.User,
-) error {
+) (interfaces.BatchUpsertResult, error) {
 	return userRepository.GenericRepository.BatchUpsert(ctx, *userEntities)
 }
 
+// BatchUpsertSummaries stores users read from list endpoints. Stored users
+// keep their profile columns; only the summary columns are refreshed.
+func (userRepository *UserRepository) BatchUpsertSummaries(
+	ctx context.Context,
+	userEntities *[]entities.User,
+) (interfaces.BatchUpsertResult, error) {
+	return userRepository.GenericRepository.BatchUpsertColumns(ctx, *userEntities, userSummaryColumns)
+}
+
 func (userRepository *UserRepository) GetByLogin(
 	ctx context.Context,
 	login string,
@@ -48,7 +63,34 @@ func (userRepository *UserRepository) List(
 		listOptions.Page,
 		listOptions.OrderBy,
 		listOptions.OrderDirection,
+		listOptions.Filters,
+	)
+}
+
+func (userRepository *UserRepository) ListPage(
+	ctx context.Context,
+	listOptions interfaces.ListOptions,
+) (*interfaces.UserPage, error) {
+	users, nextCursor, err := userRepository.GenericRepository.ListPage(
+		ctx,
+		listOptions.Limit,
+		listOptions.Page,
+		listOptions.OrderBy,
+		listOptions.OrderDirection,
+		listOptions.Filters,
+		listOptions.Cursor,
 	)
+	if err != nil {
+		return nil, err
+	}
+	return &interfaces.UserPage{Users: users, NextCursor: nextCursor}, nil
+}
+
+func (userRepository *UserRepository) Count(
+	ctx context.Context,
+	filters []interfaces.Filter,
+) (int64, error) {
+	return userRepository.GenericRepository.Count(ctx, filters)
 }
 
 func (userRepository *UserRepository) DeleteByLogin(
@@ -25,6 +25,13 @@ var sampleUser = &entities.User{
 	Type:         "User",
 	UserViewType: "public",
 	SiteAdmin:    false,
+	Name:         "Sample User",
+	Company:      "Sample Inc",
+	Location:     "Earth",
+	Bio:          "bio",
+	PublicRepos:  3,
+	Followers:    10,
+	Following:    2,
 	UpdatedAt:    time.Now(),
 	CreatedAt:    time.Now(),
 }
@@ -55,14 +62,21 @@ func TestUserRepository_List_WithPaginationAndOrdering(t *testing.T) {
 	repository := NewUserRepository(sqlxDB)
 
 	rows := sqlmock.NewRows([]string{
-		"id", "login", "node_id", "avatar_url", "url", "html_url", "type", "user_view_type", "site_admin", "updated_at", "created_at",
+		"id", "login", "node_id", "avatar_url", "url", "html_url", "type", "user_view_type", "site_admin",
+		"name", "company", "blog", "location", "email", "bio", "twitter_username",
+		"public_repos", "public_gists", "followers", "following", "github_created_at", "github_updated_at",
+		"profile_fetched_at", "updated_at", "created_at",
 	}).AddRow(
 		sampleUser.ID, sampleUser.Login, sampleUser.NodeID, sampleUser.AvatarURL, sampleUser.URL, sampleUser.HTMLURL,
-		sampleUser.Type, sampleUser.UserViewType, sampleUser.SiteAdmin, sampleUser.UpdatedAt, sampleUser.CreatedAt,
+		sampleUser.Type, sampleUser.UserViewType, sampleUser.SiteAdmin,
+		sampleUser.Name, sampleUser.Company, sampleUser.Blog, sampleUser.Location, sampleUser.Email, sampleUser.Bio,
+		sampleUser.TwitterUsername, sampleUser.PublicRepos, sampleUser.PublicGists, sampleUser.Followers,
+		sampleUser.Following, sampleUser.GitHubCreatedAt, sampleUser.GitHubUpdatedAt,
+		sampleUser.ProfileFetchedAt, sampleUser.UpdatedAt, sampleUser.CreatedAt,
 	)
 
 	mock.ExpectQuery(regexp.QuoteMeta(
-		"SELECT id, login, node_id, avatar_url, url, html_url, type, user_view_type, site_admin, updated_at, created_at FROM github_users ORDER BY login ASC LIMIT ? OFFSET ?",
+		"SELECT id, login, node_id, avatar_url, url, html_url, type, user_view_type, site_admin, name, company, blog, location, email, bio, twitter_username, public_repos, public_gists, followers, following, github_created_at, github_updated_at, profile_fetched_at, updated_at, created_at FROM github_users ORDER BY login ASC LIMIT ? OFFSET ?",
 	)).
 		WithArgs(5, 5).
 		WillReturnRows(rows)
@@ -84,15 +98,21 @@ func TestUserRepository_GetByLogin(t *testing.T) {
 	repository := NewUserRepository(sqlxDB)
 
 	rows := sqlmock.NewRows([]string{
-		"id", "login", "node_id", "avatar_url", "url", "html_url",
-		"type", "user_view_type", "site_admin", "updated_at", "created_at",
+		"id", "login", "node_id", "avatar_url", "url", "html_url", "type", "user_view_type", "site_admin",
+		"name", "company", "blog", "location", "email", "bio", "twitter_username",
+		"public_repos", "public_gists", "followers", "following", "github_created_at", "github_updated_at",
+		"profile_fetched_at", "updated_at", "created_at",
 	}).AddRow(
 		sampleUser.ID, sampleUser.Login, sampleUser.NodeID, sampleUser.AvatarURL, sampleUser.URL, sampleUser.HTMLURL,
-		sampleUser.Type, sampleUser.UserViewType, sampleUser.SiteAdmin, sampleUser.UpdatedAt, sampleUser.CreatedAt,
+		sampleUser.Type, sampleUser.UserViewType, sampleUser.SiteAdmin,
+		sampleUser.Name, sampleUser.Company, sampleUser.Blog, sampleUser.Location, sampleUser.Email, sampleUser.Bio,
+		sampleUser.TwitterUsername, sampleUser.PublicRepos, sampleUser.PublicGists, sampleUser.Followers,
+		sampleUser.Following, sampleUser.GitHubCreatedAt, sampleUser.GitHubUpdatedAt,
+		sampleUser.ProfileFetchedAt, sampleUser.UpdatedAt, sampleUser.CreatedAt,
 	)
 
 	mock.ExpectQuery(regexp.QuoteMeta(
-		"SELECT id, login, node_id, avatar_url, url, html_url, type, user_view_type, site_admin, updated_at, created_at FROM github_users WHERE login = ? LIMIT 1",
+		"SELECT id, login, node_id, avatar_url, url, html_url, type, user_view_type, site_admin, name, company, blog, location, email, bio, twitter_username, public_repos, public_gists, followers, following, github_created_at, github_updated_at, profile_fetched_at, updated_at, created_at FROM github_users WHERE login = ? LIMIT 1",
 	)).
 		WithArgs(sampleUser.Login).
 		WillReturnRows(rows)
@@ -102,6 +122,8 @@ func TestUserRepository_GetByLogin(t *testing.T) {
 	require.NotNil(t, got)
 	require.Equal(t, sampleUser.ID, got.ID)
 	require.Equal(t, sampleUser.Login, got.Login)
+	require.Equal(t, sampleUser.Name, got.Name)
+	require.Equal(t, sampleUser.Followers, got.Followers)
 
 	require.NoError(t, mock.ExpectationsWereMet())
 }
@@ -139,7 +161,7 @@ func TestUserRepository_GetByLogin_NotFound(t *testing.T) {
 	repository := NewUserRepository(sqlxDB)
 
 	mock.ExpectQuery(regexp.QuoteMeta(
-		"SELECT id, login, node_id, avatar_url, url, html_url, type, user_view_type, site_admin, updated_at, created_at FROM github_users WHERE login = ? LIMIT 1",
+		"SELECT id, login, node_id, avatar_url, url, html_url, type, user_view_type, site_admin, name, company, blog, location, email, bio, twitter_username, public_repos, public_gists, followers, following, github_created_at, github_updated_at, profile_fetched_at, updated_at, created_at FROM github_users WHERE login = ? LIMIT 1",
 	)).
 		WithArgs("nonexistent_user").
 		WillReturnError(fmt.Errorf("no row found"))
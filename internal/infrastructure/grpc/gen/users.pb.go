@@ -58,18 +58,34 @@ func (*Empty) Descriptor() ([]byte, []int) {
 }
 
 type User struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
-	Login         string                 `protobuf:"bytes,2,opt,name=login,proto3" json:"login,omitempty"`
-	NodeId        string                 `protobuf:"bytes,3,opt,name=node_id,json=nodeId,proto3" json:"node_id,omitempty"`
-	AvatarUrl     string                 `protobuf:"bytes,4,opt,name=avatar_url,json=avatarUrl,proto3" json:"avatar_url,omitempty"`
-	Url           string                 `protobuf:"bytes,5,opt,name=url,proto3" json:"url,omitempty"`
-	HtmlUrl       string                 `protobuf:"bytes,6,opt,name=html_url,json=htmlUrl,proto3" json:"html_url,omitempty"`
-	Type          string                 `protobuf:"bytes,7,opt,name=type,proto3" json:"type,omitempty"`
-	UserViewType  string                 `protobuf:"bytes,8,opt,name=user_view_type,json=userViewType,proto3" json:"user_view_type,omitempty"`
-	SiteAdmin     bool                   `protobuf:"varint,9,opt,name=site_admin,json=siteAdmin,proto3" json:"site_admin,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	Id               int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Login            string                 `protobuf:"bytes,2,opt,name=login,proto3" json:"login,omitempty"`
+	NodeId           string                 `protobuf:"bytes,3,opt,name=node_id,json=nodeId,proto3" json:"node_id,omitempty"`
+	AvatarUrl        string                 `protobuf:"bytes,4,opt,name=avatar_url,json=avatarUrl,proto3" json:"avatar_url,omitempty"`
+	Url              string                 `protobuf:"bytes,5,opt,name=url,proto3" json:"url,omitempty"`
+	HtmlUrl          string                 `protobuf:"bytes,6,opt,name=html_url,json=htmlUrl,proto3" json:"html_url,omitempty"`
+	Type             string                 `protobuf:"bytes,7,opt,name=type,proto3" json:"type,omitempty"`
+	UserViewType     string                 `protobuf:"bytes,8,opt,name=user_view_type,json=userViewType,proto3" json:"user_view_type,omitempty"`
+	SiteAdmin        bool                   `protobuf:"varint,9,opt,name=site_admin,json=siteAdmin,proto3" json:"site_admin,omitempty"`
+	Name             string                 `protobuf:"bytes,10,opt,name=name,proto3" json:"name,omitempty"`
+	Company          string                 `protobuf:"bytes,11,opt,name=company,proto3" json:"company,omitempty"`
+	Blog             string                 `protobuf:"bytes,12,opt,name=blog,proto3" json:"blog,omitempty"`
+	Location         string                 `protobuf:"bytes,13,opt,name=location,proto3" json:"location,omitempty"`
+	Email            string                 `protobuf:"bytes,14,opt,name=email,proto3" json:"email,omitempty"`
+	Bio              string                 `protobuf:"bytes,15,opt,name=bio,proto3" json:"bio,omitempty"`
+	TwitterUsername  string                 `protobuf:"bytes,16,opt,name=twitter_username,json=twitterUsername,proto3" json:"twitter_username,omitempty"`
+	PublicRepos      int32                  `protobuf:"varint,17,opt,name=public_repos,json=publicRepos,proto3" json:"public_repos,omitempty"`
+	PublicGists      int32                  `protobuf:"varint,18,opt,name=public_gists,json=publicGists,proto3" json:"public_gists,omitempty"`
+	Followers        int32                  `protobuf:"varint,19,opt,name=followers,proto3" json:"followers,omitempty"`
+	Following        int32                  `protobuf:"varint,20,opt,name=following,proto3" json:"following,omitempty"`
+	GithubCreatedAt  int64                  `protobuf:"varint,21,opt,name=github_created_at,json=githubCreatedAt,proto3" json:"github_created_at,omitempty"`
+	GithubUpdatedAt  int64                  `protobuf:"varint,22,opt,name=github_updated_at,json=githubUpdatedAt,proto3" json:"github_updated_at,omitempty"`
+	UpdatedAt        int64                  `protobuf:"varint,23,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	CreatedAt        int64                  `protobuf:"varint,24,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	ProfileFetchedAt int64                  `protobuf:"varint,25,opt,name=profile_fetched_at,json=profileFetchedAt,proto3" json:"profile_fetched_at,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
 }
 
 func (x *User) Reset() {
@@ -165,9 +181,126 @@ func (x *User) GetSiteAdmin() bool {
 	return false
 }
 
+func (x *User) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *User) GetCompany() string {
+	if x != nil {
+		return x.Company
+	}
+	return ""
+}
+
+func (x *User) GetBlog() string {
+	if x != nil {
+		return x.Blog
+	}
+	return ""
+}
+
+func (x *User) GetLocation() string {
+	if x != nil {
+		return x.Location
+	}
+	return ""
+}
+
+func (x *User) GetEmail() string {
+	if x != nil {
+		return x.Email
+	}
+	return ""
+}
+
+func (x *User) GetBio() string {
+	if x != nil {
+		return x.Bio
+	}
+	return ""
+}
+
+func (x *User) GetTwitterUsername() string {
+	if x != nil {
+		return x.TwitterUsername
+	}
+	return ""
+}
+
+func (x *User) GetPublicRepos() int32 {
+	if x != nil {
+		return x.PublicRepos
+	}
+	return 0
+}
+
+func (x *User) GetPublicGists() int32 {
+	if x != nil {
+		return x.PublicGists
+	}
+	return 0
+}
+
+func (x *User) GetFollowers() int32 {
+	if x != nil {
+		return x.Followers
+	}
+	return 0
+}
+
+func (x *User) GetFollowing() int32 {
+	if x != nil {
+		return x.Following
+	}
+	return 0
+}
+
+func (x *User) GetGithubCreatedAt() int64 {
+	if x != nil {
+		return x.GithubCreatedAt
+	}
+	return 0
+}
+
+func (x *User) GetGithubUpdatedAt() int64 {
+	if x != nil {
+		return x.GithubUpdatedAt
+	}
+	return 0
+}
+
+func (x *User) GetUpdatedAt() int64 {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return 0
+}
+
+func (x *User) GetCreatedAt() int64 {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return 0
+}
+
+func (x *User) GetProfileFetchedAt() int64 {
+	if x != nil {
+		return x.ProfileFetchedAt
+	}
+	return 0
+}
+
 type UserList struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Users         []*User                `protobuf:"bytes,1,rep,name=users,proto3" json:"users,omitempty"`
+	NextPageToken string                 `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	Total         int64                  `protobuf:"varint,3,opt,name=total,proto3" json:"total,omitempty"`
+	Page          int32                  `protobuf:"varint,4,opt,name=page,proto3" json:"page,omitempty"`
+	Limit         int32                  `protobuf:"varint,5,opt,name=limit,proto3" json:"limit,omitempty"`
+	HasMore       bool                   `protobuf:"varint,6,opt,name=has_more,json=hasMore,proto3" json:"has_more,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -209,12 +342,53 @@ func (x *UserList) GetUsers() []*User {
 	return nil
 }
 
+func (x *UserList) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+func (x *UserList) GetTotal() int64 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+func (x *UserList) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *UserList) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *UserList) GetHasMore() bool {
+	if x != nil {
+		return x.HasMore
+	}
+	return false
+}
+
 type ListUsersRequest struct {
 	state          protoimpl.MessageState `protogen:"open.v1"`
 	Limit          int32                  `protobuf:"varint,1,opt,name=limit,proto3" json:"limit,omitempty"`
 	Page           int32                  `protobuf:"varint,2,opt,name=page,proto3" json:"page,omitempty"`
 	OrderBy        string                 `protobuf:"bytes,3,opt,name=order_by,json=orderBy,proto3" json:"order_by,omitempty"`
 	OrderDirection string                 `protobuf:"bytes,4,opt,name=order_direction,json=orderDirection,proto3" json:"order_direction,omitempty"`
+	SiteAdmin      *bool                  `protobuf:"varint,5,opt,name=site_admin,json=siteAdmin,proto3,oneof" json:"site_admin,omitempty"`
+	Type           string                 `protobuf:"bytes,6,opt,name=type,proto3" json:"type,omitempty"`
+	LoginPrefix    string                 `protobuf:"bytes,7,opt,name=login_prefix,json=loginPrefix,proto3" json:"login_prefix,omitempty"`
+	UpdatedSince   int64                  `protobuf:"varint,8,opt,name=updated_since,json=updatedSince,proto3" json:"updated_since,omitempty"`
+	UpdatedBefore  int64                  `protobuf:"varint,9,opt,name=updated_before,json=updatedBefore,proto3" json:"updated_before,omitempty"`
+	PageToken      string                 `protobuf:"bytes,10,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
 	unknownFields  protoimpl.UnknownFields
 	sizeCache      protoimpl.SizeCache
 }
@@ -277,9 +451,53 @@ func (x *ListUsersRequest) GetOrderDirection() string {
 	return ""
 }
 
+func (x *ListUsersRequest) GetSiteAdmin() bool {
+	if x != nil && x.SiteAdmin != nil {
+		return *x.SiteAdmin
+	}
+	return false
+}
+
+func (x *ListUsersRequest) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *ListUsersRequest) GetLoginPrefix() string {
+	if x != nil {
+		return x.LoginPrefix
+	}
+	return ""
+}
+
+func (x *ListUsersRequest) GetUpdatedSince() int64 {
+	if x != nil {
+		return x.UpdatedSince
+	}
+	return 0
+}
+
+func (x *ListUsersRequest) GetUpdatedBefore() int64 {
+	if x != nil {
+		return x.UpdatedBefore
+	}
+	return 0
+}
+
+func (x *ListUsersRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
 type GetUserRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Username      string                 `protobuf:"bytes,1,opt,name=username,proto3" json:"username,omitempty"`
+	MaxAgeSeconds int64                  `protobuf:"varint,2,opt,name=max_age_seconds,json=maxAgeSeconds,proto3" json:"max_age_seconds,omitempty"`
+	Refresh       bool                   `protobuf:"varint,3,opt,name=refresh,proto3" json:"refresh,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -321,6 +539,20 @@ func (x *GetUserRequest) GetUsername() string {
 	return ""
 }
 
+func (x *GetUserRequest) GetMaxAgeSeconds() int64 {
+	if x != nil {
+		return x.MaxAgeSeconds
+	}
+	return 0
+}
+
+func (x *GetUserRequest) GetRefresh() bool {
+	if x != nil {
+		return x.Refresh
+	}
+	return false
+}
+
 type UpdateUserRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Username      string                 `protobuf:"bytes,1,opt,name=username,proto3" json:"username,omitempty"`
@@ -517,56 +749,1116 @@ func (x *DeleteUserResponse) GetUsername() string {
 	return ""
 }
 
-var File_users_proto protoreflect.FileDescriptor
+type SearchUsersRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Query         string                 `protobuf:"bytes,1,opt,name=query,proto3" json:"query,omitempty"`
+	Sort          string                 `protobuf:"bytes,2,opt,name=sort,proto3" json:"sort,omitempty"`
+	Order         string                 `protobuf:"bytes,3,opt,name=order,proto3" json:"order,omitempty"`
+	Page          int32                  `protobuf:"varint,4,opt,name=page,proto3" json:"page,omitempty"`
+	Persist       bool                   `protobuf:"varint,5,opt,name=persist,proto3" json:"persist,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
 
-const file_users_proto_rawDesc = "" +
-	"\n" +
-	"\vusers.proto\x12\x0egithubusers.v1\"\a\n" +
-	"\x05Empty\"\xea\x01\n" +
-	"\x04User\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x14\n" +
-	"\x05login\x18\x02 \x01(\tR\x05login\x12\x17\n" +
-	"\anode_id\x18\x03 \x01(\tR\x06nodeId\x12\x1d\n" +
-	"\n" +
-	"avatar_url\x18\x04 \x01(\tR\tavatarUrl\x12\x10\n" +
-	"\x03url\x18\x05 \x01(\tR\x03url\x12\x19\n" +
-	"\bhtml_url\x18\x06 \x01(\tR\ahtmlUrl\x12\x12\n" +
-	"\x04type\x18\a \x01(\tR\x04type\x12$\n" +
-	"\x0euser_view_type\x18\b \x01(\tR\fuserViewType\x12\x1d\n" +
-	"\n" +
-	"site_admin\x18\t \x01(\bR\tsiteAdmin\"6\n" +
-	"\bUserList\x12*\n" +
-	"\x05users\x18\x01 \x03(\v2\x14.githubusers.v1.UserR\x05users\"\x80\x01\n" +
-	"\x10ListUsersRequest\x12\x14\n" +
-	"\x05limit\x18\x01 \x01(\x05R\x05limit\x12\x12\n" +
-	"\x04page\x18\x02 \x01(\x05R\x04page\x12\x19\n" +
-	"\border_by\x18\x03 \x01(\tR\aorderBy\x12'\n" +
-	"\x0forder_direction\x18\x04 \x01(\tR\x0eorderDirection\",\n" +
-	"\x0eGetUserRequest\x12\x1a\n" +
-	"\busername\x18\x01 \x01(\tR\busername\"\x83\x02\n" +
-	"\x11UpdateUserRequest\x12\x1a\n" +
-	"\busername\x18\x01 \x01(\tR\busername\x12\x14\n" +
-	"\x05login\x18\x02 \x01(\tR\x05login\x12\x17\n" +
-	"\anode_id\x18\x03 \x01(\tR\x06nodeId\x12\x1d\n" +
-	"\n" +
-	"avatar_url\x18\x04 \x01(\tR\tavatarUrl\x12\x10\n" +
-	"\x03url\x18\x05 \x01(\tR\x03url\x12\x19\n" +
-	"\bhtml_url\x18\x06 \x01(\tR\ahtmlUrl\x12\x12\n" +
-	"\x04type\x18\a \x01(\tR\x04type\x12$\n" +
-	"\x0euser_view_type\x18\b \x01(\tR\fuserViewType\x12\x1d\n" +
-	"\n" +
-	"site_admin\x18\t \x01(\bR\tsiteAdmin\"/\n" +
-	"\x11DeleteUserRequest\x12\x1a\n" +
-	"\busername\x18\x01 \x01(\tR\busername\"0\n" +
-	"\x12DeleteUserResponse\x12\x1a\n" +
-	"\busername\x18\x01 \x01(\tR\busername2\xb3\x02\n" +
+func (x *SearchUsersRequest) Reset() {
+	*x = SearchUsersRequest{}
+	mi := &file_users_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchUsersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchUsersRequest) ProtoMessage() {}
+
+func (x *SearchUsersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_users_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchUsersRequest.ProtoReflect.Descriptor instead.
+func (*SearchUsersRequest) Descriptor() ([]byte, []int) {
+	return file_users_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *SearchUsersRequest) GetQuery() string {
+	if x != nil {
+		return x.Query
+	}
+	return ""
+}
+
+func (x *SearchUsersRequest) GetSort() string {
+	if x != nil {
+		return x.Sort
+	}
+	return ""
+}
+
+func (x *SearchUsersRequest) GetOrder() string {
+	if x != nil {
+		return x.Order
+	}
+	return ""
+}
+
+func (x *SearchUsersRequest) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *SearchUsersRequest) GetPersist() bool {
+	if x != nil {
+		return x.Persist
+	}
+	return false
+}
+
+type SearchUsersResponse struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	TotalCount        int32                  `protobuf:"varint,1,opt,name=total_count,json=totalCount,proto3" json:"total_count,omitempty"`
+	IncompleteResults bool                   `protobuf:"varint,2,opt,name=incomplete_results,json=incompleteResults,proto3" json:"incomplete_results,omitempty"`
+	Users             []*User                `protobuf:"bytes,3,rep,name=users,proto3" json:"users,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *SearchUsersResponse) Reset() {
+	*x = SearchUsersResponse{}
+	mi := &file_users_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchUsersResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchUsersResponse) ProtoMessage() {}
+
+func (x *SearchUsersResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_users_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchUsersResponse.ProtoReflect.Descriptor instead.
+func (*SearchUsersResponse) Descriptor() ([]byte, []int) {
+	return file_users_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *SearchUsersResponse) GetTotalCount() int32 {
+	if x != nil {
+		return x.TotalCount
+	}
+	return 0
+}
+
+func (x *SearchUsersResponse) GetIncompleteResults() bool {
+	if x != nil {
+		return x.IncompleteResults
+	}
+	return false
+}
+
+func (x *SearchUsersResponse) GetUsers() []*User {
+	if x != nil {
+		return x.Users
+	}
+	return nil
+}
+
+type ListFollowsRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Username       string                 `protobuf:"bytes,1,opt,name=username,proto3" json:"username,omitempty"`
+	Limit          int32                  `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
+	Page           int32                  `protobuf:"varint,3,opt,name=page,proto3" json:"page,omitempty"`
+	OrderBy        string                 `protobuf:"bytes,4,opt,name=order_by,json=orderBy,proto3" json:"order_by,omitempty"`
+	OrderDirection string                 `protobuf:"bytes,5,opt,name=order_direction,json=orderDirection,proto3" json:"order_direction,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *ListFollowsRequest) Reset() {
+	*x = ListFollowsRequest{}
+	mi := &file_users_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListFollowsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListFollowsRequest) ProtoMessage() {}
+
+func (x *ListFollowsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_users_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListFollowsRequest.ProtoReflect.Descriptor instead.
+func (*ListFollowsRequest) Descriptor() ([]byte, []int) {
+	return file_users_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *ListFollowsRequest) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+func (x *ListFollowsRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *ListFollowsRequest) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *ListFollowsRequest) GetOrderBy() string {
+	if x != nil {
+		return x.OrderBy
+	}
+	return ""
+}
+
+func (x *ListFollowsRequest) GetOrderDirection() string {
+	if x != nil {
+		return x.OrderDirection
+	}
+	return ""
+}
+
+type Organization struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Login         string                 `protobuf:"bytes,2,opt,name=login,proto3" json:"login,omitempty"`
+	NodeId        string                 `protobuf:"bytes,3,opt,name=node_id,json=nodeId,proto3" json:"node_id,omitempty"`
+	Url           string                 `protobuf:"bytes,4,opt,name=url,proto3" json:"url,omitempty"`
+	HtmlUrl       string                 `protobuf:"bytes,5,opt,name=html_url,json=htmlUrl,proto3" json:"html_url,omitempty"`
+	AvatarUrl     string                 `protobuf:"bytes,6,opt,name=avatar_url,json=avatarUrl,proto3" json:"avatar_url,omitempty"`
+	Name          string                 `protobuf:"bytes,7,opt,name=name,proto3" json:"name,omitempty"`
+	Description   string                 `protobuf:"bytes,8,opt,name=description,proto3" json:"description,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Organization) Reset() {
+	*x = Organization{}
+	mi := &file_users_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Organization) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Organization) ProtoMessage() {}
+
+func (x *Organization) ProtoReflect() protoreflect.Message {
+	mi := &file_users_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Organization.ProtoReflect.Descriptor instead.
+func (*Organization) Descriptor() ([]byte, []int) {
+	return file_users_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *Organization) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Organization) GetLogin() string {
+	if x != nil {
+		return x.Login
+	}
+	return ""
+}
+
+func (x *Organization) GetNodeId() string {
+	if x != nil {
+		return x.NodeId
+	}
+	return ""
+}
+
+func (x *Organization) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+func (x *Organization) GetHtmlUrl() string {
+	if x != nil {
+		return x.HtmlUrl
+	}
+	return ""
+}
+
+func (x *Organization) GetAvatarUrl() string {
+	if x != nil {
+		return x.AvatarUrl
+	}
+	return ""
+}
+
+func (x *Organization) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Organization) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+type OrganizationList struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Organizations []*Organization        `protobuf:"bytes,1,rep,name=organizations,proto3" json:"organizations,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *OrganizationList) Reset() {
+	*x = OrganizationList{}
+	mi := &file_users_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OrganizationList) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OrganizationList) ProtoMessage() {}
+
+func (x *OrganizationList) ProtoReflect() protoreflect.Message {
+	mi := &file_users_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OrganizationList.ProtoReflect.Descriptor instead.
+func (*OrganizationList) Descriptor() ([]byte, []int) {
+	return file_users_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *OrganizationList) GetOrganizations() []*Organization {
+	if x != nil {
+		return x.Organizations
+	}
+	return nil
+}
+
+type ListOrganizationUsersRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Organization   string                 `protobuf:"bytes,1,opt,name=organization,proto3" json:"organization,omitempty"`
+	Limit          int32                  `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
+	Page           int32                  `protobuf:"varint,3,opt,name=page,proto3" json:"page,omitempty"`
+	OrderBy        string                 `protobuf:"bytes,4,opt,name=order_by,json=orderBy,proto3" json:"order_by,omitempty"`
+	OrderDirection string                 `protobuf:"bytes,5,opt,name=order_direction,json=orderDirection,proto3" json:"order_direction,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *ListOrganizationUsersRequest) Reset() {
+	*x = ListOrganizationUsersRequest{}
+	mi := &file_users_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListOrganizationUsersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListOrganizationUsersRequest) ProtoMessage() {}
+
+func (x *ListOrganizationUsersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_users_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListOrganizationUsersRequest.ProtoReflect.Descriptor instead.
+func (*ListOrganizationUsersRequest) Descriptor() ([]byte, []int) {
+	return file_users_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *ListOrganizationUsersRequest) GetOrganization() string {
+	if x != nil {
+		return x.Organization
+	}
+	return ""
+}
+
+func (x *ListOrganizationUsersRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *ListOrganizationUsersRequest) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *ListOrganizationUsersRequest) GetOrderBy() string {
+	if x != nil {
+		return x.OrderBy
+	}
+	return ""
+}
+
+func (x *ListOrganizationUsersRequest) GetOrderDirection() string {
+	if x != nil {
+		return x.OrderDirection
+	}
+	return ""
+}
+
+type ListUserOrganizationsRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Username       string                 `protobuf:"bytes,1,opt,name=username,proto3" json:"username,omitempty"`
+	Limit          int32                  `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
+	Page           int32                  `protobuf:"varint,3,opt,name=page,proto3" json:"page,omitempty"`
+	OrderBy        string                 `protobuf:"bytes,4,opt,name=order_by,json=orderBy,proto3" json:"order_by,omitempty"`
+	OrderDirection string                 `protobuf:"bytes,5,opt,name=order_direction,json=orderDirection,proto3" json:"order_direction,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *ListUserOrganizationsRequest) Reset() {
+	*x = ListUserOrganizationsRequest{}
+	mi := &file_users_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListUserOrganizationsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListUserOrganizationsRequest) ProtoMessage() {}
+
+func (x *ListUserOrganizationsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_users_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListUserOrganizationsRequest.ProtoReflect.Descriptor instead.
+func (*ListUserOrganizationsRequest) Descriptor() ([]byte, []int) {
+	return file_users_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *ListUserOrganizationsRequest) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+func (x *ListUserOrganizationsRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *ListUserOrganizationsRequest) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *ListUserOrganizationsRequest) GetOrderBy() string {
+	if x != nil {
+		return x.OrderBy
+	}
+	return ""
+}
+
+func (x *ListUserOrganizationsRequest) GetOrderDirection() string {
+	if x != nil {
+		return x.OrderDirection
+	}
+	return ""
+}
+
+type Repository struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Id              int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	OwnerId         int64                  `protobuf:"varint,2,opt,name=owner_id,json=ownerId,proto3" json:"owner_id,omitempty"`
+	OwnerLogin      string                 `protobuf:"bytes,3,opt,name=owner_login,json=ownerLogin,proto3" json:"owner_login,omitempty"`
+	Name            string                 `protobuf:"bytes,4,opt,name=name,proto3" json:"name,omitempty"`
+	FullName        string                 `protobuf:"bytes,5,opt,name=full_name,json=fullName,proto3" json:"full_name,omitempty"`
+	HtmlUrl         string                 `protobuf:"bytes,6,opt,name=html_url,json=htmlUrl,proto3" json:"html_url,omitempty"`
+	Description     string                 `protobuf:"bytes,7,opt,name=description,proto3" json:"description,omitempty"`
+	Language        string                 `protobuf:"bytes,8,opt,name=language,proto3" json:"language,omitempty"`
+	Fork            bool                   `protobuf:"varint,9,opt,name=fork,proto3" json:"fork,omitempty"`
+	Archived        bool                   `protobuf:"varint,10,opt,name=archived,proto3" json:"archived,omitempty"`
+	StargazersCount int32                  `protobuf:"varint,11,opt,name=stargazers_count,json=stargazersCount,proto3" json:"stargazers_count,omitempty"`
+	ForksCount      int32                  `protobuf:"varint,12,opt,name=forks_count,json=forksCount,proto3" json:"forks_count,omitempty"`
+	WatchersCount   int32                  `protobuf:"varint,13,opt,name=watchers_count,json=watchersCount,proto3" json:"watchers_count,omitempty"`
+	OpenIssuesCount int32                  `protobuf:"varint,14,opt,name=open_issues_count,json=openIssuesCount,proto3" json:"open_issues_count,omitempty"`
+	PushedAt        int64                  `protobuf:"varint,15,opt,name=pushed_at,json=pushedAt,proto3" json:"pushed_at,omitempty"`
+	GithubCreatedAt int64                  `protobuf:"varint,16,opt,name=github_created_at,json=githubCreatedAt,proto3" json:"github_created_at,omitempty"`
+	GithubUpdatedAt int64                  `protobuf:"varint,17,opt,name=github_updated_at,json=githubUpdatedAt,proto3" json:"github_updated_at,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *Repository) Reset() {
+	*x = Repository{}
+	mi := &file_users_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Repository) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Repository) ProtoMessage() {}
+
+func (x *Repository) ProtoReflect() protoreflect.Message {
+	mi := &file_users_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Repository.ProtoReflect.Descriptor instead.
+func (*Repository) Descriptor() ([]byte, []int) {
+	return file_users_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *Repository) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Repository) GetOwnerId() int64 {
+	if x != nil {
+		return x.OwnerId
+	}
+	return 0
+}
+
+func (x *Repository) GetOwnerLogin() string {
+	if x != nil {
+		return x.OwnerLogin
+	}
+	return ""
+}
+
+func (x *Repository) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Repository) GetFullName() string {
+	if x != nil {
+		return x.FullName
+	}
+	return ""
+}
+
+func (x *Repository) GetHtmlUrl() string {
+	if x != nil {
+		return x.HtmlUrl
+	}
+	return ""
+}
+
+func (x *Repository) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *Repository) GetLanguage() string {
+	if x != nil {
+		return x.Language
+	}
+	return ""
+}
+
+func (x *Repository) GetFork() bool {
+	if x != nil {
+		return x.Fork
+	}
+	return false
+}
+
+func (x *Repository) GetArchived() bool {
+	if x != nil {
+		return x.Archived
+	}
+	return false
+}
+
+func (x *Repository) GetStargazersCount() int32 {
+	if x != nil {
+		return x.StargazersCount
+	}
+	return 0
+}
+
+func (x *Repository) GetForksCount() int32 {
+	if x != nil {
+		return x.ForksCount
+	}
+	return 0
+}
+
+func (x *Repository) GetWatchersCount() int32 {
+	if x != nil {
+		return x.WatchersCount
+	}
+	return 0
+}
+
+func (x *Repository) GetOpenIssuesCount() int32 {
+	if x != nil {
+		return x.OpenIssuesCount
+	}
+	return 0
+}
+
+func (x *Repository) GetPushedAt() int64 {
+	if x != nil {
+		return x.PushedAt
+	}
+	return 0
+}
+
+func (x *Repository) GetGithubCreatedAt() int64 {
+	if x != nil {
+		return x.GithubCreatedAt
+	}
+	return 0
+}
+
+func (x *Repository) GetGithubUpdatedAt() int64 {
+	if x != nil {
+		return x.GithubUpdatedAt
+	}
+	return 0
+}
+
+type RepositoryList struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Repositories  []*Repository          `protobuf:"bytes,1,rep,name=repositories,proto3" json:"repositories,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RepositoryList) Reset() {
+	*x = RepositoryList{}
+	mi := &file_users_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RepositoryList) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RepositoryList) ProtoMessage() {}
+
+func (x *RepositoryList) ProtoReflect() protoreflect.Message {
+	mi := &file_users_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RepositoryList.ProtoReflect.Descriptor instead.
+func (*RepositoryList) Descriptor() ([]byte, []int) {
+	return file_users_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *RepositoryList) GetRepositories() []*Repository {
+	if x != nil {
+		return x.Repositories
+	}
+	return nil
+}
+
+type ListUserRepositoriesRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Username       string                 `protobuf:"bytes,1,opt,name=username,proto3" json:"username,omitempty"`
+	Limit          int32                  `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
+	Page           int32                  `protobuf:"varint,3,opt,name=page,proto3" json:"page,omitempty"`
+	OrderBy        string                 `protobuf:"bytes,4,opt,name=order_by,json=orderBy,proto3" json:"order_by,omitempty"`
+	OrderDirection string                 `protobuf:"bytes,5,opt,name=order_direction,json=orderDirection,proto3" json:"order_direction,omitempty"`
+	Language       string                 `protobuf:"bytes,6,opt,name=language,proto3" json:"language,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *ListUserRepositoriesRequest) Reset() {
+	*x = ListUserRepositoriesRequest{}
+	mi := &file_users_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListUserRepositoriesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListUserRepositoriesRequest) ProtoMessage() {}
+
+func (x *ListUserRepositoriesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_users_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListUserRepositoriesRequest.ProtoReflect.Descriptor instead.
+func (*ListUserRepositoriesRequest) Descriptor() ([]byte, []int) {
+	return file_users_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *ListUserRepositoriesRequest) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+func (x *ListUserRepositoriesRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *ListUserRepositoriesRequest) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *ListUserRepositoriesRequest) GetOrderBy() string {
+	if x != nil {
+		return x.OrderBy
+	}
+	return ""
+}
+
+func (x *ListUserRepositoriesRequest) GetOrderDirection() string {
+	if x != nil {
+		return x.OrderDirection
+	}
+	return ""
+}
+
+func (x *ListUserRepositoriesRequest) GetLanguage() string {
+	if x != nil {
+		return x.Language
+	}
+	return ""
+}
+
+type RateLimitStatus struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Resource      string                 `protobuf:"bytes,1,opt,name=resource,proto3" json:"resource,omitempty"`
+	Limit         int32                  `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
+	Remaining     int32                  `protobuf:"varint,3,opt,name=remaining,proto3" json:"remaining,omitempty"`
+	Used          int32                  `protobuf:"varint,4,opt,name=used,proto3" json:"used,omitempty"`
+	ResetAt       int64                  `protobuf:"varint,5,opt,name=reset_at,json=resetAt,proto3" json:"reset_at,omitempty"`
+	BlockedUntil  int64                  `protobuf:"varint,6,opt,name=blocked_until,json=blockedUntil,proto3" json:"blocked_until,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RateLimitStatus) Reset() {
+	*x = RateLimitStatus{}
+	mi := &file_users_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RateLimitStatus) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RateLimitStatus) ProtoMessage() {}
+
+func (x *RateLimitStatus) ProtoReflect() protoreflect.Message {
+	mi := &file_users_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RateLimitStatus.ProtoReflect.Descriptor instead.
+func (*RateLimitStatus) Descriptor() ([]byte, []int) {
+	return file_users_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *RateLimitStatus) GetResource() string {
+	if x != nil {
+		return x.Resource
+	}
+	return ""
+}
+
+func (x *RateLimitStatus) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *RateLimitStatus) GetRemaining() int32 {
+	if x != nil {
+		return x.Remaining
+	}
+	return 0
+}
+
+func (x *RateLimitStatus) GetUsed() int32 {
+	if x != nil {
+		return x.Used
+	}
+	return 0
+}
+
+func (x *RateLimitStatus) GetResetAt() int64 {
+	if x != nil {
+		return x.ResetAt
+	}
+	return 0
+}
+
+func (x *RateLimitStatus) GetBlockedUntil() int64 {
+	if x != nil {
+		return x.BlockedUntil
+	}
+	return 0
+}
+
+type RateLimitList struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Resources     []*RateLimitStatus     `protobuf:"bytes,1,rep,name=resources,proto3" json:"resources,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RateLimitList) Reset() {
+	*x = RateLimitList{}
+	mi := &file_users_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RateLimitList) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RateLimitList) ProtoMessage() {}
+
+func (x *RateLimitList) ProtoReflect() protoreflect.Message {
+	mi := &file_users_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RateLimitList.ProtoReflect.Descriptor instead.
+func (*RateLimitList) Descriptor() ([]byte, []int) {
+	return file_users_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *RateLimitList) GetResources() []*RateLimitStatus {
+	if x != nil {
+		return x.Resources
+	}
+	return nil
+}
+
+var File_users_proto protoreflect.FileDescriptor
+
+const file_users_proto_rawDesc = "" +
+	"\n" +
+	"\vusers.proto\x12\x0egithubusers.v1\"\a\n" +
+	"\x05Empty\"\xe1\x05\n" +
+	"\x04User\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x14\n" +
+	"\x05login\x18\x02 \x01(\tR\x05login\x12\x17\n" +
+	"\anode_id\x18\x03 \x01(\tR\x06nodeId\x12\x1d\n" +
+	"\n" +
+	"avatar_url\x18\x04 \x01(\tR\tavatarUrl\x12\x10\n" +
+	"\x03url\x18\x05 \x01(\tR\x03url\x12\x19\n" +
+	"\bhtml_url\x18\x06 \x01(\tR\ahtmlUrl\x12\x12\n" +
+	"\x04type\x18\a \x01(\tR\x04type\x12$\n" +
+	"\x0euser_view_type\x18\b \x01(\tR\fuserViewType\x12\x1d\n" +
+	"\n" +
+	"site_admin\x18\t \x01(\bR\tsiteAdmin\x12\x12\n" +
+	"\x04name\x18\n" +
+	" \x01(\tR\x04name\x12\x18\n" +
+	"\acompany\x18\v \x01(\tR\acompany\x12\x12\n" +
+	"\x04blog\x18\f \x01(\tR\x04blog\x12\x1a\n" +
+	"\blocation\x18\r \x01(\tR\blocation\x12\x14\n" +
+	"\x05email\x18\x0e \x01(\tR\x05email\x12\x10\n" +
+	"\x03bio\x18\x0f \x01(\tR\x03bio\x12)\n" +
+	"\x10twitter_username\x18\x10 \x01(\tR\x0ftwitterUsername\x12!\n" +
+	"\fpublic_repos\x18\x11 \x01(\x05R\vpublicRepos\x12!\n" +
+	"\fpublic_gists\x18\x12 \x01(\x05R\vpublicGists\x12\x1c\n" +
+	"\tfollowers\x18\x13 \x01(\x05R\tfollowers\x12\x1c\n" +
+	"\tfollowing\x18\x14 \x01(\x05R\tfollowing\x12*\n" +
+	"\x11github_created_at\x18\x15 \x01(\x03R\x0fgithubCreatedAt\x12*\n" +
+	"\x11github_updated_at\x18\x16 \x01(\x03R\x0fgithubUpdatedAt\x12\x1d\n" +
+	"\n" +
+	"updated_at\x18\x17 \x01(\x03R\tupdatedAt\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\x18 \x01(\x03R\tcreatedAt\x12,\n" +
+	"\x12profile_fetched_at\x18\x19 \x01(\x03R\x10profileFetchedAt\"\xb9\x01\n" +
+	"\bUserList\x12*\n" +
+	"\x05users\x18\x01 \x03(\v2\x14.githubusers.v1.UserR\x05users\x12&\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\x12\x14\n" +
+	"\x05total\x18\x03 \x01(\x03R\x05total\x12\x12\n" +
+	"\x04page\x18\x04 \x01(\x05R\x04page\x12\x14\n" +
+	"\x05limit\x18\x05 \x01(\x05R\x05limit\x12\x19\n" +
+	"\bhas_more\x18\x06 \x01(\bR\ahasMore\"\xd5\x02\n" +
+	"\x10ListUsersRequest\x12\x14\n" +
+	"\x05limit\x18\x01 \x01(\x05R\x05limit\x12\x12\n" +
+	"\x04page\x18\x02 \x01(\x05R\x04page\x12\x19\n" +
+	"\border_by\x18\x03 \x01(\tR\aorderBy\x12'\n" +
+	"\x0forder_direction\x18\x04 \x01(\tR\x0eorderDirection\x12\"\n" +
+	"\n" +
+	"site_admin\x18\x05 \x01(\bH\x00R\tsiteAdmin\x88\x01\x01\x12\x12\n" +
+	"\x04type\x18\x06 \x01(\tR\x04type\x12!\n" +
+	"\flogin_prefix\x18\a \x01(\tR\vloginPrefix\x12#\n" +
+	"\rupdated_since\x18\b \x01(\x03R\fupdatedSince\x12%\n" +
+	"\x0eupdated_before\x18\t \x01(\x03R\rupdatedBefore\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\n" +
+	" \x01(\tR\tpageTokenB\r\n" +
+	"\v_site_admin\"n\n" +
+	"\x0eGetUserRequest\x12\x1a\n" +
+	"\busername\x18\x01 \x01(\tR\busername\x12&\n" +
+	"\x0fmax_age_seconds\x18\x02 \x01(\x03R\rmaxAgeSeconds\x12\x18\n" +
+	"\arefresh\x18\x03 \x01(\bR\arefresh\"\x83\x02\n" +
+	"\x11UpdateUserRequest\x12\x1a\n" +
+	"\busername\x18\x01 \x01(\tR\busername\x12\x14\n" +
+	"\x05login\x18\x02 \x01(\tR\x05login\x12\x17\n" +
+	"\anode_id\x18\x03 \x01(\tR\x06nodeId\x12\x1d\n" +
+	"\n" +
+	"avatar_url\x18\x04 \x01(\tR\tavatarUrl\x12\x10\n" +
+	"\x03url\x18\x05 \x01(\tR\x03url\x12\x19\n" +
+	"\bhtml_url\x18\x06 \x01(\tR\ahtmlUrl\x12\x12\n" +
+	"\x04type\x18\a \x01(\tR\x04type\x12$\n" +
+	"\x0euser_view_type\x18\b \x01(\tR\fuserViewType\x12\x1d\n" +
+	"\n" +
+	"site_admin\x18\t \x01(\bR\tsiteAdmin\"/\n" +
+	"\x11DeleteUserRequest\x12\x1a\n" +
+	"\busername\x18\x01 \x01(\tR\busername\"0\n" +
+	"\x12DeleteUserResponse\x12\x1a\n" +
+	"\busername\x18\x01 \x01(\tR\busername\"\x82\x01\n" +
+	"\x12SearchUsersRequest\x12\x14\n" +
+	"\x05query\x18\x01 \x01(\tR\x05query\x12\x12\n" +
+	"\x04sort\x18\x02 \x01(\tR\x04sort\x12\x14\n" +
+	"\x05order\x18\x03 \x01(\tR\x05order\x12\x12\n" +
+	"\x04page\x18\x04 \x01(\x05R\x04page\x12\x18\n" +
+	"\apersist\x18\x05 \x01(\bR\apersist\"\x91\x01\n" +
+	"\x13SearchUsersResponse\x12\x1f\n" +
+	"\vtotal_count\x18\x01 \x01(\x05R\n" +
+	"totalCount\x12-\n" +
+	"\x12incomplete_results\x18\x02 \x01(\bR\x11incompleteResults\x12*\n" +
+	"\x05users\x18\x03 \x03(\v2\x14.githubusers.v1.UserR\x05users\"\x9e\x01\n" +
+	"\x12ListFollowsRequest\x12\x1a\n" +
+	"\busername\x18\x01 \x01(\tR\busername\x12\x14\n" +
+	"\x05limit\x18\x02 \x01(\x05R\x05limit\x12\x12\n" +
+	"\x04page\x18\x03 \x01(\x05R\x04page\x12\x19\n" +
+	"\border_by\x18\x04 \x01(\tR\aorderBy\x12'\n" +
+	"\x0forder_direction\x18\x05 \x01(\tR\x0eorderDirection\"\xcf\x01\n" +
+	"\fOrganization\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x14\n" +
+	"\x05login\x18\x02 \x01(\tR\x05login\x12\x17\n" +
+	"\anode_id\x18\x03 \x01(\tR\x06nodeId\x12\x10\n" +
+	"\x03url\x18\x04 \x01(\tR\x03url\x12\x19\n" +
+	"\bhtml_url\x18\x05 \x01(\tR\ahtmlUrl\x12\x1d\n" +
+	"\n" +
+	"avatar_url\x18\x06 \x01(\tR\tavatarUrl\x12\x12\n" +
+	"\x04name\x18\a \x01(\tR\x04name\x12 \n" +
+	"\vdescription\x18\b \x01(\tR\vdescription\"V\n" +
+	"\x10OrganizationList\x12B\n" +
+	"\rorganizations\x18\x01 \x03(\v2\x1c.githubusers.v1.OrganizationR\rorganizations\"\xb0\x01\n" +
+	"\x1cListOrganizationUsersRequest\x12\"\n" +
+	"\forganization\x18\x01 \x01(\tR\forganization\x12\x14\n" +
+	"\x05limit\x18\x02 \x01(\x05R\x05limit\x12\x12\n" +
+	"\x04page\x18\x03 \x01(\x05R\x04page\x12\x19\n" +
+	"\border_by\x18\x04 \x01(\tR\aorderBy\x12'\n" +
+	"\x0forder_direction\x18\x05 \x01(\tR\x0eorderDirection\"\xa8\x01\n" +
+	"\x1cListUserOrganizationsRequest\x12\x1a\n" +
+	"\busername\x18\x01 \x01(\tR\busername\x12\x14\n" +
+	"\x05limit\x18\x02 \x01(\x05R\x05limit\x12\x12\n" +
+	"\x04page\x18\x03 \x01(\x05R\x04page\x12\x19\n" +
+	"\border_by\x18\x04 \x01(\tR\aorderBy\x12'\n" +
+	"\x0forder_direction\x18\x05 \x01(\tR\x0eorderDirection\"\xa6\x04\n" +
+	"\n" +
+	"Repository\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x19\n" +
+	"\bowner_id\x18\x02 \x01(\x03R\aownerId\x12\x1f\n" +
+	"\vowner_login\x18\x03 \x01(\tR\n" +
+	"ownerLogin\x12\x12\n" +
+	"\x04name\x18\x04 \x01(\tR\x04name\x12\x1b\n" +
+	"\tfull_name\x18\x05 \x01(\tR\bfullName\x12\x19\n" +
+	"\bhtml_url\x18\x06 \x01(\tR\ahtmlUrl\x12 \n" +
+	"\vdescription\x18\a \x01(\tR\vdescription\x12\x1a\n" +
+	"\blanguage\x18\b \x01(\tR\blanguage\x12\x12\n" +
+	"\x04fork\x18\t \x01(\bR\x04fork\x12\x1a\n" +
+	"\barchived\x18\n" +
+	" \x01(\bR\barchived\x12)\n" +
+	"\x10stargazers_count\x18\v \x01(\x05R\x0fstargazersCount\x12\x1f\n" +
+	"\vforks_count\x18\f \x01(\x05R\n" +
+	"forksCount\x12%\n" +
+	"\x0ewatchers_count\x18\r \x01(\x05R\rwatchersCount\x12*\n" +
+	"\x11open_issues_count\x18\x0e \x01(\x05R\x0fopenIssuesCount\x12\x1b\n" +
+	"\tpushed_at\x18\x0f \x01(\x03R\bpushedAt\x12*\n" +
+	"\x11github_created_at\x18\x10 \x01(\x03R\x0fgithubCreatedAt\x12*\n" +
+	"\x11github_updated_at\x18\x11 \x01(\x03R\x0fgithubUpdatedAt\"P\n" +
+	"\x0eRepositoryList\x12>\n" +
+	"\frepositories\x18\x01 \x03(\v2\x1a.githubusers.v1.RepositoryR\frepositories\"\xc3\x01\n" +
+	"\x1bListUserRepositoriesRequest\x12\x1a\n" +
+	"\busername\x18\x01 \x01(\tR\busername\x12\x14\n" +
+	"\x05limit\x18\x02 \x01(\x05R\x05limit\x12\x12\n" +
+	"\x04page\x18\x03 \x01(\x05R\x04page\x12\x19\n" +
+	"\border_by\x18\x04 \x01(\tR\aorderBy\x12'\n" +
+	"\x0forder_direction\x18\x05 \x01(\tR\x0eorderDirection\x12\x1a\n" +
+	"\blanguage\x18\x06 \x01(\tR\blanguage\"\xb5\x01\n" +
+	"\x0fRateLimitStatus\x12\x1a\n" +
+	"\bresource\x18\x01 \x01(\tR\bresource\x12\x14\n" +
+	"\x05limit\x18\x02 \x01(\x05R\x05limit\x12\x1c\n" +
+	"\tremaining\x18\x03 \x01(\x05R\tremaining\x12\x12\n" +
+	"\x04used\x18\x04 \x01(\x05R\x04used\x12\x19\n" +
+	"\breset_at\x18\x05 \x01(\x03R\aresetAt\x12#\n" +
+	"\rblocked_until\x18\x06 \x01(\x03R\fblockedUntil\"N\n" +
+	"\rRateLimitList\x12=\n" +
+	"\tresources\x18\x01 \x03(\v2\x1f.githubusers.v1.RateLimitStatusR\tresources2\x9f\a\n" +
 	"\vUserService\x12G\n" +
 	"\tListUsers\x12 .githubusers.v1.ListUsersRequest\x1a\x18.githubusers.v1.UserList\x12?\n" +
 	"\aGetUser\x12\x1e.githubusers.v1.GetUserRequest\x1a\x14.githubusers.v1.User\x12E\n" +
 	"\n" +
 	"UpdateUser\x12!.githubusers.v1.UpdateUserRequest\x1a\x14.githubusers.v1.User\x12S\n" +
 	"\n" +
-	"DeleteUser\x12!.githubusers.v1.DeleteUserRequest\x1a\".githubusers.v1.DeleteUserResponseBJZHgithub.com/unkabogaton/github-users/internal/infrastructure/grpc/gen;genb\x06proto3"
+	"DeleteUser\x12!.githubusers.v1.DeleteUserRequest\x1a\".githubusers.v1.DeleteUserResponse\x12V\n" +
+	"\vSearchUsers\x12\".githubusers.v1.SearchUsersRequest\x1a#.githubusers.v1.SearchUsersResponse\x12E\n" +
+	"\rGetRateLimits\x12\x15.githubusers.v1.Empty\x1a\x1d.githubusers.v1.RateLimitList\x12M\n" +
+	"\rListFollowers\x12\".githubusers.v1.ListFollowsRequest\x1a\x18.githubusers.v1.UserList\x12M\n" +
+	"\rListFollowing\x12\".githubusers.v1.ListFollowsRequest\x1a\x18.githubusers.v1.UserList\x12_\n" +
+	"\x15ListOrganizationUsers\x12,.githubusers.v1.ListOrganizationUsersRequest\x1a\x18.githubusers.v1.UserList\x12g\n" +
+	"\x15ListUserOrganizations\x12,.githubusers.v1.ListUserOrganizationsRequest\x1a .githubusers.v1.OrganizationList\x12c\n" +
+	"\x14ListUserRepositories\x12+.githubusers.v1.ListUserRepositoriesRequest\x1a\x1e.githubusers.v1.RepositoryListBJZHgithub.com/unkabogaton/github-users/internal/infrastructure/grpc/gen;genb\x06proto3"
 
 var (
 	file_users_proto_rawDescOnce sync.Once
@@ -580,32 +1872,62 @@ func file_users_proto_rawDescGZIP() []byte {
 	return file_users_proto_rawDescData
 }
 
-var file_users_proto_msgTypes = make([]protoimpl.MessageInfo, 8)
+var file_users_proto_msgTypes = make([]protoimpl.MessageInfo, 20)
 var file_users_proto_goTypes = []any{
-	(*Empty)(nil),              // 0: githubusers.v1.Empty
-	(*User)(nil),               // 1: githubusers.v1.User
-	(*UserList)(nil),           // 2: githubusers.v1.UserList
-	(*ListUsersRequest)(nil),   // 3: githubusers.v1.ListUsersRequest
-	(*GetUserRequest)(nil),     // 4: githubusers.v1.GetUserRequest
-	(*UpdateUserRequest)(nil),  // 5: githubusers.v1.UpdateUserRequest
-	(*DeleteUserRequest)(nil),  // 6: githubusers.v1.DeleteUserRequest
-	(*DeleteUserResponse)(nil), // 7: githubusers.v1.DeleteUserResponse
+	(*Empty)(nil),                        // 0: githubusers.v1.Empty
+	(*User)(nil),                         // 1: githubusers.v1.User
+	(*UserList)(nil),                     // 2: githubusers.v1.UserList
+	(*ListUsersRequest)(nil),             // 3: githubusers.v1.ListUsersRequest
+	(*GetUserRequest)(nil),               // 4: githubusers.v1.GetUserRequest
+	(*UpdateUserRequest)(nil),            // 5: githubusers.v1.UpdateUserRequest
+	(*DeleteUserRequest)(nil),            // 6: githubusers.v1.DeleteUserRequest
+	(*DeleteUserResponse)(nil),           // 7: githubusers.v1.DeleteUserResponse
+	(*SearchUsersRequest)(nil),           // 8: githubusers.v1.SearchUsersRequest
+	(*SearchUsersResponse)(nil),          // 9: githubusers.v1.SearchUsersResponse
+	(*ListFollowsRequest)(nil),           // 10: githubusers.v1.ListFollowsRequest
+	(*Organization)(nil),                 // 11: githubusers.v1.Organization
+	(*OrganizationList)(nil),             // 12: githubusers.v1.OrganizationList
+	(*ListOrganizationUsersRequest)(nil), // 13: githubusers.v1.ListOrganizationUsersRequest
+	(*ListUserOrganizationsRequest)(nil), // 14: githubusers.v1.ListUserOrganizationsRequest
+	(*Repository)(nil),                   // 15: githubusers.v1.Repository
+	(*RepositoryList)(nil),               // 16: githubusers.v1.RepositoryList
+	(*ListUserRepositoriesRequest)(nil),  // 17: githubusers.v1.ListUserRepositoriesRequest
+	(*RateLimitStatus)(nil),              // 18: githubusers.v1.RateLimitStatus
+	(*RateLimitList)(nil),                // 19: githubusers.v1.RateLimitList
 }
 var file_users_proto_depIdxs = []int32{
-	1, // 0: githubusers.v1.UserList.users:type_name -> githubusers.v1.User
-	3, // 1: githubusers.v1.UserService.ListUsers:input_type -> githubusers.v1.ListUsersRequest
-	4, // 2: githubusers.v1.UserService.GetUser:input_type -> githubusers.v1.GetUserRequest
-	5, // 3: githubusers.v1.UserService.UpdateUser:input_type -> githubusers.v1.UpdateUserRequest
-	6, // 4: githubusers.v1.UserService.DeleteUser:input_type -> githubusers.v1.DeleteUserRequest
-	2, // 5: githubusers.v1.UserService.ListUsers:output_type -> githubusers.v1.UserList
-	1, // 6: githubusers.v1.UserService.GetUser:output_type -> githubusers.v1.User
-	1, // 7: githubusers.v1.UserService.UpdateUser:output_type -> githubusers.v1.User
-	7, // 8: githubusers.v1.UserService.DeleteUser:output_type -> githubusers.v1.DeleteUserResponse
-	5, // [5:9] is the sub-list for method output_type
-	1, // [1:5] is the sub-list for method input_type
-	1, // [1:1] is the sub-list for extension type_name
-	1, // [1:1] is the sub-list for extension extendee
-	0, // [0:1] is the sub-list for field type_name
+	1,  // 0: githubusers.v1.UserList.users:type_name -> githubusers.v1.User
+	1,  // 1: githubusers.v1.SearchUsersResponse.users:type_name -> githubusers.v1.User
+	11, // 2: githubusers.v1.OrganizationList.organizations:type_name -> githubusers.v1.Organization
+	15, // 3: githubusers.v1.RepositoryList.repositories:type_name -> githubusers.v1.Repository
+	18, // 4: githubusers.v1.RateLimitList.resources:type_name -> githubusers.v1.RateLimitStatus
+	3,  // 5: githubusers.v1.UserService.ListUsers:input_type -> githubusers.v1.ListUsersRequest
+	4,  // 6: githubusers.v1.UserService.GetUser:input_type -> githubusers.v1.GetUserRequest
+	5,  // 7: githubusers.v1.UserService.UpdateUser:input_type -> githubusers.v1.UpdateUserRequest
+	6,  // 8: githubusers.v1.UserService.DeleteUser:input_type -> githubusers.v1.DeleteUserRequest
+	8,  // 9: githubusers.v1.UserService.SearchUsers:input_type -> githubusers.v1.SearchUsersRequest
+	0,  // 10: githubusers.v1.UserService.GetRateLimits:input_type -> githubusers.v1.Empty
+	10, // 11: githubusers.v1.UserService.ListFollowers:input_type -> githubusers.v1.ListFollowsRequest
+	10, // 12: githubusers.v1.UserService.ListFollowing:input_type -> githubusers.v1.ListFollowsRequest
+	13, // 13: githubusers.v1.UserService.ListOrganizationUsers:input_type -> githubusers.v1.ListOrganizationUsersRequest
+	14, // 14: githubusers.v1.UserService.ListUserOrganizations:input_type -> githubusers.v1.ListUserOrganizationsRequest
+	17, // 15: githubusers.v1.UserService.ListUserRepositories:input_type -> githubusers.v1.ListUserRepositoriesRequest
+	2,  // 16: githubusers.v1.UserService.ListUsers:output_type -> githubusers.v1.UserList
+	1,  // 17: githubusers.v1.UserService.GetUser:output_type -> githubusers.v1.User
+	1,  // 18: githubusers.v1.UserService.UpdateUser:output_type -> githubusers.v1.User
+	7,  // 19: githubusers.v1.UserService.DeleteUser:output_type -> githubusers.v1.DeleteUserResponse
+	9,  // 20: githubusers.v1.UserService.SearchUsers:output_type -> githubusers.v1.SearchUsersResponse
+	19, // 21: githubusers.v1.UserService.GetRateLimits:output_type -> githubusers.v1.RateLimitList
+	2,  // 22: githubusers.v1.UserService.ListFollowers:output_type -> githubusers.v1.UserList
+	2,  // 23: githubusers.v1.UserService.ListFollowing:output_type -> githubusers.v1.UserList
+	2,  // 24: githubusers.v1.UserService.ListOrganizationUsers:output_type -> githubusers.v1.UserList
+	12, // 25: githubusers.v1.UserService.ListUserOrganizations:output_type -> githubusers.v1.OrganizationList
+	16, // 26: githubusers.v1.UserService.ListUserRepositories:output_type -> githubusers.v1.RepositoryList
+	16, // [16:27] is the sub-list for method output_type
+	5,  // [5:16] is the sub-list for method input_type
+	5,  // [5:5] is the sub-list for extension type_name
+	5,  // [5:5] is the sub-list for extension extendee
+	0,  // [0:5] is the sub-list for field type_name
 }
 
 func init() { file_users_proto_init() }
@@ -613,13 +1935,14 @@ func file_users_proto_init() {
 	if File_users_proto != nil {
 		return
 	}
+	file_users_proto_msgTypes[3].OneofWrappers = []any{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_users_proto_rawDesc), len(file_users_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   8,
+			NumMessages:   20,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
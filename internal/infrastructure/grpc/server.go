@@ -3,6 +3,7 @@ package grpc
 import (
 	"context"
 	"net"
+	"time"
 
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/reflection"
@@ -15,11 +16,27 @@ import (
 
 type Server struct {
 	gen.UnimplementedUserServiceServer
-	userService interfaces.UserService
+	userService         interfaces.UserService
+	followService       interfaces.FollowService
+	organizationService interfaces.OrganizationService
+	repositoryService   interfaces.RepositoryService
+	gitHubClient        interfaces.GitHubClient
 }
 
-func NewServer(userService interfaces.UserService) *Server {
-	return &Server{userService: userService}
+func NewServer(
+	userService interfaces.UserService,
+	followService interfaces.FollowService,
+	organizationService interfaces.OrganizationService,
+	repositoryService interfaces.RepositoryService,
+	gitHubClient interfaces.GitHubClient,
+) *Server {
+	return &Server{
+		userService:         userService,
+		followService:       followService,
+		organizationService: organizationService,
+		repositoryService:   repositoryService,
+		gitHubClient:        gitHubClient,
+	}
 }
 
 func (server *Server) ListenAndServe(address string) error {
@@ -35,16 +52,58 @@ func (server *Server) ListenAndServe(address string) error {
 
 func mapUserEntityToProto(userEntity *entities.User) *gen.User {
 	return &gen.User{
-		Id:           int64(userEntity.ID),
-		Login:        userEntity.Login,
-		NodeId:       userEntity.NodeID,
-		AvatarUrl:    userEntity.AvatarURL,
-		Url:          userEntity.URL,
-		HtmlUrl:      userEntity.HTMLURL,
-		Type:         userEntity.Type,
-		UserViewType: userEntity.UserViewType,
-		SiteAdmin:    userEntity.SiteAdmin,
+		Id:               int64(userEntity.ID),
+		Login:            userEntity.Login,
+		NodeId:           userEntity.NodeID,
+		AvatarUrl:        userEntity.AvatarURL,
+		Url:              userEntity.URL,
+		HtmlUrl:          userEntity.HTMLURL,
+		Type:             userEntity.Type,
+		UserViewType:     userEntity.UserViewType,
+		SiteAdmin:        userEntity.SiteAdmin,
+		Name:             userEntity.Name,
+		Company:          userEntity.Company,
+		Blog:             userEntity.Blog,
+		Location:         userEntity.Location,
+		Email:            userEntity.Email,
+		Bio:              userEntity.Bio,
+		TwitterUsername:  userEntity.TwitterUsername,
+		PublicRepos:      int32(userEntity.PublicRepos),
+		PublicGists:      int32(userEntity.PublicGists),
+		Followers:        int32(userEntity.Followers),
+		Following:        int32(userEntity.Following),
+		GithubCreatedAt:  unixSeconds(userEntity.GitHubCreatedAt),
+		GithubUpdatedAt:  unixSeconds(userEntity.GitHubUpdatedAt),
+		UpdatedAt:        unixSeconds(&userEntity.UpdatedAt),
+		CreatedAt:        unixSeconds(&userEntity.CreatedAt),
+		ProfileFetchedAt: unixSeconds(userEntity.ProfileFetchedAt),
+	}
+}
+
+func unixSeconds(timestamp *time.Time) int64 {
+	if timestamp == nil || timestamp.IsZero() {
+		return 0
+	}
+	return timestamp.Unix()
+}
+
+// userListFiltersFromRequest maps the filter fields of req; zero timestamps
+// leave the bound open.
+func userListFiltersFromRequest(req *gen.ListUsersRequest) interfaces.UserListFilters {
+	userListFilters := interfaces.UserListFilters{
+		SiteAdmin:   req.SiteAdmin,
+		Type:        req.GetType(),
+		LoginPrefix: req.GetLoginPrefix(),
+	}
+	if updatedSince := req.GetUpdatedSince(); updatedSince != 0 {
+		timestamp := time.Unix(updatedSince, 0).UTC()
+		userListFilters.UpdatedSince = &timestamp
 	}
+	if updatedBefore := req.GetUpdatedBefore(); updatedBefore != 0 {
+		timestamp := time.Unix(updatedBefore, 0).UTC()
+		userListFilters.UpdatedBefore = &timestamp
+	}
+	return userListFilters
 }
 
 func (server *Server) ListUsers(
@@ -74,24 +133,37 @@ func (server *Server) ListUsers(
 		Page:           page,
 		OrderBy:        orderBy,
 		OrderDirection: orderDirection,
+		Filters:        userListFiltersFromRequest(req).Filters(),
+		Cursor:         req.GetPageToken(),
 	}
 
-	userEntities, err := server.userService.List(ctx, listOptions)
+	userPage, err := server.userService.ListPage(ctx, listOptions)
 	if err != nil {
 		return nil, err
 	}
 
-	protoUsers := make([]*gen.User, 0, len(userEntities))
-	for i := range userEntities {
-		protoUsers = append(protoUsers, mapUserEntityToProto(&userEntities[i]))
+	protoUsers := make([]*gen.User, 0, len(userPage.Users))
+	for i := range userPage.Users {
+		protoUsers = append(protoUsers, mapUserEntityToProto(&userPage.Users[i]))
 	}
 
-	return &gen.UserList{Users: protoUsers}, nil
+	return &gen.UserList{
+		Users:         protoUsers,
+		NextPageToken: userPage.NextCursor,
+		Total:         userPage.Total,
+		Page:          int32(userPage.Page),
+		Limit:         int32(userPage.Limit),
+		HasMore:       userPage.HasMore,
+	}, nil
 }
 
 func (server *Server) GetUser(ctx context.Context, request *gen.GetUserRequest) (*gen.User, error) {
 	username := request.GetUsername()
-	userEntity, err := server.userService.Get(ctx, username)
+	getUserOptions := interfaces.GetUserOptions{
+		MaxAge:  time.Duration(request.GetMaxAgeSeconds()) * time.Second,
+		Refresh: request.GetRefresh(),
+	}
+	userEntity, err := server.userService.Get(ctx, username, getUserOptions)
 	if err != nil {
 		return nil, err
 	}
@@ -130,3 +202,165 @@ func (server *Server) DeleteUser(ctx context.Context, request *gen.DeleteUserReq
 
 	return &gen.DeleteUserResponse{Username: username}, nil
 }
+
+func (server *Server) SearchUsers(ctx context.Context, request *gen.SearchUsersRequest) (*gen.SearchUsersResponse, error) {
+	searchRequest := interfaces.SearchUsersRequest{
+		Query:   request.GetQuery(),
+		Sort:    request.GetSort(),
+		Order:   request.GetOrder(),
+		Page:    int(request.GetPage()),
+		Persist: request.GetPersist(),
+	}
+
+	searchResult, err := server.userService.Search(ctx, searchRequest)
+	if err != nil {
+		return nil, err
+	}
+
+	protoUsers := make([]*gen.User, 0, len(searchResult.Users))
+	for i := range searchResult.Users {
+		protoUsers = append(protoUsers, mapUserEntityToProto(&searchResult.Users[i]))
+	}
+
+	return &gen.SearchUsersResponse{
+		TotalCount:        int32(searchResult.TotalCount),
+		IncompleteResults: searchResult.IncompleteResults,
+		Users:             protoUsers,
+	}, nil
+}
+
+func (server *Server) GetRateLimits(ctx context.Context, _ *gen.Empty) (*gen.RateLimitList, error) {
+	rateLimitStatuses := server.gitHubClient.RateLimits()
+
+	protoStatuses := make([]*gen.RateLimitStatus, 0, len(rateLimitStatuses))
+	for _, rateLimitStatus := range rateLimitStatuses {
+		protoStatuses = append(protoStatuses, &gen.RateLimitStatus{
+			Resource:     rateLimitStatus.Resource,
+			Limit:        int32(rateLimitStatus.Limit),
+			Remaining:    int32(rateLimitStatus.Remaining),
+			Used:         int32(rateLimitStatus.Used),
+			ResetAt:      rateLimitStatus.ResetAt.Unix(),
+			BlockedUntil: rateLimitStatus.BlockedUntil.Unix(),
+		})
+	}
+
+	return &gen.RateLimitList{Resources: protoStatuses}, nil
+}
+
+func (server *Server) ListFollowers(ctx context.Context, request *gen.ListFollowsRequest) (*gen.UserList, error) {
+	followers, err := server.followService.ListFollowers(ctx, request.GetUsername(), listFollowsOptions(request))
+	if err != nil {
+		return nil, err
+	}
+	return mapUserEntitiesToProto(followers), nil
+}
+
+func (server *Server) ListFollowing(ctx context.Context, request *gen.ListFollowsRequest) (*gen.UserList, error) {
+	following, err := server.followService.ListFollowing(ctx, request.GetUsername(), listFollowsOptions(request))
+	if err != nil {
+		return nil, err
+	}
+	return mapUserEntitiesToProto(following), nil
+}
+
+func listFollowsOptions(request *gen.ListFollowsRequest) interfaces.ListOptions {
+	return interfaces.ListOptions{
+		Limit:          int(request.GetLimit()),
+		Page:           int(request.GetPage()),
+		OrderBy:        request.GetOrderBy(),
+		OrderDirection: request.GetOrderDirection(),
+	}
+}
+
+func mapUserEntitiesToProto(userEntities []entities.User) *gen.UserList {
+	protoUsers := make([]*gen.User, 0, len(userEntities))
+	for i := range userEntities {
+		protoUsers = append(protoUsers, mapUserEntityToProto(&userEntities[i]))
+	}
+	return &gen.UserList{Users: protoUsers}
+}
+
+func (server *Server) ListOrganizationUsers(ctx context.Context, request *gen.ListOrganizationUsersRequest) (*gen.UserList, error) {
+	listOptions := interfaces.ListOptions{
+		Limit:          int(request.GetLimit()),
+		Page:           int(request.GetPage()),
+		OrderBy:        request.GetOrderBy(),
+		OrderDirection: request.GetOrderDirection(),
+	}
+
+	members, err := server.organizationService.ListMembers(ctx, request.GetOrganization(), listOptions)
+	if err != nil {
+		return nil, err
+	}
+	return mapUserEntitiesToProto(members), nil
+}
+
+func (server *Server) ListUserOrganizations(ctx context.Context, request *gen.ListUserOrganizationsRequest) (*gen.OrganizationList, error) {
+	listOptions := interfaces.ListOptions{
+		Limit:          int(request.GetLimit()),
+		Page:           int(request.GetPage()),
+		OrderBy:        request.GetOrderBy(),
+		OrderDirection: request.GetOrderDirection(),
+	}
+
+	organizations, err := server.organizationService.ListForUser(ctx, request.GetUsername(), listOptions)
+	if err != nil {
+		return nil, err
+	}
+
+	protoOrganizations := make([]*gen.Organization, 0, len(organizations))
+	for _, organization := range organizations {
+		protoOrganizations = append(protoOrganizations, &gen.Organization{
+			Id:          int64(organization.ID),
+			Login:       organization.Login,
+			NodeId:      organization.NodeID,
+			Url:         organization.URL,
+			HtmlUrl:     organization.HTMLURL,
+			AvatarUrl:   organization.AvatarURL,
+			Name:        organization.Name,
+			Description: organization.Description,
+		})
+	}
+	return &gen.OrganizationList{Organizations: protoOrganizations}, nil
+}
+
+func (server *Server) ListUserRepositories(ctx context.Context, request *gen.ListUserRepositoriesRequest) (*gen.RepositoryList, error) {
+	listOptions := interfaces.RepositoryListOptions{
+		ListOptions: interfaces.ListOptions{
+			Limit:          int(request.GetLimit()),
+			Page:           int(request.GetPage()),
+			OrderBy:        request.GetOrderBy(),
+			OrderDirection: request.GetOrderDirection(),
+		},
+		Language: request.GetLanguage(),
+	}
+
+	repositories, err := server.repositoryService.ListForUser(ctx, request.GetUsername(), listOptions)
+	if err != nil {
+		return nil, err
+	}
+
+	protoRepositories := make([]*gen.Repository, 0, len(repositories))
+	for _, repository := range repositories {
+		protoRepositories = append(protoRepositories, &gen.Repository{
+			Id:              int64(repository.ID),
+			OwnerId:         int64(repository.OwnerID),
+			OwnerLogin:      repository.OwnerLogin,
+			Name:            repository.Name,
+			FullName:        repository.FullName,
+			HtmlUrl:         repository.HTMLURL,
+			Description:     repository.Description,
+			Language:        repository.Language,
+			Fork:            repository.Fork,
+			Archived:        repository.Archived,
+			StargazersCount: int32(repository.StargazersCount),
+			ForksCount:      int32(repository.ForksCount),
+			WatchersCount:   int32(repository.WatchersCount),
+			OpenIssuesCount: int32(repository.OpenIssuesCount),
+			PushedAt:        unixSeconds(repository.PushedAt),
+			GithubCreatedAt: unixSeconds(repository.GitHubCreatedAt),
+			GithubUpdatedAt: unixSeconds(repository.GitHubUpdatedAt),
+		})
+	}
+	return &gen.RepositoryList{Repositories: protoRepositories}, nil
+}
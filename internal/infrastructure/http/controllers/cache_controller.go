@@ -0,0 +1,49 @@
+package controllers
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	derr "github.com/unkabogaton/github-users/internal/domain/errors"
+	"github.com/unkabogaton/github-users/internal/domain/interfaces"
+	"github.com/unkabogaton/github-users/internal/infrastructure/http/models"
+)
+
+type CacheController struct {
+	statsProvider interfaces.CacheStatsProvider
+	caches        map[string]interfaces.Cache
+}
+
+// NewCacheController reports statsProvider's totals and the key counts of
+// those caches that implement interfaces.CacheKeyCounter.
+func NewCacheController(
+	statsProvider interfaces.CacheStatsProvider,
+	caches map[string]interfaces.Cache,
+) *CacheController {
+	return &CacheController{statsProvider: statsProvider, caches: caches}
+}
+
+func (controller *CacheController) GetStats(ginContext *gin.Context) {
+	httpRequestContext := ginContext.Request.Context()
+
+	keyCounts := map[string]int64{}
+	for cacheName, cache := range controller.caches {
+		keyCounter, ok := cache.(interfaces.CacheKeyCounter)
+		if !ok {
+			continue
+		}
+		keyCount, countError := keyCounter.CountKeys(httpRequestContext)
+		if countError != nil {
+			_ = ginContext.Error(derr.Wrap(derr.ErrorCodeInternal, fmt.Sprintf("failed to count keys of cache %s", cacheName), countError))
+			return
+		}
+		keyCounts[cacheName] = keyCount
+	}
+
+	ginContext.JSON(http.StatusOK, models.CacheStatsResponse{
+		Caches: controller.statsProvider.CacheStats(),
+		Keys:   keyCounts,
+	})
+}
@@ -0,0 +1,79 @@
+package controllers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+
+	"github.com/unkabogaton/github-users/internal/domain/entities"
+	"github.com/unkabogaton/github-users/internal/domain/interfaces"
+	"github.com/unkabogaton/github-users/internal/infrastructure/http/middleware"
+	"github.com/unkabogaton/github-users/internal/infrastructure/http/models"
+)
+
+type fakeCacheStatsProvider struct{}
+
+func (fakeCacheStatsProvider) CacheStats() []interfaces.CacheStats {
+	return []interfaces.CacheStats{{Name: "redis", Hits: 3, Misses: 1, HitRatio: 0.75}}
+}
+
+type fakeCountingCache struct {
+	interfaces.Cache
+	keyCount int64
+	err      error
+}
+
+func (cache fakeCountingCache) CountKeys(ctx context.Context) (int64, error) {
+	return cache.keyCount, cache.err
+}
+
+type fakePlainCache struct{ interfaces.Cache }
+
+func (fakePlainCache) GetUser(ctx context.Context, login string) (*entities.User, bool, error) {
+	return nil, false, nil
+}
+
+func newCacheTestRouter(caches map[string]interfaces.Cache) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(middleware.ErrorHandlingMiddleware())
+	controller := NewCacheController(fakeCacheStatsProvider{}, caches)
+	router.GET("/admin/cache/stats", controller.GetStats)
+	return router
+}
+
+func TestGetCacheStats_OK(t *testing.T) {
+	t.Parallel()
+	router := newCacheTestRouter(map[string]interfaces.Cache{
+		"redis": fakeCountingCache{keyCount: 42},
+		"other": fakePlainCache{},
+	})
+
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/admin/cache/stats", nil))
+	require.Equal(t, http.StatusOK, recorder.Code)
+
+	var response models.CacheStatsResponse
+	require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &response))
+	require.Equal(t, map[string]int64{"redis": 42}, response.Keys)
+	require.Len(t, response.Caches, 1)
+	require.Equal(t, 0.75, response.Caches[0].HitRatio)
+	require.Contains(t, recorder.Body.String(), `"hit_ratio":0.75`)
+}
+
+func TestGetCacheStats_CountError(t *testing.T) {
+	t.Parallel()
+	router := newCacheTestRouter(map[string]interfaces.Cache{
+		"redis": fakeCountingCache{err: errors.New("connection refused")},
+	})
+
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/admin/cache/stats", nil))
+	require.Equal(t, http.StatusInternalServerError, recorder.Code)
+}
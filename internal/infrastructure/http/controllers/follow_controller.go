@@ -0,0 +1,51 @@
+package controllers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/unkabogaton/github-users/internal/domain/interfaces"
+)
+
+type FollowController struct {
+	followService interfaces.FollowService
+}
+
+func NewFollowController(followService interfaces.FollowService) *FollowController {
+	return &FollowController{followService: followService}
+}
+
+func (controller *FollowController) ListFollowers(ginContext *gin.Context) {
+	httpRequestContext := ginContext.Request.Context()
+	usernameParameter := ginContext.Param("username")
+
+	followers, listError := controller.followService.ListFollowers(
+		httpRequestContext,
+		usernameParameter,
+		listOptionsFromQuery(ginContext),
+	)
+	if listError != nil {
+		_ = ginContext.Error(listError)
+		return
+	}
+
+	ginContext.JSON(http.StatusOK, followers)
+}
+
+func (controller *FollowController) ListFollowing(ginContext *gin.Context) {
+	httpRequestContext := ginContext.Request.Context()
+	usernameParameter := ginContext.Param("username")
+
+	following, listError := controller.followService.ListFollowing(
+		httpRequestContext,
+		usernameParameter,
+		listOptionsFromQuery(ginContext),
+	)
+	if listError != nil {
+		_ = ginContext.Error(listError)
+		return
+	}
+
+	ginContext.JSON(http.StatusOK, following)
+}
@@ -0,0 +1,74 @@
+package controllers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+
+	"github.com/unkabogaton/github-users/internal/domain/entities"
+	domainErrors "github.com/unkabogaton/github-users/internal/domain/errors"
+	"github.com/unkabogaton/github-users/internal/domain/interfaces"
+	"github.com/unkabogaton/github-users/internal/infrastructure/http/middleware"
+)
+
+type fakeFollowService struct {
+	options interfaces.ListOptions
+}
+
+func (f *fakeFollowService) ListFollowers(ctx context.Context, username string, options interfaces.ListOptions) ([]entities.User, error) {
+	f.options = options
+	if username != "sample_username" {
+		return nil, domainErrors.New(domainErrors.ErrorCodeNotFound, "user not found")
+	}
+	return []entities.User{{ID: 2, Login: "follower"}}, nil
+}
+
+func (f *fakeFollowService) ListFollowing(ctx context.Context, username string, options interfaces.ListOptions) ([]entities.User, error) {
+	f.options = options
+	return []entities.User{{ID: 3, Login: "followed"}}, nil
+}
+
+func newFollowTestRouter(followService interfaces.FollowService) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(middleware.ErrorHandlingMiddleware())
+	controller := NewFollowController(followService)
+	router.GET("/users/:username/followers", controller.ListFollowers)
+	router.GET("/users/:username/following", controller.ListFollowing)
+	return router
+}
+
+func TestListFollowers_OK(t *testing.T) {
+	t.Parallel()
+	followService := &fakeFollowService{}
+	router := newFollowTestRouter(followService)
+
+	request := httptest.NewRequest(http.MethodGet, "/users/sample_username/followers?limit=5&page=2&orderby=login", nil)
+	recorder := httptest.NewRecorder()
+
+	router.ServeHTTP(recorder, request)
+
+	require.Equal(t, http.StatusOK, recorder.Code)
+	require.Equal(t, interfaces.ListOptions{Limit: 5, Page: 2, OrderBy: "login", OrderDirection: "asc"}, followService.options)
+
+	var followers []entities.User
+	require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &followers))
+	require.Equal(t, "follower", followers[0].Login)
+}
+
+func TestListFollowers_UnknownUser(t *testing.T) {
+	t.Parallel()
+	router := newFollowTestRouter(&fakeFollowService{})
+
+	request := httptest.NewRequest(http.MethodGet, "/users/ghost/followers", nil)
+	recorder := httptest.NewRecorder()
+
+	router.ServeHTTP(recorder, request)
+
+	require.Equal(t, http.StatusNotFound, recorder.Code)
+}
@@ -0,0 +1,49 @@
+package controllers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/unkabogaton/github-users/internal/domain/interfaces"
+)
+
+type GitHubController struct {
+	userService  interfaces.UserService
+	gitHubClient interfaces.GitHubClient
+}
+
+func NewGitHubController(userService interfaces.UserService, gitHubClient interfaces.GitHubClient) *GitHubController {
+	return &GitHubController{userService: userService, gitHubClient: gitHubClient}
+}
+
+func (controller *GitHubController) GetRateLimits(ginContext *gin.Context) {
+	ginContext.JSON(http.StatusOK, controller.gitHubClient.RateLimits())
+}
+
+func (controller *GitHubController) SearchUsers(ginContext *gin.Context) {
+	httpRequestContext := ginContext.Request.Context()
+
+	searchPage := 1
+	if parsedPage, parseError := strconv.Atoi(ginContext.Query("page")); parseError == nil {
+		searchPage = parsedPage
+	}
+	persistMatches, _ := strconv.ParseBool(ginContext.DefaultQuery("persist", "false"))
+
+	searchRequest := interfaces.SearchUsersRequest{
+		Query:   ginContext.Query("q"),
+		Sort:    ginContext.Query("sort"),
+		Order:   ginContext.Query("order"),
+		Page:    searchPage,
+		Persist: persistMatches,
+	}
+
+	searchResult, searchError := controller.userService.Search(httpRequestContext, searchRequest)
+	if searchError != nil {
+		_ = ginContext.Error(searchError)
+		return
+	}
+
+	ginContext.JSON(http.StatusOK, searchResult)
+}
@@ -0,0 +1,119 @@
+package controllers
+
+import (
+	"context"
+	"encoding/json"
+	"iter"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+
+	"github.com/unkabogaton/github-users/internal/domain/entities"
+	"github.com/unkabogaton/github-users/internal/domain/interfaces"
+	"github.com/unkabogaton/github-users/internal/infrastructure/http/middleware"
+)
+
+type fakeGitHubClient struct{}
+
+func (f *fakeGitHubClient) FetchUsersSince(ctx context.Context, lastUserID, resultsPerPage int) ([]entities.GitHubUser, error) {
+	return nil, nil
+}
+
+func (f *fakeGitHubClient) IterateUsersSince(ctx context.Context, lastUserID, resultsPerPage int) iter.Seq2[[]entities.GitHubUser, error] {
+	return func(yield func([]entities.GitHubUser, error) bool) {}
+}
+
+func (f *fakeGitHubClient) FetchOne(ctx context.Context, username string) (*entities.GitHubUser, error) {
+	return &entities.GitHubUser{ID: 1, Login: username}, nil
+}
+
+func (f *fakeGitHubClient) FetchFollowers(ctx context.Context, username string) ([]entities.GitHubUser, error) {
+	return nil, nil
+}
+
+func (f *fakeGitHubClient) FetchFollowing(ctx context.Context, username string) ([]entities.GitHubUser, error) {
+	return nil, nil
+}
+
+func (f *fakeGitHubClient) FetchOrganization(ctx context.Context, organizationLogin string) (*entities.GitHubOrganization, error) {
+	return nil, nil
+}
+
+func (f *fakeGitHubClient) FetchUserOrganizations(ctx context.Context, username string) ([]entities.GitHubOrganization, error) {
+	return nil, nil
+}
+
+func (f *fakeGitHubClient) FetchOrganizationMembers(ctx context.Context, organizationLogin string) ([]entities.GitHubUser, error) {
+	return nil, nil
+}
+
+func (f *fakeGitHubClient) FetchUserRepos(ctx context.Context, username string) ([]entities.GitHubRepository, error) {
+	return nil, nil
+}
+
+func (f *fakeGitHubClient) SearchUsers(ctx context.Context, query, sort, order string, page int) (*entities.GitHubUserSearchResult, error) {
+	return &entities.GitHubUserSearchResult{}, nil
+}
+
+func (f *fakeGitHubClient) RateLimits() []interfaces.RateLimitStatus {
+	return []interfaces.RateLimitStatus{{Resource: "core", Limit: 5000, Remaining: 4999, Used: 1}}
+}
+
+func newGitHubTestRouter() *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(middleware.ErrorHandlingMiddleware())
+	controller := NewGitHubController(&fakeUserService{}, &fakeGitHubClient{})
+	router.GET("/github/rate-limit", controller.GetRateLimits)
+	router.GET("/github/search/users", controller.SearchUsers)
+	return router
+}
+
+func TestGetRateLimits_OK(t *testing.T) {
+	t.Parallel()
+	router := newGitHubTestRouter()
+
+	request := httptest.NewRequest(http.MethodGet, "/github/rate-limit", nil)
+	recorder := httptest.NewRecorder()
+
+	router.ServeHTTP(recorder, request)
+
+	require.Equal(t, http.StatusOK, recorder.Code)
+
+	var statuses []interfaces.RateLimitStatus
+	require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &statuses))
+	require.Len(t, statuses, 1)
+	require.Equal(t, 4999, statuses[0].Remaining)
+}
+
+func TestSearchUsers_OK(t *testing.T) {
+	t.Parallel()
+	router := newGitHubTestRouter()
+
+	request := httptest.NewRequest(http.MethodGet, "/github/search/users?q=octocat&sort=followers&persist=true", nil)
+	recorder := httptest.NewRecorder()
+
+	router.ServeHTTP(recorder, request)
+
+	require.Equal(t, http.StatusOK, recorder.Code)
+
+	var searchResult interfaces.SearchUsersResult
+	require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &searchResult))
+	require.Equal(t, 1, searchResult.TotalCount)
+	require.Equal(t, "octocat", searchResult.Users[0].Login)
+}
+
+func TestSearchUsers_MissingQuery(t *testing.T) {
+	t.Parallel()
+	router := newGitHubTestRouter()
+
+	request := httptest.NewRequest(http.MethodGet, "/github/search/users", nil)
+	recorder := httptest.NewRecorder()
+
+	router.ServeHTTP(recorder, request)
+
+	require.Equal(t, http.StatusBadRequest, recorder.Code)
+}
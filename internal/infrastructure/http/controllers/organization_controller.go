@@ -0,0 +1,51 @@
+package controllers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/unkabogaton/github-users/internal/domain/interfaces"
+)
+
+type OrganizationController struct {
+	organizationService interfaces.OrganizationService
+}
+
+func NewOrganizationController(organizationService interfaces.OrganizationService) *OrganizationController {
+	return &OrganizationController{organizationService: organizationService}
+}
+
+func (controller *OrganizationController) ListMembers(ginContext *gin.Context) {
+	httpRequestContext := ginContext.Request.Context()
+	organizationParameter := ginContext.Param("org")
+
+	members, listError := controller.organizationService.ListMembers(
+		httpRequestContext,
+		organizationParameter,
+		listOptionsFromQuery(ginContext),
+	)
+	if listError != nil {
+		_ = ginContext.Error(listError)
+		return
+	}
+
+	ginContext.JSON(http.StatusOK, members)
+}
+
+func (controller *OrganizationController) ListUserOrganizations(ginContext *gin.Context) {
+	httpRequestContext := ginContext.Request.Context()
+	usernameParameter := ginContext.Param("username")
+
+	organizations, listError := controller.organizationService.ListForUser(
+		httpRequestContext,
+		usernameParameter,
+		listOptionsFromQuery(ginContext),
+	)
+	if listError != nil {
+		_ = ginContext.Error(listError)
+		return
+	}
+
+	ginContext.JSON(http.StatusOK, organizations)
+}
@@ -0,0 +1,70 @@
+package controllers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+
+	"github.com/unkabogaton/github-users/internal/domain/entities"
+	domainErrors "github.com/unkabogaton/github-users/internal/domain/errors"
+	"github.com/unkabogaton/github-users/internal/domain/interfaces"
+	"github.com/unkabogaton/github-users/internal/infrastructure/http/middleware"
+)
+
+type fakeOrganizationService struct{}
+
+func (f *fakeOrganizationService) ListMembers(ctx context.Context, organizationLogin string, options interfaces.ListOptions) ([]entities.User, error) {
+	if organizationLogin != "github" {
+		return nil, domainErrors.New(domainErrors.ErrorCodeNotFound, "organization not found")
+	}
+	return []entities.User{{ID: 1, Login: "octocat"}}, nil
+}
+
+func (f *fakeOrganizationService) ListForUser(ctx context.Context, username string, options interfaces.ListOptions) ([]entities.Organization, error) {
+	return []entities.Organization{{ID: 9919, Login: "github"}}, nil
+}
+
+func newOrganizationTestRouter() *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(middleware.ErrorHandlingMiddleware())
+	controller := NewOrganizationController(&fakeOrganizationService{})
+	router.GET("/orgs/:org/users", controller.ListMembers)
+	router.GET("/users/:username/orgs", controller.ListUserOrganizations)
+	return router
+}
+
+func TestListOrganizationMembers(t *testing.T) {
+	t.Parallel()
+	router := newOrganizationTestRouter()
+
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/orgs/github/users", nil))
+	require.Equal(t, http.StatusOK, recorder.Code)
+
+	var members []entities.User
+	require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &members))
+	require.Equal(t, "octocat", members[0].Login)
+
+	recorder = httptest.NewRecorder()
+	router.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/orgs/gitlab/users", nil))
+	require.Equal(t, http.StatusNotFound, recorder.Code)
+}
+
+func TestListUserOrganizations(t *testing.T) {
+	t.Parallel()
+	router := newOrganizationTestRouter()
+
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/users/octocat/orgs", nil))
+	require.Equal(t, http.StatusOK, recorder.Code)
+
+	var organizations []entities.Organization
+	require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &organizations))
+	require.Equal(t, "github", organizations[0].Login)
+}
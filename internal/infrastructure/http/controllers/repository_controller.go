@@ -0,0 +1,46 @@
+package controllers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/unkabogaton/github-users/internal/domain/interfaces"
+)
+
+type RepositoryController struct {
+	repositoryService interfaces.RepositoryService
+}
+
+func NewRepositoryController(repositoryService interfaces.RepositoryService) *RepositoryController {
+	return &RepositoryController{repositoryService: repositoryService}
+}
+
+func (controller *RepositoryController) ListUserRepositories(ginContext *gin.Context) {
+	httpRequestContext := ginContext.Request.Context()
+	usernameParameter := ginContext.Param("username")
+
+	listOptions := interfaces.RepositoryListOptions{
+		ListOptions: listOptionsFromQuery(ginContext),
+		Language:    ginContext.Query("language"),
+	}
+	// Leave the ordering to the service, which ranks by stars by default.
+	if ginContext.Query("orderby") == "" {
+		listOptions.OrderBy = ""
+		if ginContext.Query("order") == "" {
+			listOptions.OrderDirection = ""
+		}
+	}
+
+	repositories, listError := controller.repositoryService.ListForUser(
+		httpRequestContext,
+		usernameParameter,
+		listOptions,
+	)
+	if listError != nil {
+		_ = ginContext.Error(listError)
+		return
+	}
+
+	ginContext.JSON(http.StatusOK, repositories)
+}
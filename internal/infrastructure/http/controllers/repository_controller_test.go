@@ -0,0 +1,46 @@
+package controllers
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+
+	"github.com/unkabogaton/github-users/internal/domain/entities"
+	"github.com/unkabogaton/github-users/internal/domain/interfaces"
+)
+
+type fakeRepositoryService struct {
+	options interfaces.RepositoryListOptions
+}
+
+func (f *fakeRepositoryService) ListForUser(ctx context.Context, username string, options interfaces.RepositoryListOptions) ([]entities.Repository, error) {
+	f.options = options
+	return []entities.Repository{{ID: 1, OwnerLogin: username}}, nil
+}
+
+func TestListUserRepositories_PassesFilters(t *testing.T) {
+	t.Parallel()
+	gin.SetMode(gin.TestMode)
+	repositoryService := &fakeRepositoryService{}
+	router := gin.New()
+	router.GET("/users/:username/repos", NewRepositoryController(repositoryService).ListUserRepositories)
+
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/users/octocat/repos?language=Go&limit=5", nil))
+
+	require.Equal(t, http.StatusOK, recorder.Code)
+	require.Equal(t, "Go", repositoryService.options.Language)
+	require.Equal(t, 5, repositoryService.options.Limit)
+	require.Empty(t, repositoryService.options.OrderBy)
+
+	recorder = httptest.NewRecorder()
+	router.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/users/octocat/repos?orderby=forks", nil))
+
+	require.Equal(t, http.StatusOK, recorder.Code)
+	require.Equal(t, "forks", repositoryService.options.OrderBy)
+	require.Equal(t, "asc", repositoryService.options.OrderDirection)
+}
@@ -3,9 +3,12 @@ package controllers
 import (
 	"database/sql"
 	"errors"
+	"fmt"
 	"net/http"
+	"net/url"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/gin-gonic/gin"
 
@@ -24,6 +27,73 @@ func NewUserController(userService interfaces.UserService) *UserController {
 func (controller *UserController) ListUsers(ginContext *gin.Context) {
 	httpRequestContext := ginContext.Request.Context()
 
+	userListFilters, filtersError := userListFiltersFromQuery(ginContext)
+	if filtersError != nil {
+		_ = ginContext.Error(filtersError)
+		return
+	}
+	listOptions := listOptionsFromQuery(ginContext)
+	listOptions.Filters = userListFilters.Filters()
+
+	// A cursor parameter, empty for the first page, selects keyset paging.
+	cursor, cursorPaging := ginContext.GetQuery("cursor")
+	listOptions.Cursor = cursor
+	userPage, userPageError := controller.userService.ListPage(httpRequestContext, listOptions)
+	if userPageError != nil {
+		_ = ginContext.Error(userPageError)
+		return
+	}
+
+	ginContext.Header("X-Total-Count", strconv.FormatInt(userPage.Total, 10))
+	if linkHeader := paginationLinks(ginContext.Request.URL, userPage, cursorPaging); linkHeader != "" {
+		ginContext.Header("Link", linkHeader)
+	}
+	ginContext.JSON(http.StatusOK, userPage)
+}
+
+// paginationLinks formats the RFC 8288 Link header of a listing page. Pages
+// read by cursor only link to the next page, since a cursor cannot be walked
+// backwards; offset pages also link to the first, previous and last page.
+func paginationLinks(requestURL *url.URL, userPage *interfaces.UserPage, cursorPaging bool) string {
+	link := func(relation string, parameter string, value string) string {
+		query := requestURL.Query()
+		query.Set(parameter, value)
+		if parameter == "cursor" {
+			query.Del("page")
+		}
+		target := url.URL{Path: requestURL.Path, RawQuery: query.Encode()}
+		return fmt.Sprintf("<%s>; rel=%q", target.String(), relation)
+	}
+
+	var links []string
+	if cursorPaging {
+		if userPage.NextCursor != "" {
+			links = append(links, link("next", "cursor", userPage.NextCursor))
+		}
+		return strings.Join(links, ", ")
+	}
+
+	lastPage := int64(1)
+	if userPage.Limit > 0 && userPage.Total > 0 {
+		lastPage = (userPage.Total + int64(userPage.Limit) - 1) / int64(userPage.Limit)
+	}
+	if userPage.HasMore {
+		links = append(links, link("next", "page", strconv.Itoa(userPage.Page+1)))
+	}
+	if userPage.Page > 1 {
+		previousPage := min(int64(userPage.Page-1), lastPage)
+		links = append(links, link("prev", "page", strconv.FormatInt(previousPage, 10)))
+	}
+	links = append(links,
+		link("first", "page", "1"),
+		link("last", "page", strconv.FormatInt(lastPage, 10)),
+	)
+	return strings.Join(links, ", ")
+}
+
+// listOptionsFromQuery reads the limit, page, orderby and order query
+// parameters shared by the list endpoints.
+func listOptionsFromQuery(ginContext *gin.Context) interfaces.ListOptions {
 	defaultLimit := 10
 	defaultPage := 1
 	sortColumn := ginContext.DefaultQuery("orderby", "id")
@@ -43,27 +113,59 @@ func (controller *UserController) ListUsers(ginContext *gin.Context) {
 		}
 	}
 
-	listOptions := interfaces.ListOptions{
+	return interfaces.ListOptions{
 		Limit:          defaultLimit,
 		Page:           defaultPage,
 		OrderBy:        sortColumn,
 		OrderDirection: sortDirection,
 	}
+}
 
-	userList, userListError := controller.userService.List(httpRequestContext, listOptions)
-	if userListError != nil {
-		_ = ginContext.Error(userListError)
-		return
+// userListFiltersFromQuery reads the site_admin, type, login_prefix,
+// updated_since and updated_before query parameters of GET /users. Unlike the
+// paging parameters, malformed filters are rejected: ignoring them would
+// return users the caller asked to leave out.
+func userListFiltersFromQuery(ginContext *gin.Context) (interfaces.UserListFilters, error) {
+	userListFilters := interfaces.UserListFilters{
+		Type:        ginContext.Query("type"),
+		LoginPrefix: ginContext.Query("login_prefix"),
 	}
 
-	ginContext.JSON(http.StatusOK, userList)
+	if siteAdminQueryValue := ginContext.Query("site_admin"); siteAdminQueryValue != "" {
+		siteAdmin, parseError := strconv.ParseBool(siteAdminQueryValue)
+		if parseError != nil {
+			return interfaces.UserListFilters{}, domainErrors.Wrap(domainErrors.ErrorCodeValidation, "site_admin must be true or false", parseError)
+		}
+		userListFilters.SiteAdmin = &siteAdmin
+	}
+
+	for queryParameter, target := range map[string]**time.Time{
+		"updated_since":  &userListFilters.UpdatedSince,
+		"updated_before": &userListFilters.UpdatedBefore,
+	} {
+		if timestampQueryValue := ginContext.Query(queryParameter); timestampQueryValue != "" {
+			timestamp, parseError := time.Parse(time.RFC3339, timestampQueryValue)
+			if parseError != nil {
+				return interfaces.UserListFilters{}, domainErrors.Wrap(domainErrors.ErrorCodeValidation, queryParameter+" must be an RFC 3339 timestamp", parseError)
+			}
+			*target = &timestamp
+		}
+	}
+
+	return userListFilters, nil
 }
 
 func (controller *UserController) GetUser(ginContext *gin.Context) {
 	httpRequestContext := ginContext.Request.Context()
 	usernameParameter := ginContext.Param("username")
 
-	userEntity, getUserError := controller.userService.Get(httpRequestContext, usernameParameter)
+	var getUserOptions interfaces.GetUserOptions
+	if maxAgeSeconds, parseError := strconv.Atoi(ginContext.Query("max_age")); parseError == nil {
+		getUserOptions.MaxAge = time.Duration(maxAgeSeconds) * time.Second
+	}
+	getUserOptions.Refresh, _ = strconv.ParseBool(ginContext.DefaultQuery("refresh", "false"))
+
+	userEntity, getUserError := controller.userService.Get(httpRequestContext, usernameParameter, getUserOptions)
 	if getUserError != nil {
 		if domainErrors.IsCode(getUserError, domainErrors.ErrorCodeNotFound) {
 			_ = ginContext.Error(getUserError)
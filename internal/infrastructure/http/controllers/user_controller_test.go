@@ -2,21 +2,25 @@ package controllers
 
 import (
 	"context"
+	"encoding/json"
 	"net/http"
 	"net/http/httptest"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/require"
 
 	"github.com/unkabogaton/github-users/internal/domain/entities"
+	domainErrors "github.com/unkabogaton/github-users/internal/domain/errors"
 	"github.com/unkabogaton/github-users/internal/domain/interfaces"
+	"github.com/unkabogaton/github-users/internal/infrastructure/http/middleware"
 )
 
 type fakeUserService struct{}
 
-func (f *fakeUserService) Get(ctx context.Context, username string) (*entities.User, error) {
+func (f *fakeUserService) Get(ctx context.Context, username string, options interfaces.GetUserOptions) (*entities.User, error) {
 	return &entities.User{ID: 1, Login: username}, nil
 }
 
@@ -24,12 +28,26 @@ func (f *fakeUserService) List(ctx context.Context, _ interfaces.ListOptions) ([
 	return []entities.User{{ID: 1, Login: "sample_username"}}, nil
 }
 
+func (f *fakeUserService) ListPage(ctx context.Context, _ interfaces.ListOptions) (*interfaces.UserPage, error) {
+	return &interfaces.UserPage{Users: []entities.User{{ID: 1, Login: "sample_username"}}, NextCursor: "next"}, nil
+}
+
 func (f *fakeUserService) Update(ctx context.Context, username string, update interfaces.UpdateUserRequest) (*entities.User, error) {
 	return &entities.User{ID: 1, Login: update.Login}, nil
 }
 
 func (f *fakeUserService) Delete(ctx context.Context, username string) error { return nil }
 
+func (f *fakeUserService) Search(ctx context.Context, request interfaces.SearchUsersRequest) (*interfaces.SearchUsersResult, error) {
+	if request.Query == "" {
+		return nil, domainErrors.New(domainErrors.ErrorCodeValidation, "search query is required")
+	}
+	return &interfaces.SearchUsersResult{
+		TotalCount: 1,
+		Users:      []entities.User{{ID: 1, Login: request.Query}},
+	}, nil
+}
+
 func newTestRouter() *gin.Engine {
 	gin.SetMode(gin.TestMode)
 	router := gin.New()
@@ -53,6 +71,61 @@ func TestListUsers_OK(t *testing.T) {
 	require.Equal(t, http.StatusOK, recorder.Code)
 }
 
+func TestListUsers_Cursor(t *testing.T) {
+	t.Parallel()
+	router := newTestRouter()
+
+	request := httptest.NewRequest(http.MethodGet, "/users?cursor=", nil)
+	recorder := httptest.NewRecorder()
+
+	router.ServeHTTP(recorder, request)
+
+	require.Equal(t, http.StatusOK, recorder.Code)
+	var page interfaces.UserPage
+	require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &page))
+	require.Len(t, page.Users, 1)
+	require.Equal(t, "next", page.NextCursor)
+}
+
+type pagedUserService struct {
+	fakeUserService
+	userPage interfaces.UserPage
+}
+
+func (f *pagedUserService) ListPage(ctx context.Context, _ interfaces.ListOptions) (*interfaces.UserPage, error) {
+	userPage := f.userPage
+	return &userPage, nil
+}
+
+func TestListUsers_PaginationHeaders(t *testing.T) {
+	t.Parallel()
+	gin.SetMode(gin.TestMode)
+	userService := &pagedUserService{userPage: interfaces.UserPage{Total: 25, Page: 2, Limit: 10, HasMore: true, NextCursor: "position"}}
+	router := gin.New()
+	router.GET("/users", NewUserController(userService).ListUsers)
+
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/users?limit=10&page=2&type=User", nil))
+	require.Equal(t, http.StatusOK, recorder.Code)
+	require.Equal(t, "25", recorder.Header().Get("X-Total-Count"))
+	require.Equal(t,
+		`</users?limit=10&page=3&type=User>; rel="next", `+
+			`</users?limit=10&page=1&type=User>; rel="prev", `+
+			`</users?limit=10&page=1&type=User>; rel="first", `+
+			`</users?limit=10&page=3&type=User>; rel="last"`,
+		recorder.Header().Get("Link"))
+
+	var page interfaces.UserPage
+	require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &page))
+	require.EqualValues(t, 25, page.Total)
+	require.True(t, page.HasMore)
+
+	recorder = httptest.NewRecorder()
+	router.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/users?cursor=&page=4", nil))
+	require.Equal(t, http.StatusOK, recorder.Code)
+	require.Equal(t, `</users?cursor=position>; rel="next"`, recorder.Header().Get("Link"))
+}
+
 func TestListUsers_InvalidLimit(t *testing.T) {
 	router := newTestRouter()
 
@@ -77,6 +150,45 @@ func TestListUsers_InvalidPage(t *testing.T) {
 	require.Equal(t, http.StatusOK, recorder.Code)
 }
 
+type listRecordingUserService struct {
+	fakeUserService
+	listOptions interfaces.ListOptions
+}
+
+func (f *listRecordingUserService) ListPage(ctx context.Context, options interfaces.ListOptions) (*interfaces.UserPage, error) {
+	f.listOptions = options
+	return &interfaces.UserPage{}, nil
+}
+
+func TestListUsers_Filters(t *testing.T) {
+	t.Parallel()
+	gin.SetMode(gin.TestMode)
+	userService := &listRecordingUserService{}
+	router := gin.New()
+	router.Use(middleware.ErrorHandlingMiddleware())
+	router.GET("/users", NewUserController(userService).ListUsers)
+
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet,
+		"/users?site_admin=true&type=Organization&login_prefix=oct&updated_since=2024-01-01T00:00:00Z", nil))
+	require.Equal(t, http.StatusOK, recorder.Code)
+
+	siteAdmin := true
+	updatedSince := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	require.Equal(t, interfaces.UserListFilters{
+		SiteAdmin:    &siteAdmin,
+		Type:         "Organization",
+		LoginPrefix:  "oct",
+		UpdatedSince: &updatedSince,
+	}.Filters(), userService.listOptions.Filters)
+
+	for _, query := range []string{"site_admin=maybe", "updated_since=yesterday", "updated_before=1700000000"} {
+		recorder = httptest.NewRecorder()
+		router.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/users?"+query, nil))
+		require.Equal(t, http.StatusBadRequest, recorder.Code, query)
+	}
+}
+
 func TestGetUser_OK(t *testing.T) {
 	t.Parallel()
 	router := newTestRouter()
@@ -5,7 +5,9 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"iter"
 	"net/http"
+	"sync"
 	"time"
 
 	"github.com/unkabogaton/github-users/internal/domain/entities"
@@ -16,21 +18,35 @@ import (
 )
 
 type GitHubClient struct {
-	httpClient  *http.Client
-	accessToken string
-	apiBaseURL  string
-	rateLimiter *rate.Limiter
+	httpClient     *http.Client
+	credentials    interfaces.GitHubCredentials
+	apiBaseURL     string
+	userAgent      string
+	rateLimiter    *rate.Limiter
+	validatorStore interfaces.ValidatorStore
+
+	// maximumRequestRate is the ceiling the limiter is reset to whenever the
+	// rate-limit headers leave room for it. Zero means no ceiling.
+	maximumRequestRate rate.Limit
+
+	// The search API is metered in its own, much smaller bucket.
+	searchRateLimiter        *rate.Limiter
+	maximumSearchRequestRate rate.Limit
+
+	rateLimitMutex sync.Mutex
+	rateLimits     map[string]interfaces.RateLimitStatus
 }
 
-func NewGitHubClient(accessToken string) interfaces.GitHubClient {
-	rateLimiter := rate.NewLimiter(rate.Limit(1), 2)
-
-	return &GitHubClient{
-		httpClient:  &http.Client{Timeout: 15 * time.Second},
-		accessToken: accessToken,
-		apiBaseURL:  "https://api.github.com",
-		rateLimiter: rateLimiter,
-	}
+func NewGitHubClient(
+	credentials interfaces.GitHubCredentials,
+	validatorStore interfaces.ValidatorStore,
+) interfaces.GitHubClient {
+	// Without a CA bundle or proxy to load the options constructor cannot fail.
+	client, _ := NewGitHubClientWithOptions(GitHubClientOptions{
+		Credentials:    credentials,
+		ValidatorStore: validatorStore,
+	})
+	return client
 }
 
 func (c *GitHubClient) FetchUsersSince(
@@ -38,87 +54,118 @@ func (c *GitHubClient) FetchUsersSince(
 	lastUserID int,
 	resultsPerPage int,
 ) ([]entities.GitHubUser, error) {
+	fetchedUsers, _, err := fetchPage[entities.GitHubUser](ctx, c, coreRateLimitResource, c.usersSinceURL(lastUserID, resultsPerPage))
+	if err != nil {
+		return nil, err
+	}
+	return fetchedUsers, nil
+}
 
-    if err := c.rateLimiter.Wait(ctx); err != nil {
-        return nil, derr.Wrap(derr.ErrorCodeInternal, "rate limiter wait failed", err)
-    }
+func (c *GitHubClient) IterateUsersSince(
+	ctx context.Context,
+	lastUserID int,
+	resultsPerPage int,
+) iter.Seq2[[]entities.GitHubUser, error] {
+	return Paginate[entities.GitHubUser](ctx, c, c.usersSinceURL(lastUserID, resultsPerPage))
+}
 
-	requestURL := fmt.Sprintf(
+func (c *GitHubClient) usersSinceURL(lastUserID, resultsPerPage int) string {
+	return fmt.Sprintf(
 		"%s/users?per_page=%d&since=%d",
 		c.apiBaseURL,
 		resultsPerPage,
 		lastUserID,
 	)
-
-	httpRequest, _ := http.NewRequestWithContext(ctx, http.MethodGet, requestURL, nil)
-	if c.accessToken != "" {
-		httpRequest.Header.Set("Authorization", "token "+c.accessToken)
-	}
-	httpRequest.Header.Set("Accept", "application/vnd.github.v3+json")
-
-    httpResponse, err := c.httpClient.Do(httpRequest)
-    if err != nil {
-        return nil, derr.Wrap(derr.ErrorCodeUpstream, "GitHub request failed", err)
-    }
-	defer httpResponse.Body.Close()
-
-    if httpResponse.StatusCode == http.StatusTooManyRequests || httpResponse.StatusCode >= http.StatusInternalServerError {
-        responseBody, _ := io.ReadAll(httpResponse.Body)
-        return nil, derr.Wrap(derr.ErrorCodeRateLimited, "Upstream GitHub rate/server error", fmt.Errorf("status %d body %s", httpResponse.StatusCode, string(responseBody)))
-    }
-    if httpResponse.StatusCode != http.StatusOK {
-        responseBody, _ := io.ReadAll(httpResponse.Body)
-        return nil, derr.Wrap(derr.ErrorCodeUpstream, "Unexpected GitHub status", fmt.Errorf("status %d body %s", httpResponse.StatusCode, string(responseBody)))
-    }
-
-	var fetchedUsers []entities.GitHubUser
-    if err := json.NewDecoder(httpResponse.Body).Decode(&fetchedUsers); err != nil {
-        return nil, derr.Wrap(derr.ErrorCodeUpstream, "Failed to decode GitHub users response", err)
-    }
-
-	return fetchedUsers, nil
 }
 
 func (c *GitHubClient) FetchOne(
 	ctx context.Context,
 	username string,
 ) (*entities.GitHubUser, error) {
-    if err := c.rateLimiter.Wait(ctx); err != nil {
-        return nil, derr.Wrap(derr.ErrorCodeInternal, "rate limiter wait failed", err)
-    }
-
 	userRequestURL := fmt.Sprintf("%s/users/%s", c.apiBaseURL, username)
 
-	httpRequest, _ := http.NewRequestWithContext(ctx, http.MethodGet, userRequestURL, nil)
-	if c.accessToken != "" {
-		httpRequest.Header.Set("Authorization", "token "+c.accessToken)
+	httpResponse, err := c.get(ctx, coreRateLimitResource, userRequestURL)
+	if err != nil {
+		return nil, err
 	}
-	httpRequest.Header.Set("Accept", "application/vnd.github.v3+json")
-
-    httpResponse, err := c.httpClient.Do(httpRequest)
-    if err != nil {
-        return nil, derr.Wrap(derr.ErrorCodeUpstream, "GitHub request failed", err)
-    }
 	defer httpResponse.Body.Close()
 
-    if httpResponse.StatusCode == http.StatusTooManyRequests || httpResponse.StatusCode >= http.StatusInternalServerError {
-        responseBody, _ := io.ReadAll(httpResponse.Body)
-        return nil, derr.Wrap(derr.ErrorCodeRateLimited, "Upstream GitHub rate/server error", fmt.Errorf("status %d body %s", httpResponse.StatusCode, string(responseBody)))
-    }
+	if httpResponse.StatusCode == http.StatusTooManyRequests || httpResponse.StatusCode >= http.StatusInternalServerError {
+		responseBody, _ := io.ReadAll(httpResponse.Body)
+		return nil, derr.Wrap(derr.ErrorCodeRateLimited, "Upstream GitHub rate/server error", fmt.Errorf("status %d body %s", httpResponse.StatusCode, string(responseBody)))
+	}
 
-    if httpResponse.StatusCode == http.StatusNotFound {
-        return nil, derr.New(derr.ErrorCodeNotFound, fmt.Sprintf("user %s not found", username))
-    }
+	if httpResponse.StatusCode == http.StatusNotFound {
+		return nil, derr.New(derr.ErrorCodeNotFound, fmt.Sprintf("user %s not found", username))
+	}
 
-    if httpResponse.StatusCode != http.StatusOK {
-        responseBody, _ := io.ReadAll(httpResponse.Body)
-        return nil, derr.Wrap(derr.ErrorCodeUpstream, "Unexpected GitHub status", fmt.Errorf("status %d body %s", httpResponse.StatusCode, string(responseBody)))
-    }
+	if httpResponse.StatusCode != http.StatusOK {
+		responseBody, _ := io.ReadAll(httpResponse.Body)
+		return nil, derr.Wrap(derr.ErrorCodeUpstream, "Unexpected GitHub status", fmt.Errorf("status %d body %s", httpResponse.StatusCode, string(responseBody)))
+	}
 
 	var fetchedUser entities.GitHubUser
-    if err := json.NewDecoder(httpResponse.Body).Decode(&fetchedUser); err != nil {
-        return nil, derr.Wrap(derr.ErrorCodeUpstream, "Failed to decode GitHub user response", err)
-    }
+	if err := json.NewDecoder(httpResponse.Body).Decode(&fetchedUser); err != nil {
+		return nil, derr.Wrap(derr.ErrorCodeUpstream, "Failed to decode GitHub user response", err)
+	}
 
 	return &fetchedUser, nil
 }
+
+// get performs an authenticated GET against the API, pacing it against the
+// rate-limit state of resource and retrying when GitHub reports that a primary
+// or secondary rate limit was hit. Requests are made conditional when the
+// validator store knows the URL. The caller owns the returned body.
+func (c *GitHubClient) get(ctx context.Context, resource, requestURL string) (*http.Response, error) {
+	validators := c.storedValidators(ctx, requestURL)
+
+	for attempt := 0; ; attempt++ {
+		if err := c.waitForRateLimit(ctx, resource); err != nil {
+			return nil, err
+		}
+
+		httpRequest, err := http.NewRequestWithContext(ctx, http.MethodGet, requestURL, nil)
+		if err != nil {
+			return nil, derr.Wrap(derr.ErrorCodeInternal, "failed to build GitHub request", err)
+		}
+		var authorization string
+		if c.credentials != nil {
+			authorization, err = c.credentials.Authorization(ctx)
+			if err != nil {
+				return nil, err
+			}
+			if authorization != "" {
+				httpRequest.Header.Set("Authorization", authorization)
+			}
+		}
+		httpRequest.Header.Set("Accept", "application/vnd.github.v3+json")
+		if c.userAgent != "" {
+			httpRequest.Header.Set("User-Agent", c.userAgent)
+		}
+		setConditionalHeaders(httpRequest, validators)
+
+		httpResponse, err := c.httpClient.Do(httpRequest)
+		if err != nil {
+			return nil, derr.Wrap(derr.ErrorCodeUpstream, "GitHub request failed", err)
+		}
+
+		c.recordRateLimit(resource, authorization, httpResponse.Header)
+
+		backoff, rateLimited := rateLimitBackoff(httpResponse, attempt)
+		if !rateLimited {
+			return c.revalidate(ctx, requestURL, httpResponse, validators)
+		}
+
+		responseBody, _ := io.ReadAll(httpResponse.Body)
+		httpResponse.Body.Close()
+		if attempt >= maximumRateLimitRetries {
+			return nil, derr.Wrap(derr.ErrorCodeRateLimited, "GitHub rate limit exceeded", fmt.Errorf("status %d body %s", httpResponse.StatusCode, string(responseBody)))
+		}
+		if _, rotates := c.credentials.(interfaces.RateLimitObserver); rotates &&
+			httpResponse.Header.Get("X-RateLimit-Remaining") == "0" {
+			// Only this token ran dry; the next attempt is signed with another one.
+			continue
+		}
+		c.blockUntil(resource, time.Now().Add(backoff))
+	}
+}
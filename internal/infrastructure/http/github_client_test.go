@@ -5,10 +5,16 @@ import (
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
+	"strconv"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 	"golang.org/x/time/rate"
+
+	derr "github.com/unkabogaton/github-users/internal/domain/errors"
+	"github.com/unkabogaton/github-users/internal/domain/interfaces"
 )
 
 func TestFetchOne_Success(t *testing.T) {
@@ -30,7 +36,6 @@ func TestFetchOne_Success(t *testing.T) {
 
 	client := &GitHubClient{
 		httpClient:  server.Client(),
-		accessToken: "",
 		apiBaseURL:  server.URL,
 		rateLimiter: rate.NewLimiter(rate.Inf, 1),
 	}
@@ -50,7 +55,6 @@ func TestFetchOne_NotFound(t *testing.T) {
 
 	client := &GitHubClient{
 		httpClient:  server.Client(),
-		accessToken: "",
 		apiBaseURL:  server.URL,
 		rateLimiter: rate.NewLimiter(rate.Inf, 1),
 	}
@@ -70,7 +74,6 @@ func TestFetchUsersSince_UnexpectedStatus(t *testing.T) {
 
 	client := &GitHubClient{
 		httpClient:  server.Client(),
-		accessToken: "",
 		apiBaseURL:  server.URL,
 		rateLimiter: rate.NewLimiter(rate.Inf, 1),
 	}
@@ -79,3 +82,214 @@ func TestFetchUsersSince_UnexpectedStatus(t *testing.T) {
 	require.Error(t, err)
 	require.Nil(t, users)
 }
+
+func TestFetchOne_RecordsRateLimitHeaders(t *testing.T) {
+	t.Parallel()
+	resetAt := time.Now().Add(time.Hour).Truncate(time.Second)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-RateLimit-Limit", "5000")
+		w.Header().Set("X-RateLimit-Remaining", "3600")
+		w.Header().Set("X-RateLimit-Used", "1400")
+		w.Header().Set("X-RateLimit-Reset", strconv.FormatInt(resetAt.Unix(), 10))
+		w.Header().Set("X-RateLimit-Resource", "core")
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"id": 1, "login": "sample_username"})
+	}))
+	defer server.Close()
+
+	client := &GitHubClient{
+		httpClient:         server.Client(),
+		apiBaseURL:         server.URL,
+		rateLimiter:        rate.NewLimiter(rate.Inf, 1),
+		maximumRequestRate: rate.Limit(10),
+	}
+
+	_, err := client.FetchOne(context.Background(), "sample_username")
+	require.NoError(t, err)
+
+	statuses := client.RateLimits()
+	require.Len(t, statuses, 1)
+	require.Equal(t, "core", statuses[0].Resource)
+	require.Equal(t, 5000, statuses[0].Limit)
+	require.Equal(t, 3600, statuses[0].Remaining)
+	require.Equal(t, 1400, statuses[0].Used)
+	require.True(t, resetAt.Equal(statuses[0].ResetAt))
+
+	// 3600 requests left over one hour paces the client at about one per second.
+	require.InDelta(t, 1.0, float64(client.rateLimiter.Limit()), 0.05)
+}
+
+func TestFetchOne_RetriesAfterSecondaryRateLimit(t *testing.T) {
+	t.Parallel()
+	var requestCount int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&requestCount, 1) == 1 {
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusForbidden)
+			_, _ = w.Write([]byte(`{"message":"You have exceeded a secondary rate limit"}`))
+			return
+		}
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"id": 1, "login": "sample_username"})
+	}))
+	defer server.Close()
+
+	client := &GitHubClient{
+		httpClient:  server.Client(),
+		apiBaseURL:  server.URL,
+		rateLimiter: rate.NewLimiter(rate.Inf, 1),
+	}
+
+	user, err := client.FetchOne(context.Background(), "sample_username")
+	require.NoError(t, err)
+	require.Equal(t, "sample_username", user.Login)
+	require.Equal(t, int32(2), atomic.LoadInt32(&requestCount))
+}
+
+func TestFetchOne_WaitsForResetWhenQuotaExhausted(t *testing.T) {
+	t.Parallel()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-RateLimit-Remaining", "0")
+		w.Header().Set("X-RateLimit-Reset", strconv.FormatInt(time.Now().Add(time.Hour).Unix(), 10))
+		w.WriteHeader(http.StatusForbidden)
+	}))
+	defer server.Close()
+
+	client := &GitHubClient{
+		httpClient:  server.Client(),
+		apiBaseURL:  server.URL,
+		rateLimiter: rate.NewLimiter(rate.Inf, 1),
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	user, err := client.FetchOne(ctx, "sample_username")
+	require.Error(t, err)
+	require.Nil(t, user)
+	require.True(t, derr.IsCode(err, derr.ErrorCodeRateLimited))
+
+	statuses := client.RateLimits()
+	require.Len(t, statuses, 1)
+	require.True(t, statuses[0].BlockedUntil.After(time.Now()))
+}
+
+func TestFetchOne_ForbiddenIsNotRetried(t *testing.T) {
+	t.Parallel()
+	var requestCount int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requestCount, 1)
+		w.WriteHeader(http.StatusForbidden)
+		_, _ = w.Write([]byte(`{"message":"Resource not accessible"}`))
+	}))
+	defer server.Close()
+
+	client := &GitHubClient{
+		httpClient:  server.Client(),
+		apiBaseURL:  server.URL,
+		rateLimiter: rate.NewLimiter(rate.Inf, 1),
+	}
+
+	_, err := client.FetchOne(context.Background(), "sample_username")
+	require.True(t, derr.IsCode(err, derr.ErrorCodeUpstream))
+	require.Equal(t, int32(1), atomic.LoadInt32(&requestCount))
+}
+
+type memoryValidatorStore map[string]interfaces.ResponseValidators
+
+func (store memoryValidatorStore) GetValidators(ctx context.Context, requestURL string) (*interfaces.ResponseValidators, bool, error) {
+	validators, ok := store[requestURL]
+	return &validators, ok, nil
+}
+
+func (store memoryValidatorStore) SetValidators(ctx context.Context, requestURL string, validators *interfaces.ResponseValidators) error {
+	store[requestURL] = *validators
+	return nil
+}
+
+func TestFetchOne_NotModifiedServesStoredBody(t *testing.T) {
+	t.Parallel()
+	var conditionalRequests int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			atomic.AddInt32(&conditionalRequests, 1)
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("ETag", `"v1"`)
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"id": 1, "login": "sample_username"})
+	}))
+	defer server.Close()
+
+	store := memoryValidatorStore{}
+	client := &GitHubClient{
+		httpClient:     server.Client(),
+		apiBaseURL:     server.URL,
+		rateLimiter:    rate.NewLimiter(rate.Inf, 1),
+		validatorStore: store,
+	}
+
+	first, err := client.FetchOne(context.Background(), "sample_username")
+	require.NoError(t, err)
+	require.Contains(t, store, server.URL+"/users/sample_username")
+
+	second, err := client.FetchOne(context.Background(), "sample_username")
+	require.NoError(t, err)
+	require.Equal(t, first, second)
+	require.Equal(t, int32(1), atomic.LoadInt32(&conditionalRequests))
+}
+
+func TestSearchUsers_UsesSearchBucket(t *testing.T) {
+	t.Parallel()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/search/users", r.URL.Path)
+		require.Equal(t, "location:berlin followers:>100", r.URL.Query().Get("q"))
+		require.Equal(t, "followers", r.URL.Query().Get("sort"))
+		require.Equal(t, "2", r.URL.Query().Get("page"))
+		w.Header().Set("X-RateLimit-Limit", "30")
+		w.Header().Set("X-RateLimit-Remaining", "29")
+		w.Header().Set("X-RateLimit-Reset", strconv.FormatInt(time.Now().Add(time.Minute).Unix(), 10))
+		w.Header().Set("X-RateLimit-Resource", "search")
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"total_count":        1,
+			"incomplete_results": false,
+			"items":              []map[string]interface{}{{"id": 1, "login": "sample_username"}},
+		})
+	}))
+	defer server.Close()
+
+	client := &GitHubClient{
+		httpClient:         server.Client(),
+		apiBaseURL:         server.URL,
+		rateLimiter:        rate.NewLimiter(rate.Limit(1), 1),
+		maximumRequestRate: rate.Limit(1),
+		searchRateLimiter:  rate.NewLimiter(rate.Inf, 1),
+	}
+
+	result, err := client.SearchUsers(context.Background(), "location:berlin followers:>100", "followers", "desc", 2)
+	require.NoError(t, err)
+	require.Equal(t, 1, result.TotalCount)
+	require.Equal(t, "sample_username", result.Items[0].Login)
+
+	statuses := client.RateLimits()
+	require.Len(t, statuses, 1)
+	require.Equal(t, "search", statuses[0].Resource)
+	require.Equal(t, 29, statuses[0].Remaining)
+	require.Equal(t, rate.Limit(1), client.rateLimiter.Limit())
+	require.Less(t, float64(client.searchRateLimiter.Limit()), 1.0)
+}
+
+func TestSearchUsers_InvalidQuery(t *testing.T) {
+	t.Parallel()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnprocessableEntity)
+		_, _ = w.Write([]byte(`{"message":"Validation Failed"}`))
+	}))
+	defer server.Close()
+
+	client := &GitHubClient{
+		httpClient: server.Client(),
+		apiBaseURL: server.URL,
+	}
+
+	_, err := client.SearchUsers(context.Background(), "", "", "", 1)
+	require.True(t, derr.IsCode(err, derr.ErrorCodeValidation))
+}
@@ -0,0 +1,76 @@
+package http
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+
+	derr "github.com/unkabogaton/github-users/internal/domain/errors"
+	"github.com/unkabogaton/github-users/internal/domain/interfaces"
+)
+
+// storedValidators returns the validators previously recorded for requestURL.
+// Store failures are treated as a miss: conditional requests only save quota.
+func (c *GitHubClient) storedValidators(ctx context.Context, requestURL string) *interfaces.ResponseValidators {
+	if c.validatorStore == nil {
+		return nil
+	}
+	validators, found, err := c.validatorStore.GetValidators(ctx, requestURL)
+	if err != nil || !found {
+		return nil
+	}
+	return validators
+}
+
+func setConditionalHeaders(httpRequest *http.Request, validators *interfaces.ResponseValidators) {
+	if validators == nil {
+		return
+	}
+	if validators.ETag != "" {
+		httpRequest.Header.Set("If-None-Match", validators.ETag)
+	}
+	if validators.LastModified != "" {
+		httpRequest.Header.Set("If-Modified-Since", validators.LastModified)
+	}
+}
+
+// revalidate answers a 304 Not Modified with the stored body, so callers see
+// an unchanged 200 response, and records the validators of fresh 200 responses.
+func (c *GitHubClient) revalidate(
+	ctx context.Context,
+	requestURL string,
+	httpResponse *http.Response,
+	validators *interfaces.ResponseValidators,
+) (*http.Response, error) {
+	if httpResponse.StatusCode == http.StatusNotModified && validators != nil {
+		httpResponse.Body.Close()
+		httpResponse.StatusCode = http.StatusOK
+		httpResponse.Status = http.StatusText(http.StatusOK)
+		httpResponse.Body = io.NopCloser(bytes.NewReader(validators.Body))
+		return httpResponse, nil
+	}
+
+	if c.validatorStore == nil || httpResponse.StatusCode != http.StatusOK {
+		return httpResponse, nil
+	}
+	entityTag := httpResponse.Header.Get("ETag")
+	lastModified := httpResponse.Header.Get("Last-Modified")
+	if entityTag == "" && lastModified == "" {
+		return httpResponse, nil
+	}
+
+	responseBody, err := io.ReadAll(httpResponse.Body)
+	httpResponse.Body.Close()
+	if err != nil {
+		return nil, derr.Wrap(derr.ErrorCodeUpstream, "Failed to read GitHub response", err)
+	}
+	httpResponse.Body = io.NopCloser(bytes.NewReader(responseBody))
+
+	_ = c.validatorStore.SetValidators(ctx, requestURL, &interfaces.ResponseValidators{
+		ETag:         entityTag,
+		LastModified: lastModified,
+		Body:         responseBody,
+	})
+	return httpResponse, nil
+}
@@ -0,0 +1,178 @@
+package http
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	derr "github.com/unkabogaton/github-users/internal/domain/errors"
+	"github.com/unkabogaton/github-users/internal/domain/interfaces"
+)
+
+const (
+	// appJWTLifetime stays under the ten minutes GitHub accepts, and the
+	// issued-at time is backdated to tolerate clock drift.
+	appJWTLifetime  = 9 * time.Minute
+	appJWTClockSkew = time.Minute
+
+	// installationTokenRefreshMargin renews installation tokens this long
+	// before GitHub expires them, so in-flight requests never carry a dead token.
+	installationTokenRefreshMargin = 5 * time.Minute
+)
+
+type StaticTokenCredentials struct {
+	accessToken string
+}
+
+func NewStaticTokenCredentials(accessToken string) interfaces.GitHubCredentials {
+	return &StaticTokenCredentials{accessToken: accessToken}
+}
+
+func (credentials *StaticTokenCredentials) Authorization(ctx context.Context) (string, error) {
+	if credentials.accessToken == "" {
+		return "", nil
+	}
+	return "token " + credentials.accessToken, nil
+}
+
+// AppCredentials authenticates as a GitHub App installation. It signs a JWT
+// with the app's private key, exchanges it for an installation token and
+// caches that token until shortly before it expires.
+type AppCredentials struct {
+	httpClient     *http.Client
+	apiBaseURL     string
+	appID          int64
+	installationID int64
+	privateKey     *rsa.PrivateKey
+	now            func() time.Time
+
+	tokenMutex          sync.Mutex
+	installationToken   string
+	installationExpires time.Time
+}
+
+func NewAppCredentials(
+	appID int64,
+	installationID int64,
+	privateKeyPEM []byte,
+	apiBaseURL string,
+	httpClient *http.Client,
+) (interfaces.GitHubCredentials, error) {
+	privateKey, err := parseRSAPrivateKey(privateKeyPEM)
+	if err != nil {
+		return nil, derr.Wrap(derr.ErrorCodeValidation, "invalid GitHub App private key", err)
+	}
+
+	return &AppCredentials{
+		httpClient:     httpClient,
+		apiBaseURL:     apiBaseURL,
+		appID:          appID,
+		installationID: installationID,
+		privateKey:     privateKey,
+		now:            time.Now,
+	}, nil
+}
+
+func (credentials *AppCredentials) Authorization(ctx context.Context) (string, error) {
+	credentials.tokenMutex.Lock()
+	defer credentials.tokenMutex.Unlock()
+
+	if credentials.installationToken != "" &&
+		credentials.now().Before(credentials.installationExpires.Add(-installationTokenRefreshMargin)) {
+		return "token " + credentials.installationToken, nil
+	}
+
+	installationToken, expiresAt, err := credentials.exchangeInstallationToken(ctx)
+	if err != nil {
+		return "", err
+	}
+	credentials.installationToken = installationToken
+	credentials.installationExpires = expiresAt
+	return "token " + installationToken, nil
+}
+
+func (credentials *AppCredentials) exchangeInstallationToken(ctx context.Context) (string, time.Time, error) {
+	appJWT, err := credentials.signJWT()
+	if err != nil {
+		return "", time.Time{}, derr.Wrap(derr.ErrorCodeInternal, "failed to sign GitHub App JWT", err)
+	}
+
+	requestURL := fmt.Sprintf("%s/app/installations/%d/access_tokens", credentials.apiBaseURL, credentials.installationID)
+	httpRequest, err := http.NewRequestWithContext(ctx, http.MethodPost, requestURL, nil)
+	if err != nil {
+		return "", time.Time{}, derr.Wrap(derr.ErrorCodeInternal, "failed to build GitHub request", err)
+	}
+	httpRequest.Header.Set("Authorization", "Bearer "+appJWT)
+	httpRequest.Header.Set("Accept", "application/vnd.github.v3+json")
+
+	httpResponse, err := credentials.httpClient.Do(httpRequest)
+	if err != nil {
+		return "", time.Time{}, derr.Wrap(derr.ErrorCodeUpstream, "GitHub request failed", err)
+	}
+	defer httpResponse.Body.Close()
+
+	if httpResponse.StatusCode != http.StatusCreated {
+		responseBody, _ := io.ReadAll(httpResponse.Body)
+		return "", time.Time{}, derr.Wrap(derr.ErrorCodeUnauthorized, "GitHub App installation token exchange failed", fmt.Errorf("status %d body %s", httpResponse.StatusCode, string(responseBody)))
+	}
+
+	var tokenResponse struct {
+		Token     string    `json:"token"`
+		ExpiresAt time.Time `json:"expires_at"`
+	}
+	if err := json.NewDecoder(httpResponse.Body).Decode(&tokenResponse); err != nil {
+		return "", time.Time{}, derr.Wrap(derr.ErrorCodeUpstream, "Failed to decode GitHub installation token response", err)
+	}
+	return tokenResponse.Token, tokenResponse.ExpiresAt, nil
+}
+
+func (credentials *AppCredentials) signJWT() (string, error) {
+	issuedAt := credentials.now().Add(-appJWTClockSkew)
+
+	header, _ := json.Marshal(map[string]string{"alg": "RS256", "typ": "JWT"})
+	claims, _ := json.Marshal(map[string]interface{}{
+		"iat": issuedAt.Unix(),
+		"exp": issuedAt.Add(appJWTLifetime).Unix(),
+		"iss": strconv.FormatInt(credentials.appID, 10),
+	})
+
+	signingInput := base64.RawURLEncoding.EncodeToString(header) + "." + base64.RawURLEncoding.EncodeToString(claims)
+	digest := sha256.Sum256([]byte(signingInput))
+	signature, err := rsa.SignPKCS1v15(rand.Reader, credentials.privateKey, crypto.SHA256, digest[:])
+	if err != nil {
+		return "", err
+	}
+	return signingInput + "." + base64.RawURLEncoding.EncodeToString(signature), nil
+}
+
+func parseRSAPrivateKey(privateKeyPEM []byte) (*rsa.PrivateKey, error) {
+	block, _ := pem.Decode(privateKeyPEM)
+	if block == nil {
+		return nil, errors.New("no PEM block found")
+	}
+	if privateKey, err := x509.ParsePKCS1PrivateKey(block.Bytes); err == nil {
+		return privateKey, nil
+	}
+	parsedKey, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, err
+	}
+	privateKey, ok := parsedKey.(*rsa.PrivateKey)
+	if !ok {
+		return nil, errors.New("private key is not an RSA key")
+	}
+	return privateKey, nil
+}
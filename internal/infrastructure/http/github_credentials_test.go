@@ -0,0 +1,149 @@
+package http
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"golang.org/x/time/rate"
+)
+
+func newTestAppServer(t *testing.T, publicKey *rsa.PublicKey, tokenLifetime time.Duration) (*httptest.Server, *int32) {
+	t.Helper()
+	var exchanges int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/app/installations/42/access_tokens":
+			appJWT := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+			parts := strings.Split(appJWT, ".")
+			if len(parts) != 3 {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			signature, _ := base64.RawURLEncoding.DecodeString(parts[2])
+			digest := sha256.Sum256([]byte(parts[0] + "." + parts[1]))
+			if rsa.VerifyPKCS1v15(publicKey, crypto.SHA256, digest[:], signature) != nil {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+
+			exchangeNumber := atomic.AddInt32(&exchanges, 1)
+			w.WriteHeader(http.StatusCreated)
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{
+				"token":      fmt.Sprintf("ghs_installation_%d", exchangeNumber),
+				"expires_at": time.Now().Add(tokenLifetime).UTC().Format(time.RFC3339),
+			})
+		case r.URL.Path == "/users/sample_username":
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{
+				"id":    1,
+				"login": "sample_username",
+				"type":  r.Header.Get("Authorization"),
+			})
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	return server, &exchanges
+}
+
+func newTestPrivateKey(t *testing.T) (*rsa.PrivateKey, []byte) {
+	t.Helper()
+	privateKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	privateKeyPEM := pem.EncodeToMemory(&pem.Block{
+		Type:  "RSA PRIVATE KEY",
+		Bytes: x509.MarshalPKCS1PrivateKey(privateKey),
+	})
+	return privateKey, privateKeyPEM
+}
+
+func TestAppCredentials_ExchangesAndCachesInstallationToken(t *testing.T) {
+	t.Parallel()
+	privateKey, privateKeyPEM := newTestPrivateKey(t)
+	server, exchanges := newTestAppServer(t, &privateKey.PublicKey, time.Hour)
+	defer server.Close()
+
+	credentials, err := NewAppCredentials(7, 42, privateKeyPEM, server.URL, server.Client())
+	require.NoError(t, err)
+
+	client := &GitHubClient{
+		httpClient:  server.Client(),
+		credentials: credentials,
+		apiBaseURL:  server.URL,
+		rateLimiter: rate.NewLimiter(rate.Inf, 1),
+	}
+
+	for range 2 {
+		user, err := client.FetchOne(context.Background(), "sample_username")
+		require.NoError(t, err)
+		require.Equal(t, "token ghs_installation_1", user.Type)
+	}
+	require.Equal(t, int32(1), atomic.LoadInt32(exchanges))
+}
+
+func TestAppCredentials_RefreshesBeforeExpiry(t *testing.T) {
+	t.Parallel()
+	privateKey, privateKeyPEM := newTestPrivateKey(t)
+	server, exchanges := newTestAppServer(t, &privateKey.PublicKey, time.Hour)
+	defer server.Close()
+
+	credentials, err := NewAppCredentials(7, 42, privateKeyPEM, server.URL, server.Client())
+	require.NoError(t, err)
+	appCredentials := credentials.(*AppCredentials)
+
+	authorization, err := appCredentials.Authorization(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, "token ghs_installation_1", authorization)
+
+	appCredentials.now = func() time.Time { return time.Now().Add(time.Hour - installationTokenRefreshMargin/2) }
+
+	authorization, err = appCredentials.Authorization(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, "token ghs_installation_2", authorization)
+	require.Equal(t, int32(2), atomic.LoadInt32(exchanges))
+}
+
+func TestAppCredentials_RejectedJWT(t *testing.T) {
+	t.Parallel()
+	privateKey, _ := newTestPrivateKey(t)
+	_, otherPrivateKeyPEM := newTestPrivateKey(t)
+	server, _ := newTestAppServer(t, &privateKey.PublicKey, time.Hour)
+	defer server.Close()
+
+	credentials, err := NewAppCredentials(7, 42, otherPrivateKeyPEM, server.URL, server.Client())
+	require.NoError(t, err)
+
+	_, err = credentials.Authorization(context.Background())
+	require.Error(t, err)
+}
+
+func TestNewAppCredentials_InvalidKey(t *testing.T) {
+	t.Parallel()
+	_, err := NewAppCredentials(7, 42, []byte("not a key"), "http://localhost", http.DefaultClient)
+	require.Error(t, err)
+}
+
+func TestStaticTokenCredentials(t *testing.T) {
+	t.Parallel()
+	authorization, err := NewStaticTokenCredentials("pat").Authorization(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, "token pat", authorization)
+
+	authorization, err = NewStaticTokenCredentials("").Authorization(context.Background())
+	require.NoError(t, err)
+	require.Empty(t, authorization)
+}
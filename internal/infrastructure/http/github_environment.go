@@ -0,0 +1,91 @@
+package http
+
+import (
+	"fmt"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	derr "github.com/unkabogaton/github-users/internal/domain/errors"
+	"github.com/unkabogaton/github-users/internal/domain/interfaces"
+)
+
+// GitHubClientOptionsFromEnvironment reads the client settings from GITHUB_*
+// environment variables and resolves the credentials against the same API
+// base URL, CA bundle and proxy the client will use.
+func GitHubClientOptionsFromEnvironment() (GitHubClientOptions, error) {
+	options := GitHubClientOptions{
+		APIBaseURL:   os.Getenv("GITHUB_API_URL"),
+		CABundlePath: os.Getenv("GITHUB_CA_BUNDLE"),
+		ProxyURL:     os.Getenv("GITHUB_PROXY_URL"),
+		UserAgent:    os.Getenv("GITHUB_USER_AGENT"),
+	}
+	if timeoutSeconds, err := strconv.Atoi(os.Getenv("GITHUB_TIMEOUT_SEC")); err == nil {
+		options.Timeout = time.Duration(timeoutSeconds) * time.Second
+	}
+	if requestsPerSecond, err := strconv.ParseFloat(os.Getenv("GITHUB_REQUESTS_PER_SECOND"), 64); err == nil {
+		options.RequestsPerSecond = requestsPerSecond
+	}
+	if burst, err := strconv.Atoi(os.Getenv("GITHUB_BURST")); err == nil {
+		options.Burst = burst
+	}
+	if searchRequestsPerSecond, err := strconv.ParseFloat(os.Getenv("GITHUB_SEARCH_REQUESTS_PER_SECOND"), 64); err == nil {
+		options.SearchRequestsPerSecond = searchRequestsPerSecond
+	}
+	if searchBurst, err := strconv.Atoi(os.Getenv("GITHUB_SEARCH_BURST")); err == nil {
+		options.SearchBurst = searchBurst
+	}
+	options = options.withDefaults()
+
+	httpClient, err := options.newHTTPClient()
+	if err != nil {
+		return GitHubClientOptions{}, err
+	}
+	credentials, err := credentialsFromEnvironment(options.APIBaseURL, httpClient)
+	if err != nil {
+		return GitHubClientOptions{}, err
+	}
+	options.Credentials = credentials
+	return options, nil
+}
+
+// credentialsFromEnvironment returns GitHub App credentials when GITHUB_APP_ID
+// is set, a token pool when GITHUB_TOKENS lists comma-separated tokens, and
+// falls back to the GITHUB_TOKEN personal access token otherwise.
+// The App private key is read from GITHUB_APP_PRIVATE_KEY or, if that is
+// empty, from the file named by GITHUB_APP_PRIVATE_KEY_PATH.
+func credentialsFromEnvironment(apiBaseURL string, httpClient *http.Client) (interfaces.GitHubCredentials, error) {
+	appIDValue := os.Getenv("GITHUB_APP_ID")
+	if appIDValue == "" {
+		if pooledTokens := os.Getenv("GITHUB_TOKENS"); pooledTokens != "" {
+			accessTokens := strings.Split(pooledTokens, ",")
+			for index := range accessTokens {
+				accessTokens[index] = strings.TrimSpace(accessTokens[index])
+			}
+			return NewTokenPool(accessTokens), nil
+		}
+		return NewStaticTokenCredentials(os.Getenv("GITHUB_TOKEN")), nil
+	}
+
+	appID, err := strconv.ParseInt(appIDValue, 10, 64)
+	if err != nil {
+		return nil, derr.Wrap(derr.ErrorCodeValidation, "invalid GITHUB_APP_ID", err)
+	}
+	installationID, err := strconv.ParseInt(os.Getenv("GITHUB_APP_INSTALLATION_ID"), 10, 64)
+	if err != nil {
+		return nil, derr.Wrap(derr.ErrorCodeValidation, "invalid GITHUB_APP_INSTALLATION_ID", err)
+	}
+
+	privateKeyPEM := []byte(os.Getenv("GITHUB_APP_PRIVATE_KEY"))
+	if len(privateKeyPEM) == 0 {
+		privateKeyPath := os.Getenv("GITHUB_APP_PRIVATE_KEY_PATH")
+		privateKeyPEM, err = os.ReadFile(privateKeyPath)
+		if err != nil {
+			return nil, derr.Wrap(derr.ErrorCodeValidation, fmt.Sprintf("failed to read GitHub App private key %q", privateKeyPath), err)
+		}
+	}
+
+	return NewAppCredentials(appID, installationID, privateKeyPEM, apiBaseURL, httpClient)
+}
@@ -0,0 +1,24 @@
+package http
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+
+	"github.com/unkabogaton/github-users/internal/domain/entities"
+)
+
+// maximumPerPage is the largest page size GitHub list endpoints accept.
+const maximumPerPage = 100
+
+// FetchFollowers pages through the followers_url of username and returns
+// every follower as a summary user.
+func (c *GitHubClient) FetchFollowers(ctx context.Context, username string) ([]entities.GitHubUser, error) {
+	return fetchAll[entities.GitHubUser](ctx, c, fmt.Sprintf("/users/%s/followers?per_page=%d", url.PathEscape(username), maximumPerPage))
+}
+
+// FetchFollowing pages through the following_url of username and returns
+// every account it follows as a summary user.
+func (c *GitHubClient) FetchFollowing(ctx context.Context, username string) ([]entities.GitHubUser, error) {
+	return fetchAll[entities.GitHubUser](ctx, c, fmt.Sprintf("/users/%s/following?per_page=%d", url.PathEscape(username), maximumPerPage))
+}
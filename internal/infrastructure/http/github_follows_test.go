@@ -0,0 +1,48 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"golang.org/x/time/rate"
+
+	derr "github.com/unkabogaton/github-users/internal/domain/errors"
+)
+
+func TestFetchFollowers_WalksEveryPage(t *testing.T) {
+	t.Parallel()
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/users/octocat/followers" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		if r.URL.Query().Get("page") == "" {
+			w.Header().Set("Link", fmt.Sprintf(`<%s/users/octocat/followers?per_page=100&page=2>; rel="next"`, server.URL))
+			_ = json.NewEncoder(w).Encode([]map[string]interface{}{{"id": 2, "login": "hubot"}})
+			return
+		}
+		_ = json.NewEncoder(w).Encode([]map[string]interface{}{{"id": 3, "login": "monalisa"}})
+	}))
+	defer server.Close()
+
+	client := &GitHubClient{
+		httpClient:  server.Client(),
+		apiBaseURL:  server.URL,
+		rateLimiter: rate.NewLimiter(rate.Inf, 1),
+	}
+
+	followers, err := client.FetchFollowers(context.Background(), "octocat")
+	require.NoError(t, err)
+	require.Len(t, followers, 2)
+	require.Equal(t, "hubot", followers[0].Login)
+	require.Equal(t, "monalisa", followers[1].Login)
+
+	_, err = client.FetchFollowing(context.Background(), "octocat")
+	require.True(t, derr.IsCode(err, derr.ErrorCodeNotFound))
+}
@@ -0,0 +1,133 @@
+package http
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	derr "github.com/unkabogaton/github-users/internal/domain/errors"
+	"github.com/unkabogaton/github-users/internal/domain/interfaces"
+
+	"golang.org/x/time/rate"
+)
+
+const (
+	defaultAPIBaseURL        = "https://api.github.com"
+	defaultTimeout           = 15 * time.Second
+	defaultUserAgent         = "github-users"
+	defaultRequestsPerSecond = 1
+	defaultBurst             = 2
+
+	// The search API allows 30 authenticated requests per minute.
+	defaultSearchRequestsPerSecond = 0.5
+	defaultSearchBurst             = 2
+)
+
+// GitHubClientOptions configures NewGitHubClientWithOptions. Zero values fall
+// back to the public github.com API with the client's historical defaults.
+type GitHubClientOptions struct {
+	// APIBaseURL points the client at a GitHub Enterprise Server instance,
+	// e.g. https://github.example.com/api/v3, or at a local fake.
+	APIBaseURL string
+	// CABundlePath names a PEM file whose certificates are trusted in
+	// addition to the system roots.
+	CABundlePath string
+	// ProxyURL routes requests through an HTTP proxy. When empty the
+	// standard HTTPS_PROXY/NO_PROXY environment variables apply.
+	ProxyURL          string
+	Timeout           time.Duration
+	UserAgent         string
+	RequestsPerSecond float64
+	Burst             int
+	// SearchRequestsPerSecond and SearchBurst pace /search requests, which
+	// GitHub meters separately from the core API.
+	SearchRequestsPerSecond float64
+	SearchBurst             int
+
+	Credentials    interfaces.GitHubCredentials
+	ValidatorStore interfaces.ValidatorStore
+}
+
+func NewGitHubClientWithOptions(options GitHubClientOptions) (interfaces.GitHubClient, error) {
+	options = options.withDefaults()
+
+	httpClient, err := options.newHTTPClient()
+	if err != nil {
+		return nil, err
+	}
+
+	maximumRequestRate := rate.Limit(options.RequestsPerSecond)
+	maximumSearchRequestRate := rate.Limit(options.SearchRequestsPerSecond)
+	return &GitHubClient{
+		httpClient:               httpClient,
+		credentials:              options.Credentials,
+		apiBaseURL:               options.APIBaseURL,
+		userAgent:                options.UserAgent,
+		rateLimiter:              rate.NewLimiter(maximumRequestRate, options.Burst),
+		validatorStore:           options.ValidatorStore,
+		maximumRequestRate:       maximumRequestRate,
+		searchRateLimiter:        rate.NewLimiter(maximumSearchRequestRate, options.SearchBurst),
+		maximumSearchRequestRate: maximumSearchRequestRate,
+	}, nil
+}
+
+func (options GitHubClientOptions) withDefaults() GitHubClientOptions {
+	options.APIBaseURL = strings.TrimSuffix(options.APIBaseURL, "/")
+	if options.APIBaseURL == "" {
+		options.APIBaseURL = defaultAPIBaseURL
+	}
+	if options.Timeout <= 0 {
+		options.Timeout = defaultTimeout
+	}
+	if options.UserAgent == "" {
+		options.UserAgent = defaultUserAgent
+	}
+	if options.RequestsPerSecond <= 0 {
+		options.RequestsPerSecond = defaultRequestsPerSecond
+	}
+	if options.Burst <= 0 {
+		options.Burst = defaultBurst
+	}
+	if options.SearchRequestsPerSecond <= 0 {
+		options.SearchRequestsPerSecond = defaultSearchRequestsPerSecond
+	}
+	if options.SearchBurst <= 0 {
+		options.SearchBurst = defaultSearchBurst
+	}
+	return options
+}
+
+func (options GitHubClientOptions) newHTTPClient() (*http.Client, error) {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+
+	if options.ProxyURL != "" {
+		proxyURL, err := url.Parse(options.ProxyURL)
+		if err != nil {
+			return nil, derr.Wrap(derr.ErrorCodeValidation, "invalid GitHub proxy URL", err)
+		}
+		transport.Proxy = http.ProxyURL(proxyURL)
+	}
+
+	if options.CABundlePath != "" {
+		caBundle, err := os.ReadFile(options.CABundlePath)
+		if err != nil {
+			return nil, derr.Wrap(derr.ErrorCodeValidation, fmt.Sprintf("failed to read CA bundle %q", options.CABundlePath), err)
+		}
+		rootCAs, err := x509.SystemCertPool()
+		if err != nil {
+			rootCAs = x509.NewCertPool()
+		}
+		if !rootCAs.AppendCertsFromPEM(caBundle) {
+			return nil, derr.Wrap(derr.ErrorCodeValidation, fmt.Sprintf("invalid CA bundle %q", options.CABundlePath), errors.New("no certificates found"))
+		}
+		transport.TLSClientConfig = &tls.Config{RootCAs: rootCAs, MinVersion: tls.VersionTLS12}
+	}
+
+	return &http.Client{Timeout: options.Timeout, Transport: transport}, nil
+}
@@ -0,0 +1,92 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewGitHubClientWithOptions_Defaults(t *testing.T) {
+	t.Parallel()
+	gitHubClient, err := NewGitHubClientWithOptions(GitHubClientOptions{})
+	require.NoError(t, err)
+
+	client := gitHubClient.(*GitHubClient)
+	require.Equal(t, "https://api.github.com", client.apiBaseURL)
+	require.Equal(t, defaultTimeout, client.httpClient.Timeout)
+	require.Equal(t, "github-users", client.userAgent)
+	require.Equal(t, float64(1), float64(client.rateLimiter.Limit()))
+	require.Equal(t, 2, client.rateLimiter.Burst())
+}
+
+func TestNewGitHubClientWithOptions_EnterpriseServerWithCABundle(t *testing.T) {
+	t.Parallel()
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v3/users/sample_username" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"id":    1,
+			"login": "sample_username",
+			"type":  r.Header.Get("User-Agent"),
+		})
+	}))
+	defer server.Close()
+
+	caBundlePath := filepath.Join(t.TempDir(), "ca.pem")
+	caBundle := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+	require.NoError(t, os.WriteFile(caBundlePath, caBundle, 0o600))
+
+	gitHubClient, err := NewGitHubClientWithOptions(GitHubClientOptions{
+		APIBaseURL:        server.URL + "/api/v3/",
+		CABundlePath:      caBundlePath,
+		UserAgent:         "ghes-sync",
+		RequestsPerSecond: 100,
+	})
+	require.NoError(t, err)
+
+	user, err := gitHubClient.FetchOne(context.Background(), "sample_username")
+	require.NoError(t, err)
+	require.Equal(t, "sample_username", user.Login)
+	require.Equal(t, "ghes-sync", user.Type)
+}
+
+func TestNewGitHubClientWithOptions_Proxy(t *testing.T) {
+	t.Parallel()
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"id": 1, "login": "via_proxy", "type": r.URL.Host})
+	}))
+	defer proxy.Close()
+
+	gitHubClient, err := NewGitHubClientWithOptions(GitHubClientOptions{
+		APIBaseURL:        "http://github.internal",
+		ProxyURL:          proxy.URL,
+		RequestsPerSecond: 100,
+	})
+	require.NoError(t, err)
+
+	user, err := gitHubClient.FetchOne(context.Background(), "sample_username")
+	require.NoError(t, err)
+	require.Equal(t, "via_proxy", user.Login)
+	require.Equal(t, "github.internal", user.Type)
+}
+
+func TestNewGitHubClientWithOptions_InvalidCABundle(t *testing.T) {
+	t.Parallel()
+	caBundlePath := filepath.Join(t.TempDir(), "ca.pem")
+	require.NoError(t, os.WriteFile(caBundlePath, []byte("not a certificate"), 0o600))
+
+	_, err := NewGitHubClientWithOptions(GitHubClientOptions{CABundlePath: caBundlePath})
+	require.Error(t, err)
+
+	_, err = NewGitHubClientWithOptions(GitHubClientOptions{CABundlePath: filepath.Join(t.TempDir(), "missing.pem")})
+	require.Error(t, err)
+}
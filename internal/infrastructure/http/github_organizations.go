@@ -0,0 +1,56 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/unkabogaton/github-users/internal/domain/entities"
+	derr "github.com/unkabogaton/github-users/internal/domain/errors"
+)
+
+func (c *GitHubClient) FetchOrganization(
+	ctx context.Context,
+	organizationLogin string,
+) (*entities.GitHubOrganization, error) {
+	organizationRequestURL := fmt.Sprintf("%s/orgs/%s", c.apiBaseURL, url.PathEscape(organizationLogin))
+
+	httpResponse, err := c.get(ctx, coreRateLimitResource, organizationRequestURL)
+	if err != nil {
+		return nil, err
+	}
+	defer httpResponse.Body.Close()
+
+	if httpResponse.StatusCode == http.StatusNotFound {
+		return nil, derr.New(derr.ErrorCodeNotFound, fmt.Sprintf("organization %s not found", organizationLogin))
+	}
+	if err := checkListStatus(httpResponse); err != nil {
+		return nil, err
+	}
+
+	var fetchedOrganization entities.GitHubOrganization
+	if err := json.NewDecoder(httpResponse.Body).Decode(&fetchedOrganization); err != nil {
+		return nil, derr.Wrap(derr.ErrorCodeUpstream, "Failed to decode GitHub organization response", err)
+	}
+	return &fetchedOrganization, nil
+}
+
+// FetchUserOrganizations pages through the organizations_url of username.
+// GitHub only lists public memberships here.
+func (c *GitHubClient) FetchUserOrganizations(
+	ctx context.Context,
+	username string,
+) ([]entities.GitHubOrganization, error) {
+	return fetchAll[entities.GitHubOrganization](ctx, c, fmt.Sprintf("/users/%s/orgs?per_page=%d", url.PathEscape(username), maximumPerPage))
+}
+
+// FetchOrganizationMembers pages through the members of organizationLogin.
+// Private members are only listed when the credentials belong to a member.
+func (c *GitHubClient) FetchOrganizationMembers(
+	ctx context.Context,
+	organizationLogin string,
+) ([]entities.GitHubUser, error) {
+	return fetchAll[entities.GitHubUser](ctx, c, fmt.Sprintf("/orgs/%s/members?per_page=%d", url.PathEscape(organizationLogin), maximumPerPage))
+}
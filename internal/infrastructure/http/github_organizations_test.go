@@ -0,0 +1,53 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"golang.org/x/time/rate"
+
+	derr "github.com/unkabogaton/github-users/internal/domain/errors"
+)
+
+func TestFetchOrganizationAndMembers(t *testing.T) {
+	t.Parallel()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/orgs/github":
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"id": 9919, "login": "github", "name": "GitHub"})
+		case "/orgs/github/members":
+			_ = json.NewEncoder(w).Encode([]map[string]interface{}{{"id": 1, "login": "octocat"}, {"id": 2, "login": "hubot"}})
+		case "/users/octocat/orgs":
+			_ = json.NewEncoder(w).Encode([]map[string]interface{}{{"id": 9919, "login": "github", "description": "How people build software."}})
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client := &GitHubClient{
+		httpClient:  server.Client(),
+		apiBaseURL:  server.URL,
+		rateLimiter: rate.NewLimiter(rate.Inf, 1),
+	}
+
+	organization, err := client.FetchOrganization(context.Background(), "github")
+	require.NoError(t, err)
+	require.Equal(t, 9919, organization.ID)
+	require.Equal(t, "GitHub", organization.ToOrganization().Name)
+
+	members, err := client.FetchOrganizationMembers(context.Background(), "github")
+	require.NoError(t, err)
+	require.Len(t, members, 2)
+
+	organizations, err := client.FetchUserOrganizations(context.Background(), "octocat")
+	require.NoError(t, err)
+	require.Equal(t, "github", organizations[0].Login)
+
+	_, err = client.FetchOrganization(context.Background(), "missing")
+	require.True(t, derr.IsCode(err, derr.ErrorCodeNotFound))
+}
@@ -0,0 +1,131 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"iter"
+	"net/http"
+	"strings"
+
+	derr "github.com/unkabogaton/github-users/internal/domain/errors"
+)
+
+// Paginate walks a GitHub list endpoint page by page, following the
+// rel="next" URL of each response's Link header until there is none, the
+// consumer stops ranging, or ctx is cancelled. pageURL may be absolute, as in
+// the *_url fields GitHub returns, or a path relative to the API base URL.
+func Paginate[T any](ctx context.Context, client *GitHubClient, pageURL string) iter.Seq2[[]T, error] {
+	return func(yield func([]T, error) bool) {
+		nextPageURL := client.resolveURL(pageURL)
+		for nextPageURL != "" {
+			if err := ctx.Err(); err != nil {
+				yield(nil, derr.Wrap(derr.ErrorCodeInternal, "GitHub pagination cancelled", err))
+				return
+			}
+
+			var page []T
+			var err error
+			page, nextPageURL, err = fetchPage[T](ctx, client, coreRateLimitResource, nextPageURL)
+			if err != nil {
+				yield(nil, err)
+				return
+			}
+			if !yield(page, nil) {
+				return
+			}
+		}
+	}
+}
+
+// ForEachPage is the callback form of Paginate. It stops at the first error,
+// whether returned by GitHub or by handlePage.
+func ForEachPage[T any](
+	ctx context.Context,
+	client *GitHubClient,
+	pageURL string,
+	handlePage func(page []T) error,
+) error {
+	for page, err := range Paginate[T](ctx, client, pageURL) {
+		if err != nil {
+			return err
+		}
+		if err := handlePage(page); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// fetchAll collects every page of a list endpoint into one slice.
+func fetchAll[T any](ctx context.Context, client *GitHubClient, pageURL string) ([]T, error) {
+	var items []T
+	err := ForEachPage(ctx, client, pageURL, func(page []T) error {
+		items = append(items, page...)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+// fetchPage fetches and decodes one page of a list endpoint and returns the
+// URL of the next page, or an empty string on the last page.
+func fetchPage[T any](ctx context.Context, client *GitHubClient, resource, pageURL string) ([]T, string, error) {
+	httpResponse, err := client.get(ctx, resource, pageURL)
+	if err != nil {
+		return nil, "", err
+	}
+	defer httpResponse.Body.Close()
+
+	if err := checkListStatus(httpResponse); err != nil {
+		return nil, "", err
+	}
+
+	var page []T
+	if err := json.NewDecoder(httpResponse.Body).Decode(&page); err != nil {
+		return nil, "", derr.Wrap(derr.ErrorCodeUpstream, "Failed to decode GitHub list response", err)
+	}
+	return page, nextLink(httpResponse.Header.Get("Link")), nil
+}
+
+func checkListStatus(httpResponse *http.Response) error {
+	if httpResponse.StatusCode == http.StatusTooManyRequests || httpResponse.StatusCode >= http.StatusInternalServerError {
+		responseBody, _ := io.ReadAll(httpResponse.Body)
+		return derr.Wrap(derr.ErrorCodeRateLimited, "Upstream GitHub rate/server error", fmt.Errorf("status %d body %s", httpResponse.StatusCode, string(responseBody)))
+	}
+	if httpResponse.StatusCode == http.StatusNotFound {
+		return derr.New(derr.ErrorCodeNotFound, "GitHub resource not found")
+	}
+	if httpResponse.StatusCode != http.StatusOK {
+		responseBody, _ := io.ReadAll(httpResponse.Body)
+		return derr.Wrap(derr.ErrorCodeUpstream, "Unexpected GitHub status", fmt.Errorf("status %d body %s", httpResponse.StatusCode, string(responseBody)))
+	}
+	return nil
+}
+
+// nextLink extracts the rel="next" target from a Link header such as
+// <https://api.github.com/users?since=46>; rel="next", <...>; rel="first".
+func nextLink(linkHeader string) string {
+	for _, link := range strings.Split(linkHeader, ",") {
+		segments := strings.Split(link, ";")
+		if len(segments) < 2 {
+			continue
+		}
+		for _, parameter := range segments[1:] {
+			if strings.TrimSpace(parameter) == `rel="next"` {
+				return strings.Trim(strings.TrimSpace(segments[0]), "<>")
+			}
+		}
+	}
+	return ""
+}
+
+func (c *GitHubClient) resolveURL(pageURL string) string {
+	if strings.HasPrefix(pageURL, "/") {
+		return c.apiBaseURL + pageURL
+	}
+	return pageURL
+}
@@ -0,0 +1,109 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"golang.org/x/time/rate"
+
+	"github.com/unkabogaton/github-users/internal/domain/entities"
+)
+
+// newPagedUsersServer serves three pages of two users each from /users and
+// links them together with Link headers the way GitHub does.
+func newPagedUsersServer(t *testing.T) *httptest.Server {
+	t.Helper()
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		since, _ := strconv.Atoi(r.URL.Query().Get("since"))
+		if since < 4 {
+			w.Header().Set("Link", fmt.Sprintf(`<%s/users?per_page=2&since=%d>; rel="next", <%s/users{?since}>; rel="first"`, server.URL, since+2, server.URL))
+		}
+		_ = json.NewEncoder(w).Encode([]map[string]interface{}{
+			{"id": since + 1, "login": fmt.Sprintf("user%d", since+1)},
+			{"id": since + 2, "login": fmt.Sprintf("user%d", since+2)},
+		})
+	}))
+	return server
+}
+
+func TestIterateUsersSince_FollowsLinkHeaders(t *testing.T) {
+	t.Parallel()
+	server := newPagedUsersServer(t)
+	defer server.Close()
+
+	client := &GitHubClient{
+		httpClient:  server.Client(),
+		apiBaseURL:  server.URL,
+		rateLimiter: rate.NewLimiter(rate.Inf, 1),
+	}
+
+	var logins []string
+	for page, err := range client.IterateUsersSince(context.Background(), 0, 2) {
+		require.NoError(t, err)
+		for _, user := range page {
+			logins = append(logins, user.Login)
+		}
+	}
+	require.Equal(t, []string{"user1", "user2", "user3", "user4", "user5", "user6"}, logins)
+}
+
+func TestPaginate_StopsWhenConsumerBreaks(t *testing.T) {
+	t.Parallel()
+	server := newPagedUsersServer(t)
+	defer server.Close()
+
+	client := &GitHubClient{
+		httpClient:  server.Client(),
+		apiBaseURL:  server.URL,
+		rateLimiter: rate.NewLimiter(rate.Inf, 1),
+	}
+
+	pages := 0
+	for _, err := range Paginate[entities.GitHubUser](context.Background(), client, "/users?per_page=2&since=0") {
+		require.NoError(t, err)
+		pages++
+		break
+	}
+	require.Equal(t, 1, pages)
+}
+
+func TestForEachPage_StopsOnCancelledContext(t *testing.T) {
+	t.Parallel()
+	server := newPagedUsersServer(t)
+	defer server.Close()
+
+	client := &GitHubClient{
+		httpClient:  server.Client(),
+		apiBaseURL:  server.URL,
+		rateLimiter: rate.NewLimiter(rate.Inf, 1),
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	pages := 0
+	err := ForEachPage(ctx, client, "/users?per_page=2&since=0", func(page []entities.GitHubUser) error {
+		pages++
+		cancel()
+		return nil
+	})
+	require.Error(t, err)
+	require.Equal(t, 1, pages)
+}
+
+func TestNextLink(t *testing.T) {
+	t.Parallel()
+	require.Equal(t,
+		"https://api.github.com/users?since=46",
+		nextLink(`<https://api.github.com/users{?since}>; rel="first", <https://api.github.com/users?since=46>; rel="next"`),
+	)
+	require.Empty(t, nextLink(`<https://api.github.com/users?page=1>; rel="prev"`))
+	require.Empty(t, nextLink(""))
+}
@@ -0,0 +1,186 @@
+package http
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	derr "github.com/unkabogaton/github-users/internal/domain/errors"
+	"github.com/unkabogaton/github-users/internal/domain/interfaces"
+
+	"golang.org/x/time/rate"
+)
+
+const (
+	coreRateLimitResource   = "core"
+	searchRateLimitResource = "search"
+
+	maximumRateLimitRetries = 3
+
+	// GitHub asks clients hitting a secondary rate limit without a Retry-After
+	// header to wait at least a minute, backing off exponentially after that.
+	secondaryRateLimitBackoff = time.Minute
+)
+
+func (c *GitHubClient) RateLimits() []interfaces.RateLimitStatus {
+	c.rateLimitMutex.Lock()
+	defer c.rateLimitMutex.Unlock()
+
+	statuses := make([]interfaces.RateLimitStatus, 0, len(c.rateLimits))
+	for _, status := range c.rateLimits {
+		statuses = append(statuses, status)
+	}
+	sort.Slice(statuses, func(i, j int) bool { return statuses[i].Resource < statuses[j].Resource })
+	return statuses
+}
+
+func (c *GitHubClient) waitForRateLimit(ctx context.Context, resource string) error {
+	c.rateLimitMutex.Lock()
+	blockedUntil := c.rateLimits[resource].BlockedUntil
+	c.rateLimitMutex.Unlock()
+
+	if wait := time.Until(blockedUntil); wait > 0 {
+		timer := time.NewTimer(wait)
+		defer timer.Stop()
+		select {
+		case <-ctx.Done():
+			return derr.Wrap(derr.ErrorCodeRateLimited, "gave up waiting for GitHub rate limit reset", ctx.Err())
+		case <-timer.C:
+		}
+	}
+
+	rateLimiter, _ := c.limiterFor(resource)
+	if rateLimiter == nil {
+		return nil
+	}
+	if err := rateLimiter.Wait(ctx); err != nil {
+		return derr.Wrap(derr.ErrorCodeInternal, "rate limiter wait failed", err)
+	}
+	return nil
+}
+
+func (c *GitHubClient) blockUntil(resource string, until time.Time) {
+	c.rateLimitMutex.Lock()
+	defer c.rateLimitMutex.Unlock()
+
+	status := c.rateLimitStatusLocked(resource)
+	if until.After(status.BlockedUntil) {
+		status.BlockedUntil = until
+	}
+	c.rateLimits[resource] = status
+}
+
+// recordRateLimit stores the X-RateLimit-* headers of a response and spreads
+// the remaining quota evenly over the time left until the window resets.
+// Credentials that rotate tokens are told about the quota of the token that
+// signed the request instead, since one token's exhaustion says nothing about
+// the others.
+func (c *GitHubClient) recordRateLimit(resource, authorization string, header http.Header) {
+	remainingHeader := header.Get("X-RateLimit-Remaining")
+	if remainingHeader == "" {
+		return
+	}
+	if headerResource := header.Get("X-RateLimit-Resource"); headerResource != "" {
+		resource = headerResource
+	}
+
+	remaining, _ := strconv.Atoi(remainingHeader)
+	limit, _ := strconv.Atoi(header.Get("X-RateLimit-Limit"))
+	used, _ := strconv.Atoi(header.Get("X-RateLimit-Used"))
+	resetUnix, _ := strconv.ParseInt(header.Get("X-RateLimit-Reset"), 10, 64)
+	resetAt := time.Unix(resetUnix, 0)
+
+	rateLimitObserver, rotates := c.credentials.(interfaces.RateLimitObserver)
+
+	c.rateLimitMutex.Lock()
+	status := c.rateLimitStatusLocked(resource)
+	status.Limit = limit
+	status.Remaining = remaining
+	status.Used = used
+	status.ResetAt = resetAt
+	if !rotates && remaining == 0 && resetAt.After(status.BlockedUntil) {
+		status.BlockedUntil = resetAt
+	}
+	c.rateLimits[resource] = status
+	c.rateLimitMutex.Unlock()
+
+	if rotates {
+		rateLimitObserver.ObserveRateLimit(authorization, status)
+		return
+	}
+	rateLimiter, pace := c.limiterFor(resource)
+	if rateLimiter == nil {
+		return
+	}
+	if pace == 0 {
+		pace = rate.Inf
+	}
+	if untilReset := time.Until(resetAt); remaining > 0 && untilReset > 0 {
+		if quotaPace := rate.Limit(float64(remaining) / untilReset.Seconds()); quotaPace < pace {
+			pace = quotaPace
+		}
+	}
+	rateLimiter.SetLimit(pace)
+}
+
+// limiterFor returns the client-side limiter of a rate-limit resource and the
+// ceiling it may be raised back to.
+func (c *GitHubClient) limiterFor(resource string) (*rate.Limiter, rate.Limit) {
+	switch resource {
+	case coreRateLimitResource:
+		return c.rateLimiter, c.maximumRequestRate
+	case searchRateLimitResource:
+		return c.searchRateLimiter, c.maximumSearchRequestRate
+	default:
+		return nil, 0
+	}
+}
+
+func (c *GitHubClient) rateLimitStatusLocked(resource string) interfaces.RateLimitStatus {
+	if c.rateLimits == nil {
+		c.rateLimits = map[string]interfaces.RateLimitStatus{}
+	}
+	status, ok := c.rateLimits[resource]
+	if !ok {
+		status.Resource = resource
+	}
+	return status
+}
+
+// rateLimitBackoff reports whether the response was rejected by a primary or
+// secondary rate limit and, if so, how long to wait before retrying. A 403 that
+// is not rate-limit related is left untouched for the caller to handle.
+func rateLimitBackoff(httpResponse *http.Response, attempt int) (time.Duration, bool) {
+	if httpResponse.StatusCode != http.StatusForbidden && httpResponse.StatusCode != http.StatusTooManyRequests {
+		return 0, false
+	}
+
+	if retryAfter := httpResponse.Header.Get("Retry-After"); retryAfter != "" {
+		if seconds, err := strconv.Atoi(retryAfter); err == nil {
+			return time.Duration(seconds) * time.Second, true
+		}
+		if retryAt, err := http.ParseTime(retryAfter); err == nil {
+			return time.Until(retryAt), true
+		}
+	}
+
+	if httpResponse.Header.Get("X-RateLimit-Remaining") == "0" {
+		resetUnix, _ := strconv.ParseInt(httpResponse.Header.Get("X-RateLimit-Reset"), 10, 64)
+		return time.Until(time.Unix(resetUnix, 0)) + time.Second, true
+	}
+
+	responseBody, _ := io.ReadAll(httpResponse.Body)
+	httpResponse.Body.Close()
+	httpResponse.Body = io.NopCloser(bytes.NewReader(responseBody))
+
+	if httpResponse.StatusCode == http.StatusTooManyRequests ||
+		strings.Contains(strings.ToLower(string(responseBody)), "rate limit") {
+		return secondaryRateLimitBackoff << attempt, true
+	}
+	return 0, false
+}
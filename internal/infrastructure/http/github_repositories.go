@@ -0,0 +1,15 @@
+package http
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+
+	"github.com/unkabogaton/github-users/internal/domain/entities"
+)
+
+// FetchUserRepos pages through the repos_url of username and returns the
+// public repositories it owns.
+func (c *GitHubClient) FetchUserRepos(ctx context.Context, username string) ([]entities.GitHubRepository, error) {
+	return fetchAll[entities.GitHubRepository](ctx, c, fmt.Sprintf("/users/%s/repos?type=owner&per_page=%d", url.PathEscape(username), maximumPerPage))
+}
@@ -0,0 +1,50 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"golang.org/x/time/rate"
+)
+
+func TestFetchUserRepos(t *testing.T) {
+	t.Parallel()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/users/octocat/repos" || r.URL.Query().Get("type") != "owner" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_ = json.NewEncoder(w).Encode([]map[string]interface{}{{
+			"id":               1296269,
+			"name":             "Hello-World",
+			"full_name":        "octocat/Hello-World",
+			"owner":            map[string]interface{}{"id": 1, "login": "octocat"},
+			"language":         "Go",
+			"stargazers_count": 80,
+			"pushed_at":        "2011-01-26T19:06:43Z",
+		}})
+	}))
+	defer server.Close()
+
+	client := &GitHubClient{
+		httpClient:  server.Client(),
+		apiBaseURL:  server.URL,
+		rateLimiter: rate.NewLimiter(rate.Inf, 1),
+	}
+
+	repositories, err := client.FetchUserRepos(context.Background(), "octocat")
+	require.NoError(t, err)
+	require.Len(t, repositories, 1)
+
+	repository := repositories[0].ToRepository()
+	require.Equal(t, 1, repository.OwnerID)
+	require.Equal(t, "octocat", repository.OwnerLogin)
+	require.Equal(t, "Go", repository.Language)
+	require.Equal(t, 80, repository.StargazersCount)
+	require.NotNil(t, repository.PushedAt)
+	require.Nil(t, repository.GitHubCreatedAt)
+}
@@ -0,0 +1,58 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/unkabogaton/github-users/internal/domain/entities"
+	derr "github.com/unkabogaton/github-users/internal/domain/errors"
+)
+
+// SearchUsers runs a /search/users query such as "location:berlin
+// followers:>100 type:user". Requests are paced by the search rate-limit
+// bucket, which is independent of the core API quota.
+func (c *GitHubClient) SearchUsers(
+	ctx context.Context,
+	query string,
+	sort string,
+	order string,
+	page int,
+) (*entities.GitHubUserSearchResult, error) {
+	searchParameters := url.Values{}
+	searchParameters.Set("q", query)
+	if sort != "" {
+		searchParameters.Set("sort", sort)
+	}
+	if order != "" {
+		searchParameters.Set("order", order)
+	}
+	if page > 0 {
+		searchParameters.Set("page", strconv.Itoa(page))
+	}
+	requestURL := c.apiBaseURL + "/search/users?" + searchParameters.Encode()
+
+	httpResponse, err := c.get(ctx, searchRateLimitResource, requestURL)
+	if err != nil {
+		return nil, err
+	}
+	defer httpResponse.Body.Close()
+
+	if httpResponse.StatusCode == http.StatusUnprocessableEntity {
+		responseBody, _ := io.ReadAll(httpResponse.Body)
+		return nil, derr.Wrap(derr.ErrorCodeValidation, "GitHub rejected the search query", fmt.Errorf("status %d body %s", httpResponse.StatusCode, string(responseBody)))
+	}
+	if err := checkListStatus(httpResponse); err != nil {
+		return nil, err
+	}
+
+	var searchResult entities.GitHubUserSearchResult
+	if err := json.NewDecoder(httpResponse.Body).Decode(&searchResult); err != nil {
+		return nil, derr.Wrap(derr.ErrorCodeUpstream, "Failed to decode GitHub search response", err)
+	}
+	return &searchResult, nil
+}
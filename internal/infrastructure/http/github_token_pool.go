@@ -0,0 +1,143 @@
+package http
+
+import (
+	"context"
+	"math"
+	"sync"
+	"time"
+
+	derr "github.com/unkabogaton/github-users/internal/domain/errors"
+	"github.com/unkabogaton/github-users/internal/domain/interfaces"
+)
+
+// TokenPool rotates requests over several personal access tokens. Every
+// request is signed with the token that has the most core quota left, and
+// tokens that ran dry are skipped until their window resets.
+type TokenPool struct {
+	mutex  sync.Mutex
+	tokens []*pooledToken
+	now    func() time.Time
+}
+
+type pooledToken struct {
+	accessToken string
+	observed    bool
+	requests    int
+	limit       int
+	remaining   int
+	resetAt     time.Time
+}
+
+func NewTokenPool(accessTokens []string) interfaces.GitHubCredentials {
+	pool := &TokenPool{now: time.Now}
+	for _, accessToken := range accessTokens {
+		if accessToken != "" {
+			pool.tokens = append(pool.tokens, &pooledToken{accessToken: accessToken})
+		}
+	}
+	return pool
+}
+
+func (pool *TokenPool) Authorization(ctx context.Context) (string, error) {
+	for {
+		pool.mutex.Lock()
+		if len(pool.tokens) == 0 {
+			pool.mutex.Unlock()
+			return "", nil
+		}
+		selectedToken, earliestReset := pool.selectTokenLocked()
+		if selectedToken != nil {
+			selectedToken.requests++
+			if selectedToken.observed && selectedToken.remaining > 0 {
+				// Count the request against the token right away so that
+				// concurrent callers spread out before the response arrives.
+				selectedToken.remaining--
+			}
+			pool.mutex.Unlock()
+			return "token " + selectedToken.accessToken, nil
+		}
+		wait := earliestReset.Sub(pool.now())
+		pool.mutex.Unlock()
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return "", derr.Wrap(derr.ErrorCodeRateLimited, "all GitHub tokens are rate limited", ctx.Err())
+		case <-timer.C:
+		}
+	}
+}
+
+// selectTokenLocked returns the usable token with the most remaining quota.
+// Tokens that have not been used yet rank first. When every token is
+// exhausted it returns nil and the earliest reset time instead.
+func (pool *TokenPool) selectTokenLocked() (*pooledToken, time.Time) {
+	now := pool.now()
+	var selectedToken *pooledToken
+	var earliestReset time.Time
+
+	for _, token := range pool.tokens {
+		if token.observed && token.remaining <= 0 && now.Before(token.resetAt) {
+			if earliestReset.IsZero() || token.resetAt.Before(earliestReset) {
+				earliestReset = token.resetAt
+			}
+			continue
+		}
+		if selectedToken == nil || token.rank(now) > selectedToken.rank(now) {
+			selectedToken = token
+		}
+	}
+	return selectedToken, earliestReset
+}
+
+func (token *pooledToken) rank(now time.Time) int {
+	if !token.observed || !now.Before(token.resetAt) {
+		return math.MaxInt
+	}
+	return token.remaining
+}
+
+func (pool *TokenPool) ObserveRateLimit(authorization string, status interfaces.RateLimitStatus) {
+	if status.Resource != coreRateLimitResource {
+		return
+	}
+
+	pool.mutex.Lock()
+	defer pool.mutex.Unlock()
+
+	for _, token := range pool.tokens {
+		if "token "+token.accessToken != authorization {
+			continue
+		}
+		token.observed = true
+		token.limit = status.Limit
+		token.remaining = status.Remaining
+		token.resetAt = status.ResetAt
+		return
+	}
+}
+
+func (pool *TokenPool) TokenUsage() []interfaces.TokenUsage {
+	pool.mutex.Lock()
+	defer pool.mutex.Unlock()
+
+	usage := make([]interfaces.TokenUsage, 0, len(pool.tokens))
+	for _, token := range pool.tokens {
+		usage = append(usage, interfaces.TokenUsage{
+			Token:     maskToken(token.accessToken),
+			Requests:  token.requests,
+			Limit:     token.limit,
+			Remaining: token.remaining,
+			ResetAt:   token.resetAt,
+		})
+	}
+	return usage
+}
+
+func maskToken(accessToken string) string {
+	if len(accessToken) <= 4 {
+		return "****"
+	}
+	return "****" + accessToken[len(accessToken)-4:]
+}
@@ -0,0 +1,124 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"golang.org/x/time/rate"
+
+	derr "github.com/unkabogaton/github-users/internal/domain/errors"
+	"github.com/unkabogaton/github-users/internal/domain/interfaces"
+)
+
+func observe(pool interfaces.GitHubCredentials, accessToken string, remaining int, resetAt time.Time) {
+	pool.(interfaces.RateLimitObserver).ObserveRateLimit("token "+accessToken, interfaces.RateLimitStatus{
+		Resource:  "core",
+		Limit:     5000,
+		Remaining: remaining,
+		ResetAt:   resetAt,
+	})
+}
+
+func TestTokenPool_PicksTokenWithMostRemainingQuota(t *testing.T) {
+	t.Parallel()
+	pool := NewTokenPool([]string{"first", "second", "third"})
+	resetAt := time.Now().Add(time.Hour)
+	observe(pool, "first", 100, resetAt)
+	observe(pool, "second", 4000, resetAt)
+	observe(pool, "third", 2500, resetAt)
+
+	authorization, err := pool.Authorization(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, "token second", authorization)
+}
+
+func TestTokenPool_SkipsExhaustedTokensUntilReset(t *testing.T) {
+	t.Parallel()
+	pool := NewTokenPool([]string{"first", "second"})
+	tokenPool := pool.(*TokenPool)
+	now := time.Now()
+	tokenPool.now = func() time.Time { return now }
+
+	observe(pool, "first", 0, now.Add(time.Minute))
+	observe(pool, "second", 10, now.Add(time.Hour))
+
+	authorization, err := pool.Authorization(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, "token second", authorization)
+
+	tokenPool.now = func() time.Time { return now.Add(2 * time.Minute) }
+	authorization, err = pool.Authorization(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, "token first", authorization)
+}
+
+func TestTokenPool_WaitsWhenEveryTokenIsExhausted(t *testing.T) {
+	t.Parallel()
+	pool := NewTokenPool([]string{"first", "second"})
+	observe(pool, "first", 0, time.Now().Add(time.Hour))
+	observe(pool, "second", 0, time.Now().Add(time.Hour))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	_, err := pool.Authorization(ctx)
+	require.True(t, derr.IsCode(err, derr.ErrorCodeRateLimited))
+}
+
+func TestTokenPool_TokenUsageMasksTokens(t *testing.T) {
+	t.Parallel()
+	pool := NewTokenPool([]string{"ghp_secret1234"})
+	_, err := pool.Authorization(context.Background())
+	require.NoError(t, err)
+
+	usage := pool.(interfaces.TokenUsageReporter).TokenUsage()
+	require.Len(t, usage, 1)
+	require.Equal(t, "****1234", usage[0].Token)
+	require.Equal(t, 1, usage[0].Requests)
+}
+
+func TestFetchOne_RotatesAwayFromExhaustedToken(t *testing.T) {
+	t.Parallel()
+	var mutex sync.Mutex
+	remainingByToken := map[string]int{"token first": 1, "token second": 4000}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mutex.Lock()
+		authorization := r.Header.Get("Authorization")
+		remaining := remainingByToken[authorization]
+		if remaining > 0 {
+			remaining--
+			remainingByToken[authorization] = remaining
+		}
+		mutex.Unlock()
+
+		w.Header().Set("X-RateLimit-Limit", "5000")
+		w.Header().Set("X-RateLimit-Remaining", strconv.Itoa(remaining))
+		w.Header().Set("X-RateLimit-Reset", strconv.FormatInt(time.Now().Add(time.Hour).Unix(), 10))
+		if remaining == 0 {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"id": 1, "login": "sample_username", "type": authorization})
+	}))
+	defer server.Close()
+
+	client := &GitHubClient{
+		httpClient:  server.Client(),
+		credentials: NewTokenPool([]string{"first", "second"}),
+		apiBaseURL:  server.URL,
+		rateLimiter: rate.NewLimiter(rate.Inf, 1),
+	}
+
+	for range 3 {
+		user, err := client.FetchOne(context.Background(), "sample_username")
+		require.NoError(t, err)
+		require.Equal(t, "token second", user.Type)
+	}
+}
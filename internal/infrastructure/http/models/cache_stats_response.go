@@ -0,0 +1,10 @@
+package models
+
+import "github.com/unkabogaton/github-users/internal/domain/interfaces"
+
+// CacheStatsResponse reports per-cache call totals and, for the caches that
+// can count them, how many keys each holds.
+type CacheStatsResponse struct {
+	Caches []interfaces.CacheStats `json:"caches"`
+	Keys   map[string]int64        `json:"keys"`
+}
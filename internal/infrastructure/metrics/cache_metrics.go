@@ -0,0 +1,93 @@
+package metrics
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/unkabogaton/github-users/internal/domain/interfaces"
+)
+
+// CacheMetrics exports cache calls as Prometheus counters and latency
+// histograms, and keeps running totals per cache for the stats endpoint.
+type CacheMetrics struct {
+	operations *prometheus.CounterVec
+	durations  *prometheus.HistogramVec
+
+	mutex  sync.Mutex
+	totals map[string]*interfaces.CacheStats
+}
+
+func NewCacheMetrics(registerer prometheus.Registerer) *CacheMetrics {
+	cacheMetrics := &CacheMetrics{
+		operations: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "github_users_cache_operations_total",
+			Help: "Cache calls by cache, operation and outcome.",
+		}, []string{"cache", "operation", "outcome"}),
+		durations: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "github_users_cache_operation_duration_seconds",
+			Help:    "Latency of cache calls by cache and operation.",
+			Buckets: []float64{.0001, .00025, .0005, .001, .0025, .005, .01, .025, .05, .1, .25},
+		}, []string{"cache", "operation"}),
+		totals: map[string]*interfaces.CacheStats{},
+	}
+	registerer.MustRegister(cacheMetrics.operations, cacheMetrics.durations)
+	return cacheMetrics
+}
+
+func (cacheMetrics *CacheMetrics) ObserveCacheOperation(
+	cacheName, operation, outcome string,
+	duration time.Duration,
+) {
+	cacheMetrics.operations.WithLabelValues(cacheName, operation, outcome).Inc()
+	cacheMetrics.durations.WithLabelValues(cacheName, operation).Observe(duration.Seconds())
+
+	cacheMetrics.mutex.Lock()
+	defer cacheMetrics.mutex.Unlock()
+
+	stats, ok := cacheMetrics.totals[cacheName]
+	if !ok {
+		stats = &interfaces.CacheStats{Name: cacheName}
+		cacheMetrics.totals[cacheName] = stats
+	}
+	switch outcome {
+	case interfaces.CacheOutcomeHit:
+		stats.Hits++
+	case interfaces.CacheOutcomeStaleHit:
+		stats.StaleHits++
+	case interfaces.CacheOutcomeNotFoundHit:
+		stats.NotFoundHits++
+	case interfaces.CacheOutcomeMiss:
+		stats.Misses++
+	case interfaces.CacheOutcomeError:
+		stats.Errors++
+	}
+	if outcome != interfaces.CacheOutcomeError {
+		switch operation {
+		case interfaces.CacheOperationSet, interfaces.CacheOperationSetNotFound:
+			stats.Sets++
+		case interfaces.CacheOperationDelete, interfaces.CacheOperationClearNotFound:
+			stats.Deletes++
+		}
+	}
+}
+
+// CacheStats returns the totals of every cache seen so far, by name. Stale and
+// not-found hits count as hits in the hit ratio.
+func (cacheMetrics *CacheMetrics) CacheStats() []interfaces.CacheStats {
+	cacheMetrics.mutex.Lock()
+	defer cacheMetrics.mutex.Unlock()
+
+	allStats := make([]interfaces.CacheStats, 0, len(cacheMetrics.totals))
+	for _, stats := range cacheMetrics.totals {
+		snapshot := *stats
+		hits := snapshot.Hits + snapshot.StaleHits + snapshot.NotFoundHits
+		if lookups := hits + snapshot.Misses; lookups > 0 {
+			snapshot.HitRatio = float64(hits) / float64(lookups)
+		}
+		allStats = append(allStats, snapshot)
+	}
+	sort.Slice(allStats, func(i, j int) bool { return allStats[i].Name < allStats[j].Name })
+	return allStats
+}
@@ -0,0 +1,39 @@
+package metrics
+
+import (
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+
+	"github.com/unkabogaton/github-users/internal/domain/interfaces"
+)
+
+func TestCacheMetrics_CountsAndStats(t *testing.T) {
+	t.Parallel()
+	registry := prometheus.NewRegistry()
+	cacheMetrics := NewCacheMetrics(registry)
+
+	for _, outcome := range []string{
+		interfaces.CacheOutcomeHit,
+		interfaces.CacheOutcomeHit,
+		interfaces.CacheOutcomeStaleHit,
+		interfaces.CacheOutcomeMiss,
+		interfaces.CacheOutcomeError,
+	} {
+		cacheMetrics.ObserveCacheOperation("redis", interfaces.CacheOperationGet, outcome, time.Millisecond)
+	}
+	cacheMetrics.ObserveCacheOperation("redis", interfaces.CacheOperationSet, interfaces.CacheOutcomeOK, time.Millisecond)
+	cacheMetrics.ObserveCacheOperation("redis", interfaces.CacheOperationSet, interfaces.CacheOutcomeError, time.Millisecond)
+	cacheMetrics.ObserveCacheOperation("local", interfaces.CacheOperationDelete, interfaces.CacheOutcomeOK, time.Millisecond)
+
+	require.Equal(t, 2.0, testutil.ToFloat64(cacheMetrics.operations.WithLabelValues("redis", "get", "hit")))
+	require.Equal(t, 1, testutil.CollectAndCount(cacheMetrics.durations.WithLabelValues("redis", "get").(prometheus.Histogram)))
+
+	require.Equal(t, []interfaces.CacheStats{
+		{Name: "local", Deletes: 1},
+		{Name: "redis", Hits: 2, StaleHits: 1, Misses: 1, Errors: 2, Sets: 1, HitRatio: 0.75},
+	}, cacheMetrics.CacheStats())
+}
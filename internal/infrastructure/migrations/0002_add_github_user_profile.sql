@@ -0,0 +1,31 @@
+-- +goose Up
+ALTER TABLE github_users
+    ADD COLUMN name              VARCHAR(255) NOT NULL DEFAULT '',
+    ADD COLUMN company           VARCHAR(255) NOT NULL DEFAULT '',
+    ADD COLUMN blog              VARCHAR(255) NOT NULL DEFAULT '',
+    ADD COLUMN location          VARCHAR(255) NOT NULL DEFAULT '',
+    ADD COLUMN email             VARCHAR(255) NOT NULL DEFAULT '',
+    ADD COLUMN bio               VARCHAR(512) NOT NULL DEFAULT '',
+    ADD COLUMN twitter_username  VARCHAR(255) NOT NULL DEFAULT '',
+    ADD COLUMN public_repos      INT NOT NULL DEFAULT 0,
+    ADD COLUMN public_gists      INT NOT NULL DEFAULT 0,
+    ADD COLUMN followers         INT NOT NULL DEFAULT 0,
+    ADD COLUMN following         INT NOT NULL DEFAULT 0,
+    ADD COLUMN github_created_at TIMESTAMP NULL,
+    ADD COLUMN github_updated_at TIMESTAMP NULL;
+
+-- +goose Down
+ALTER TABLE github_users
+    DROP COLUMN name,
+    DROP COLUMN company,
+    DROP COLUMN blog,
+    DROP COLUMN location,
+    DROP COLUMN email,
+    DROP COLUMN bio,
+    DROP COLUMN twitter_username,
+    DROP COLUMN public_repos,
+    DROP COLUMN public_gists,
+    DROP COLUMN followers,
+    DROP COLUMN following,
+    DROP COLUMN github_created_at,
+    DROP COLUMN github_updated_at;
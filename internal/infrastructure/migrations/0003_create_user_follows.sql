@@ -0,0 +1,14 @@
+-- +goose Up
+CREATE TABLE IF NOT EXISTS user_follows (
+    follower_id  BIGINT NOT NULL,
+    following_id BIGINT NOT NULL,
+    created_at   TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at   TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    PRIMARY KEY (follower_id, following_id)
+);
+
+ALTER TABLE user_follows ADD INDEX idx_user_follows_following_id (following_id);
+
+-- +goose Down
+DROP INDEX idx_user_follows_following_id ON user_follows;
+DROP TABLE IF EXISTS user_follows;
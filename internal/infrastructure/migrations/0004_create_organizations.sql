@@ -0,0 +1,31 @@
+-- +goose Up
+CREATE TABLE IF NOT EXISTS organizations (
+    id          BIGINT PRIMARY KEY,
+    login       VARCHAR(255) NOT NULL,
+    node_id     VARCHAR(255),
+    url         VARCHAR(255),
+    html_url    VARCHAR(255),
+    avatar_url  VARCHAR(255),
+    name        VARCHAR(255) NOT NULL DEFAULT '',
+    description VARCHAR(1024) NOT NULL DEFAULT '',
+    created_at  TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at  TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP
+);
+
+ALTER TABLE organizations ADD INDEX idx_organizations_login (login);
+
+CREATE TABLE IF NOT EXISTS user_organizations (
+    user_id         BIGINT NOT NULL,
+    organization_id BIGINT NOT NULL,
+    created_at      TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at      TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    PRIMARY KEY (user_id, organization_id)
+);
+
+ALTER TABLE user_organizations ADD INDEX idx_user_organizations_organization_id (organization_id);
+
+-- +goose Down
+DROP INDEX idx_user_organizations_organization_id ON user_organizations;
+DROP TABLE IF EXISTS user_organizations;
+DROP INDEX idx_organizations_login ON organizations;
+DROP TABLE IF EXISTS organizations;
@@ -0,0 +1,30 @@
+-- +goose Up
+CREATE TABLE IF NOT EXISTS repositories (
+    id                BIGINT PRIMARY KEY,
+    owner_id          BIGINT NOT NULL,
+    owner_login       VARCHAR(255) NOT NULL,
+    name              VARCHAR(255) NOT NULL,
+    full_name         VARCHAR(512) NOT NULL,
+    html_url          VARCHAR(512),
+    description       VARCHAR(1024) NOT NULL DEFAULT '',
+    language          VARCHAR(100) NOT NULL DEFAULT '',
+    fork              TINYINT(1) NOT NULL DEFAULT 0,
+    archived          TINYINT(1) NOT NULL DEFAULT 0,
+    stargazers_count  INT NOT NULL DEFAULT 0,
+    forks_count       INT NOT NULL DEFAULT 0,
+    watchers_count    INT NOT NULL DEFAULT 0,
+    open_issues_count INT NOT NULL DEFAULT 0,
+    pushed_at         TIMESTAMP NULL,
+    github_created_at TIMESTAMP NULL,
+    github_updated_at TIMESTAMP NULL,
+    created_at        TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at        TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP
+);
+
+ALTER TABLE repositories ADD INDEX idx_repositories_owner_stars (owner_id, stargazers_count);
+ALTER TABLE repositories ADD INDEX idx_repositories_owner_language (owner_id, language);
+
+-- +goose Down
+DROP INDEX idx_repositories_owner_language ON repositories;
+DROP INDEX idx_repositories_owner_stars ON repositories;
+DROP TABLE IF EXISTS repositories;
@@ -0,0 +1,7 @@
+-- +goose Up
+ALTER TABLE github_users
+    ADD COLUMN profile_fetched_at TIMESTAMP NULL;
+
+-- +goose Down
+ALTER TABLE github_users
+    DROP COLUMN profile_fetched_at;
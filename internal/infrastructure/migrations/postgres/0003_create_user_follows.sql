@@ -0,0 +1,14 @@
+-- +goose Up
+CREATE TABLE IF NOT EXISTS user_follows (
+    follower_id  BIGINT NOT NULL,
+    following_id BIGINT NOT NULL,
+    created_at   TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at   TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (follower_id, following_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_user_follows_following_id ON user_follows (following_id);
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_user_follows_following_id;
+DROP TABLE IF EXISTS user_follows;
@@ -0,0 +1,29 @@
+-- +goose Up
+ALTER TABLE github_users ADD COLUMN name VARCHAR(255) NOT NULL DEFAULT '';
+ALTER TABLE github_users ADD COLUMN company VARCHAR(255) NOT NULL DEFAULT '';
+ALTER TABLE github_users ADD COLUMN blog VARCHAR(255) NOT NULL DEFAULT '';
+ALTER TABLE github_users ADD COLUMN location VARCHAR(255) NOT NULL DEFAULT '';
+ALTER TABLE github_users ADD COLUMN email VARCHAR(255) NOT NULL DEFAULT '';
+ALTER TABLE github_users ADD COLUMN bio VARCHAR(512) NOT NULL DEFAULT '';
+ALTER TABLE github_users ADD COLUMN twitter_username VARCHAR(255) NOT NULL DEFAULT '';
+ALTER TABLE github_users ADD COLUMN public_repos INT NOT NULL DEFAULT 0;
+ALTER TABLE github_users ADD COLUMN public_gists INT NOT NULL DEFAULT 0;
+ALTER TABLE github_users ADD COLUMN followers INT NOT NULL DEFAULT 0;
+ALTER TABLE github_users ADD COLUMN following INT NOT NULL DEFAULT 0;
+ALTER TABLE github_users ADD COLUMN github_created_at TIMESTAMP NULL;
+ALTER TABLE github_users ADD COLUMN github_updated_at TIMESTAMP NULL;
+
+-- +goose Down
+ALTER TABLE github_users DROP COLUMN name;
+ALTER TABLE github_users DROP COLUMN company;
+ALTER TABLE github_users DROP COLUMN blog;
+ALTER TABLE github_users DROP COLUMN location;
+ALTER TABLE github_users DROP COLUMN email;
+ALTER TABLE github_users DROP COLUMN bio;
+ALTER TABLE github_users DROP COLUMN twitter_username;
+ALTER TABLE github_users DROP COLUMN public_repos;
+ALTER TABLE github_users DROP COLUMN public_gists;
+ALTER TABLE github_users DROP COLUMN followers;
+ALTER TABLE github_users DROP COLUMN following;
+ALTER TABLE github_users DROP COLUMN github_created_at;
+ALTER TABLE github_users DROP COLUMN github_updated_at;